@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/blugelabs/bluge/analysis"
+	phalanxchar "github.com/mosuka/phalanx/analysis/char"
+	"github.com/mosuka/phalanx/analysis/resource"
+	phalanxtoken "github.com/mosuka/phalanx/analysis/token"
+	phalanxtokenizer "github.com/mosuka/phalanx/analysis/tokenizer"
+)
+
+// AnalysisSetting defines named analyzers, char filters, tokenizers and token filters
+// once per index so that fields and queries can refer to them by name.
+type AnalysisSetting struct {
+	CharFilters  map[string]phalanxchar.CharFilterSetting     `json:"char_filters,omitempty"`
+	Tokenizers   map[string]phalanxtokenizer.TokenizerSetting `json:"tokenizers,omitempty"`
+	TokenFilters map[string]phalanxtoken.TokenFilterSetting   `json:"token_filters,omitempty"`
+	Analyzers    map[string]AnalyzerSetting                   `json:"analyzers,omitempty"`
+}
+
+// Resolve replaces the names in the analyzer setting with their definitions.
+func (s AnalysisSetting) Resolve(setting AnalyzerSetting) (AnalyzerSetting, error) {
+	if setting.Name != "" {
+		namedSetting, ok := s.Analyzers[setting.Name]
+		if !ok {
+			return AnalyzerSetting{}, fmt.Errorf("analyzer does not exist: %s", setting.Name)
+		}
+		if namedSetting.Name != "" {
+			return AnalyzerSetting{}, fmt.Errorf("analyzer cannot refer to another analyzer: %s", setting.Name)
+		}
+		setting = namedSetting
+	}
+
+	resolved := AnalyzerSetting{
+		CharFilterSettings:  make([]phalanxchar.CharFilterSetting, len(setting.CharFilterSettings)),
+		TokenizerSetting:    setting.TokenizerSetting,
+		TokenFilterSettings: make([]phalanxtoken.TokenFilterSetting, len(setting.TokenFilterSettings)),
+		Language:            setting.Language,
+	}
+
+	for i, charFilterSetting := range setting.CharFilterSettings {
+		if namedSetting, ok := s.CharFilters[string(charFilterSetting.Name)]; ok {
+			charFilterSetting = namedSetting
+		}
+		resolved.CharFilterSettings[i] = charFilterSetting
+	}
+
+	if namedSetting, ok := s.Tokenizers[string(setting.TokenizerSetting.Name)]; ok {
+		resolved.TokenizerSetting = namedSetting
+	}
+
+	for i, tokenFilterSetting := range setting.TokenFilterSettings {
+		if namedSetting, ok := s.TokenFilters[string(tokenFilterSetting.Name)]; ok {
+			tokenFilterSetting = namedSetting
+		}
+		resolved.TokenFilterSettings[i] = tokenFilterSetting
+	}
+
+	return resolved, nil
+}
+
+// Validate checks that every named analyzer can be built.
+func (s AnalysisSetting) Validate() error {
+	for name := range s.Analyzers {
+		if _, err := s.NewAnalyzer(AnalyzerSetting{Name: name}); err != nil {
+			return fmt.Errorf("invalid analyzer %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// NewAnalyzer resolves the analyzer setting and builds the analyzer.
+func (s AnalysisSetting) NewAnalyzer(setting AnalyzerSetting) (*analysis.Analyzer, error) {
+	resolved, err := s.Resolve(setting)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAnalyzer(resolved)
+}
+
+// AnalyzerCache builds each analyzer once and reuses it.
+// Building an analyzer can be expensive, e.g. compiling regular expressions or loading dictionaries.
+// An analyzer is rebuilt when one of the resources it was built from, such as a word list, is reloaded.
+type AnalyzerCache struct {
+	analysisSetting AnalysisSetting
+	analyzers       map[string]*cachedAnalyzer
+	mutex           sync.RWMutex
+}
+
+// cachedAnalyzer keeps the generations of the resources the analyzer was built from.
+type cachedAnalyzer struct {
+	analyzer    *analysis.Analyzer
+	generations map[string]uint64
+}
+
+func (c *cachedAnalyzer) stale() bool {
+	for uri, generation := range c.generations {
+		if resource.Generation(uri) != generation {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewAnalyzerCache(analysisSetting AnalysisSetting) *AnalyzerCache {
+	return &AnalyzerCache{
+		analysisSetting: analysisSetting,
+		analyzers:       make(map[string]*cachedAnalyzer),
+	}
+}
+
+// Resolve replaces the names in the analyzer setting with their definitions.
+func (c *AnalyzerCache) Resolve(setting AnalyzerSetting) (AnalyzerSetting, error) {
+	return c.analysisSetting.Resolve(setting)
+}
+
+func (c *AnalyzerCache) Get(setting AnalyzerSetting) (*analysis.Analyzer, error) {
+	resolved, err := c.analysisSetting.Resolve(setting)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBytes, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+	key := string(keyBytes)
+
+	c.mutex.RLock()
+	cached, ok := c.analyzers[key]
+	c.mutex.RUnlock()
+	if ok && !cached.stale() {
+		return cached.analyzer, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cached, ok := c.analyzers[key]; ok && !cached.stale() {
+		return cached.analyzer, nil
+	}
+
+	// The generations are taken before building, so that a resource reloaded meanwhile rebuilds the analyzer again.
+	generations := make(map[string]uint64)
+	for _, uri := range resourceUris(resolved) {
+		generations[uri] = resource.Generation(uri)
+	}
+
+	analyzer, err := NewAnalyzer(resolved)
+	if err != nil {
+		return nil, err
+	}
+	c.analyzers[key] = &cachedAnalyzer{
+		analyzer:    analyzer,
+		generations: generations,
+	}
+
+	return analyzer, nil
+}
+
+// resourceUris returns the URIs of the resources in the options of the resolved analyzer setting.
+// The options to read resources are named with the _uri suffix, e.g. stop_tokens_uri.
+func resourceUris(setting AnalyzerSetting) []string {
+	optionsList := make([]map[string]interface{}, 0)
+	for _, charFilterSetting := range setting.CharFilterSettings {
+		optionsList = append(optionsList, charFilterSetting.Options)
+	}
+	optionsList = append(optionsList, setting.TokenizerSetting.Options)
+	for _, tokenFilterSetting := range setting.TokenFilterSettings {
+		optionsList = append(optionsList, tokenFilterSetting.Options)
+	}
+
+	uris := make([]string, 0)
+	for _, options := range optionsList {
+		for name, value := range options {
+			if uri, ok := value.(string); ok && strings.HasSuffix(name, "_uri") {
+				uris = append(uris, uri)
+			}
+		}
+	}
+
+	return uris
+}
@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	phalanxchar "github.com/mosuka/phalanx/analysis/char"
+	"github.com/mosuka/phalanx/analysis/resource"
+	phalanxtoken "github.com/mosuka/phalanx/analysis/token"
+	phalanxtokenizer "github.com/mosuka/phalanx/analysis/tokenizer"
+)
+
+func TestAnalyzerCache(t *testing.T) {
+	analysisFile := "../../testdata/test_analysis.json"
+
+	bytes, err := ioutil.ReadFile(analysisFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	var analysisSetting AnalysisSetting
+	if err := json.Unmarshal(bytes, &analysisSetting); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := analysisSetting.Validate(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	var analyzerSetting AnalyzerSetting
+	if err := json.Unmarshal([]byte(`"my_analyzer"`), &analyzerSetting); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	cache := NewAnalyzerCache(analysisSetting)
+
+	analyzer1, err := cache.Get(analyzerSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := analyzer1.Analyze([]byte("The ｑｕｉｃｋ fox"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"quick", "fox"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	analyzer2, err := cache.Get(analyzerSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if analyzer1 != analyzer2 {
+		t.Fatalf("analyzer is not cached\n")
+	}
+
+	if _, err := cache.Get(AnalyzerSetting{Name: "unknown_analyzer"}); err == nil {
+		t.Fatalf("expected an error for an unknown analyzer\n")
+	}
+}
+
+func TestAnalyzerCacheReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stop_tokens.txt")
+	if err := ioutil.WriteFile(path, []byte("a\nthe\n"), 0644); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	stopAnalyzerSetting := AnalyzerSetting{
+		CharFilterSettings: []phalanxchar.CharFilterSetting{},
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.UnicodeTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.StopTokensTokenFilter,
+				Options: map[string]interface{}{
+					"stop_tokens_uri": "file://" + path,
+				},
+			},
+		},
+	}
+	unicodeAnalyzerSetting := AnalyzerSetting{
+		CharFilterSettings: []phalanxchar.CharFilterSetting{},
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.UnicodeTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{},
+	}
+
+	cache := NewAnalyzerCache(AnalysisSetting{})
+
+	stopAnalyzer1, err := cache.Get(stopAnalyzerSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	unicodeAnalyzer1, err := cache.Get(unicodeAnalyzerSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("a\nthe\nfox\n"), 0644); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if _, err := resource.Reload(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// Only the analyzer using the reloaded resource is rebuilt.
+	stopAnalyzer2, err := cache.Get(stopAnalyzerSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if stopAnalyzer1 == stopAnalyzer2 {
+		t.Fatalf("analyzer is not rebuilt\n")
+	}
+	unicodeAnalyzer2, err := cache.Get(unicodeAnalyzerSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if unicodeAnalyzer1 != unicodeAnalyzer2 {
+		t.Fatalf("analyzer is rebuilt\n")
+	}
+
+	tokens := stopAnalyzer2.Analyze([]byte("the quick fox"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"quick"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/blugelabs/bluge/analysis"
+	phalanxtoken "github.com/mosuka/phalanx/analysis/token"
+	phalanxtokenizer "github.com/mosuka/phalanx/analysis/tokenizer"
+)
+
+// Names for analysis.TokenType.
+var TokenType_name = map[analysis.TokenType]string{
+	analysis.AlphaNumeric: "<ALPHANUM>",
+	analysis.Ideographic:  "<IDEOGRAPHIC>",
+	analysis.Numeric:      "<NUM>",
+	analysis.DateTime:     "<DATETIME>",
+	analysis.Shingle:      "<SHINGLE>",
+	analysis.Single:       "<SINGLE>",
+	analysis.Double:       "<DOUBLE>",
+	analysis.Boolean:      "<BOOLEAN>",
+}
+
+// NewStandardAnalyzerSetting returns the setting of the analyzer used when no analyzer is specified.
+// It is the same as the StandardAnalyzer of bluge.
+func NewStandardAnalyzerSetting() AnalyzerSetting {
+	return AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.UnicodeTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.LowerCaseTokenFilter,
+			},
+		},
+	}
+}
+
+// AnalyzedToken is a token in the output of an analyzer.
+// Positions are the same as the positions in the index and start at 1.
+// Offsets are byte offsets in the text after char filters are applied.
+type AnalyzedToken struct {
+	Term     string `json:"term"`
+	Position int    `json:"position"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Type     string `json:"type"`
+	Keyword  bool   `json:"keyword"`
+}
+
+// AnalyzeStage is the output of a char filter, the tokenizer or a token filter.
+// Char filters output text, and the others output tokens.
+type AnalyzeStage struct {
+	Name   string          `json:"name"`
+	Text   string          `json:"text,omitempty"`
+	Tokens []AnalyzedToken `json:"tokens,omitempty"`
+}
+
+func makeAnalyzedTokens(tokenStream analysis.TokenStream) []AnalyzedToken {
+	tokens := make([]AnalyzedToken, 0, len(tokenStream))
+	position := 0
+	for _, token := range tokenStream {
+		position += token.PositionIncr
+		tokenType, ok := TokenType_name[token.Type]
+		if !ok {
+			tokenType = fmt.Sprintf("<%d>", token.Type)
+		}
+		tokens = append(tokens, AnalyzedToken{
+			Term:     string(token.Term),
+			Position: position,
+			Start:    token.Start,
+			End:      token.End,
+			Type:     tokenType,
+			Keyword:  token.KeyWord,
+		})
+	}
+
+	return tokens
+}
+
+// Analyze analyzes the text and returns the tokens.
+func Analyze(analyzer *analysis.Analyzer, text string) []AnalyzedToken {
+	return makeAnalyzedTokens(analyzer.Analyze([]byte(text)))
+}
+
+// AnalyzeStages analyzes the text and returns the tokens with the output of each stage.
+// The setting must be the resolved setting that the analyzer was built from;
+// it is only used to name the stages.
+func AnalyzeStages(analyzer *analysis.Analyzer, setting AnalyzerSetting, text string) ([]AnalyzedToken, []AnalyzeStage) {
+	stages := make([]AnalyzeStage, 0, len(analyzer.CharFilters)+1+len(analyzer.TokenFilters))
+
+	// Filters may modify the tokens in place, so the output of each stage is copied.
+	input := []byte(text)
+	for i, charFilter := range analyzer.CharFilters {
+		name := fmt.Sprintf("char_filter[%d]", i)
+		if i < len(setting.CharFilterSettings) {
+			name = fmt.Sprintf("char_filter:%s", setting.CharFilterSettings[i].Name)
+		}
+		input = charFilter.Filter(input)
+		stages = append(stages, AnalyzeStage{
+			Name: name,
+			Text: string(input),
+		})
+	}
+
+	tokenizerName := fmt.Sprintf("tokenizer:%s", setting.TokenizerSetting.Name)
+	if setting.Language != "" {
+		tokenizerName = fmt.Sprintf("language:%s", setting.Language)
+	}
+	tokenStream := analyzer.Tokenizer.Tokenize(input)
+	stages = append(stages, AnalyzeStage{
+		Name:   tokenizerName,
+		Tokens: makeAnalyzedTokens(tokenStream),
+	})
+
+	for i, tokenFilter := range analyzer.TokenFilters {
+		name := fmt.Sprintf("token_filter[%d]", i)
+		if i < len(setting.TokenFilterSettings) {
+			name = fmt.Sprintf("token_filter:%s", setting.TokenFilterSettings[i].Name)
+		}
+		tokenStream = tokenFilter.Filter(tokenStream)
+		stages = append(stages, AnalyzeStage{
+			Name:   name,
+			Tokens: makeAnalyzedTokens(tokenStream),
+		})
+	}
+
+	return makeAnalyzedTokens(tokenStream), stages
+}
@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeStages(t *testing.T) {
+	var analyzerSetting AnalyzerSetting
+	if err := json.Unmarshal([]byte(`{
+		"char_filters": [{"name": "html"}],
+		"tokenizer": {"name": "unicode"},
+		"token_filters": [{"name": "lower_case"}, {"name": "stop_tokens", "options": {"stop_tokens": ["the"]}}]
+	}`), &analyzerSetting); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	analyzer, err := NewAnalyzer(analyzerSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens, stages := AnalyzeStages(analyzer, analyzerSetting, "<b>The</b> Quick fox")
+
+	expected := []AnalyzedToken{
+		{Term: "quick", Position: 2, Start: 6, End: 11, Type: "<ALPHANUM>"},
+		{Term: "fox", Position: 3, Start: 12, End: 15, Type: "<ALPHANUM>"},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Fatalf("`%v` is not `%v`\n", tokens, expected)
+	}
+
+	if !reflect.DeepEqual(Analyze(analyzer, "<b>The</b> Quick fox"), expected) {
+		t.Fatalf("`%v` is not `%v`\n", Analyze(analyzer, "<b>The</b> Quick fox"), expected)
+	}
+
+	expectedNames := []string{"char_filter:html", "tokenizer:unicode", "token_filter:lower_case", "token_filter:stop_tokens"}
+	actualNames := make([]string, len(stages))
+	for i, stage := range stages {
+		actualNames[i] = stage.Name
+	}
+	if !reflect.DeepEqual(actualNames, expectedNames) {
+		t.Fatalf("`%v` is not `%v`\n", actualNames, expectedNames)
+	}
+
+	// The output of the tokenizer is not modified by the following filters.
+	if stages[1].Tokens[0].Term != "The" {
+		t.Fatalf("`%v` is not `%v`\n", stages[1].Tokens[0].Term, "The")
+	}
+	if stages[2].Tokens[0].Term != "the" {
+		t.Fatalf("`%v` is not `%v`\n", stages[2].Tokens[0].Term, "the")
+	}
+}
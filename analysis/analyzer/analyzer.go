@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/blugelabs/bluge/analysis"
@@ -13,14 +14,58 @@ import (
 )
 
 type AnalyzerSetting struct {
+	// Name refers to an analyzer defined in the analysis settings of the index.
+	// It is written in JSON as a string instead of an object.
+	Name                string                            `json:"-"`
 	CharFilterSettings  []phalanxchar.CharFilterSetting   `json:"char_filters"`
 	TokenizerSetting    phalanxtokenizer.TokenizerSetting `json:"tokenizer"`
 	TokenFilterSettings []phalanxtoken.TokenFilterSetting `json:"token_filters"`
+	// Language uses the analyzer of the language (ISO 639-1) instead of the char filters,
+	// tokenizer and token filters. "auto" detects the language of each text.
+	Language string `json:"language,omitempty"`
+}
+
+func (s *AnalyzerSetting) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = AnalyzerSetting{Name: name}
+		return nil
+	}
+
+	type analyzerSetting AnalyzerSetting
+	var setting analyzerSetting
+	if err := json.Unmarshal(data, &setting); err != nil {
+		return err
+	}
+	*s = AnalyzerSetting(setting)
+
+	return nil
+}
+
+func (s AnalyzerSetting) MarshalJSON() ([]byte, error) {
+	if s.Name != "" {
+		return json.Marshal(s.Name)
+	}
+
+	type analyzerSetting AnalyzerSetting
+	return json.Marshal(analyzerSetting(s))
 }
 
 func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 	var err error
 
+	// Named analyzers must be resolved with the analysis settings first.
+	if config.Name != "" {
+		return nil, fmt.Errorf("analyzer is not resolved: %s", config.Name)
+	}
+
+	if config.Language != "" {
+		if len(config.CharFilterSettings) > 0 || config.TokenizerSetting.Name != "" || len(config.TokenFilterSettings) > 0 {
+			return nil, fmt.Errorf("language cannot be used with char filters, tokenizer or token filters")
+		}
+		return newLanguageAnalyzer(config.Language)
+	}
+
 	// Char filter.
 	charFilters := make([]analysis.CharFilter, 0)
 	charFilterSettings := config.CharFilterSettings
@@ -63,6 +108,12 @@ func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 		case phalanxtoken.CamelCaseTokenFilter:
 			tokenFilter := token.NewCamelCaseFilter()
 			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.CJKBigramTokenFilter:
+			tokenFilter, err := phalanxtoken.NewCJKBigramFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
 		case phalanxtoken.DictionaryCompoundTokenFilter:
 			tokenFilter, err := phalanxtoken.NewDictionaryCompoundFilterWithOptions(tokenFilterSetting.Options)
 			if err != nil {
@@ -81,6 +132,27 @@ func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 				return nil, err
 			}
 			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.HunspellTokenFilter:
+			tokenFilter, err := phalanxtoken.NewHunspellFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.JapaneseKatakanaStemTokenFilter:
+			tokenFilter, err := phalanxtoken.NewKatakanaStemFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.JapaneseNumberTokenFilter:
+			tokenFilter := phalanxtoken.NewJapaneseNumberFilter()
+			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.JapaneseReadingFormTokenFilter:
+			tokenFilter, err := phalanxtoken.NewReadingFormFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
 		case phalanxtoken.KeywordMarkerTokenFilter:
 			tokenFilter, err := phalanxtoken.NewKeyWordMarkerFilterWithOptions(tokenFilterSetting.Options)
 			if err != nil {
@@ -102,6 +174,24 @@ func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 				return nil, err
 			}
 			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.PatternCaptureTokenFilter:
+			tokenFilter, err := phalanxtoken.NewPatternCaptureFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.PatternReplaceTokenFilter:
+			tokenFilter, err := phalanxtoken.NewPatternReplaceFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.PhoneticTokenFilter:
+			tokenFilter, err := phalanxtoken.NewPhoneticFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
 		case phalanxtoken.PorterStemmerTokenFilter:
 			tokenFilter := token.NewPorterStemmer()
 			tokenFilters = append(tokenFilters, tokenFilter)
@@ -114,12 +204,30 @@ func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 				return nil, err
 			}
 			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.StemmerTokenFilter:
+			tokenFilter, err := phalanxtoken.NewStemmerFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
 		case phalanxtoken.StopTokensTokenFilter:
 			tokenFilter, err := phalanxtoken.NewStopTokensFilterWithOptions(tokenFilterSetting.Options)
 			if err != nil {
 				return nil, err
 			}
 			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.SynonymTokenFilter:
+			tokenFilter, err := phalanxtoken.NewSynonymFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.SynonymGraphTokenFilter:
+			tokenFilter, err := phalanxtoken.NewSynonymGraphFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
 		case phalanxtoken.TruncateTokenFilter:
 			tokenFilter, err := phalanxtoken.NewTruncateTokenFilterWithOptions(tokenFilterSetting.Options)
 			if err != nil {
@@ -135,6 +243,12 @@ func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 		case phalanxtoken.UniqueTermTokenFilter:
 			tokenFilter := token.NewUniqueTermFilter()
 			tokenFilters = append(tokenFilters, tokenFilter)
+		case phalanxtoken.WordDelimiterGraphTokenFilter:
+			tokenFilter, err := phalanxtoken.NewWordDelimiterGraphFilterWithOptions(tokenFilterSetting.Options)
+			if err != nil {
+				return nil, err
+			}
+			tokenFilters = append(tokenFilters, tokenFilter)
 		default:
 			err := fmt.Errorf("unknown token filter: %v", tokenFilterSetting.Name)
 			return nil, err
@@ -150,6 +264,24 @@ func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 		if err != nil {
 			return nil, err
 		}
+	case phalanxtokenizer.CharGroupTokenizer:
+		var err error
+		fieldTokenizer, err = phalanxtokenizer.NewCharGroupTokenizerWithOptions(tokenizerSetting.Options)
+		if err != nil {
+			return nil, err
+		}
+	case phalanxtokenizer.CJKBigramTokenizer:
+		var err error
+		fieldTokenizer, err = phalanxtokenizer.NewCJKBigramTokenizerWithOptions(tokenizerSetting.Options)
+		if err != nil {
+			return nil, err
+		}
+	case phalanxtokenizer.EdgeNgramTokenizer:
+		var err error
+		fieldTokenizer, err = phalanxtokenizer.NewEdgeNgramTokenizerWithOptions(tokenizerSetting.Options)
+		if err != nil {
+			return nil, err
+		}
 	case phalanxtokenizer.ExceptionTokenizer:
 		var err error
 		fieldTokenizer, err = phalanxtokenizer.NewExceptionsTokenizerWithOptions(tokenizerSetting.Options)
@@ -164,6 +296,18 @@ func NewAnalyzer(config AnalyzerSetting) (*analysis.Analyzer, error) {
 		}
 	case phalanxtokenizer.LetterTokenizer:
 		fieldTokenizer = tokenizer.NewLetterTokenizer()
+	case phalanxtokenizer.NgramTokenizer:
+		var err error
+		fieldTokenizer, err = phalanxtokenizer.NewNgramTokenizerWithOptions(tokenizerSetting.Options)
+		if err != nil {
+			return nil, err
+		}
+	case phalanxtokenizer.PathHierarchyTokenizer:
+		var err error
+		fieldTokenizer, err = phalanxtokenizer.NewPathHierarchyTokenizerWithOptions(tokenizerSetting.Options)
+		if err != nil {
+			return nil, err
+		}
 	case phalanxtokenizer.RegexpTokenizer:
 		var err error
 		fieldTokenizer, err = phalanxtokenizer.NewRegexpTokenizerWithOptions(tokenizerSetting.Options)
@@ -87,7 +87,7 @@ func NewAnalyzerDetector(logger *zap.Logger) (*AnalyzerDetector, error) {
 
 	analyzers := map[string]*analysis.Analyzer{
 		"ar": ar.Analyzer(),    // ar
-		"br": bg.Analyzer(),    // bg
+		"bg": bg.Analyzer(),    // bg
 		"ca": ca.Analyzer(),    // ca
 		"cs": cs.Analyzer(),    // cs
 		"da": da.Analyzer(),    // da
@@ -147,6 +147,11 @@ func (d *AnalyzerDetector) detectLanguage(text string) (string, bool) {
 	return iso639_1, exists
 }
 
+// DetectLanguage returns the ISO 639-1 code of the language of the text.
+func (d *AnalyzerDetector) DetectLanguage(text string) (string, bool) {
+	return d.detectLanguage(text)
+}
+
 func (d *AnalyzerDetector) getAnalyzer(iso639_1 string) *analysis.Analyzer {
 	langAnalyzer, ok := d.analyzers[iso639_1]
 	if !ok {
@@ -166,3 +171,10 @@ func (d *AnalyzerDetector) DetectAnalyzer(text string) *analysis.Analyzer {
 
 	return d.getAnalyzer(iso639_1)
 }
+
+// LanguageAnalyzer returns the analyzer of the language.
+// ok is false if the language is not supported.
+func (d *AnalyzerDetector) LanguageAnalyzer(iso639_1 string) (*analysis.Analyzer, bool) {
+	langAnalyzer, ok := d.analyzers[iso639_1]
+	return langAnalyzer, ok
+}
@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/blugelabs/bluge/analysis/analyzer"
+	"go.uber.org/zap"
+)
+
+// AutoLanguage detects the language of each text and analyzes it with the analyzer of the language.
+const AutoLanguage = "auto"
+
+// The language models are large, so the detector is shared by the node.
+var (
+	sharedDetector     *AnalyzerDetector
+	sharedDetectorErr  error
+	sharedDetectorOnce sync.Once
+)
+
+// GetAnalyzerDetector returns the analyzer detector shared by the node.
+func GetAnalyzerDetector() (*AnalyzerDetector, error) {
+	sharedDetectorOnce.Do(func() {
+		sharedDetector, sharedDetectorErr = NewAnalyzerDetector(zap.NewNop())
+	})
+	return sharedDetector, sharedDetectorErr
+}
+
+// DetectLanguageAnalyzer returns the ISO 639-1 code of the language of the text and its analyzer.
+// The language is empty and the standard analyzer is returned if the language is not detected.
+func DetectLanguageAnalyzer(text string) (string, *analysis.Analyzer, error) {
+	detector, err := GetAnalyzerDetector()
+	if err != nil {
+		return "", nil, err
+	}
+
+	language, ok := detector.DetectLanguage(text)
+	if !ok {
+		return "", analyzer.NewStandardAnalyzer(), nil
+	}
+
+	langAnalyzer, ok := detector.LanguageAnalyzer(language)
+	if !ok {
+		return language, analyzer.NewStandardAnalyzer(), nil
+	}
+
+	return language, langAnalyzer, nil
+}
+
+// languageDetectTokenizer analyzes the whole input with the analyzer of the detected language.
+type languageDetectTokenizer struct{}
+
+func (t *languageDetectTokenizer) Tokenize(input []byte) analysis.TokenStream {
+	_, langAnalyzer, err := DetectLanguageAnalyzer(string(input))
+	if err != nil {
+		langAnalyzer = analyzer.NewStandardAnalyzer()
+	}
+
+	return langAnalyzer.Analyze(input)
+}
+
+func newLanguageAnalyzer(language string) (*analysis.Analyzer, error) {
+	if language == AutoLanguage {
+		return &analysis.Analyzer{
+			Tokenizer: &languageDetectTokenizer{},
+		}, nil
+	}
+
+	detector, err := GetAnalyzerDetector()
+	if err != nil {
+		return nil, err
+	}
+
+	langAnalyzer, ok := detector.LanguageAnalyzer(language)
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", language)
+	}
+
+	return langAnalyzer, nil
+}
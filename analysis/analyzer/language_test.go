@@ -0,0 +1,31 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLanguageAnalyzer(t *testing.T) {
+	autoAnalyzer, err := NewAnalyzer(AnalyzerSetting{Language: AutoLanguage})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := autoAnalyzer.Analyze([]byte("The quick brown foxes are jumping over the lazy dogs."))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"quick", "brown", "fox", "jump", "lazi", "dog"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	if _, err := NewAnalyzer(AnalyzerSetting{Language: "ja"}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := NewAnalyzer(AnalyzerSetting{Language: "xx"}); err == nil {
+		t.Fatalf("expected an error for an unsupported language\n")
+	}
+}
@@ -0,0 +1,148 @@
+package resource
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultReloadInterval is the interval to check the cached resources for changes.
+const DefaultReloadInterval = 60 * time.Second
+
+// The resources are cached once per node.
+var defaultCache = NewCache()
+
+// Cache keeps the loaded resources so that analyzers do not read them every time they are built.
+// The generation of a resource is incremented whenever it changes,
+// so that only the analyzers built from the old resource are rebuilt.
+type Cache struct {
+	resources   map[string][]byte
+	generations map[string]uint64
+	mutex       sync.RWMutex
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		resources:   make(map[string][]byte),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Get returns the resource at the URI, loading it on first use.
+func (c *Cache) Get(uri string) ([]byte, error) {
+	c.mutex.RLock()
+	data, ok := c.resources[uri]
+	c.mutex.RUnlock()
+	if ok {
+		return data, nil
+	}
+
+	data, err := Load(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.resources[uri] = data
+
+	return data, nil
+}
+
+// Generation returns the number of times the resource at the URI has changed.
+func (c *Cache) Generation(uri string) uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.generations[uri]
+}
+
+// Reload loads the cached resources again and returns the URIs of the changed resources.
+// A resource that cannot be loaded keeps the previous content.
+func (c *Cache) Reload() ([]string, error) {
+	c.mutex.RLock()
+	uris := make([]string, 0, len(c.resources))
+	for uri := range c.resources {
+		uris = append(uris, uri)
+	}
+	c.mutex.RUnlock()
+
+	var lastErr error
+	changed := make([]string, 0)
+	for _, uri := range uris {
+		data, err := Load(uri)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		c.mutex.Lock()
+		if !bytes.Equal(c.resources[uri], data) {
+			c.resources[uri] = data
+			c.generations[uri]++
+			changed = append(changed, uri)
+		}
+		c.mutex.Unlock()
+	}
+
+	return changed, lastErr
+}
+
+// Get returns the resource at the URI from the cache of the node.
+func Get(uri string) ([]byte, error) {
+	return defaultCache.Get(uri)
+}
+
+// Generation returns the generation of the resource at the URI in the cache of the node.
+func Generation(uri string) uint64 {
+	return defaultCache.Generation(uri)
+}
+
+// Reload reloads the cache of the node.
+func Reload() ([]string, error) {
+	return defaultCache.Reload()
+}
+
+// Reloader reloads the cache of the node periodically.
+type Reloader struct {
+	interval time.Duration
+	stop     chan bool
+	logger   *zap.Logger
+}
+
+func NewReloader(interval time.Duration, logger *zap.Logger) *Reloader {
+	return &Reloader{
+		interval: interval,
+		stop:     make(chan bool),
+		logger:   logger.Named("resource"),
+	}
+}
+
+func (r *Reloader) Start() {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				changed, err := Reload()
+				if err != nil {
+					r.logger.Warn(err.Error())
+				}
+				for _, uri := range changed {
+					r.logger.Info("resource has been reloaded", zap.String("uri", uri))
+				}
+			}
+		}
+	}()
+}
+
+func (r *Reloader) Stop() {
+	r.stop <- true
+}
@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCacheReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stop_tokens.txt")
+	uri := "file://" + path
+
+	if err := ioutil.WriteFile(path, []byte("# stop tokens\na\nthe\n"), 0644); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	cache := NewCache()
+
+	data, err := cache.Get(uri)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	actual := Lines(data)
+	expected := []string{"a", "the"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	// Nothing has changed.
+	changed, err := cache.Reload()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(changed) != 0 || cache.Generation(uri) != 0 {
+		t.Fatalf("unexpected reload: %v\n", changed)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("a\nthe\nof\n"), 0644); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	changed, err = cache.Reload()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(changed, []string{uri}) {
+		t.Fatalf("`%v` is not `%v`\n", changed, []string{uri})
+	}
+	if cache.Generation(uri) != 1 {
+		t.Fatalf("`%v` is not `%v`\n", cache.Generation(uri), 1)
+	}
+
+	data, err = cache.Get(uri)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	actual = Lines(data)
+	expected = []string{"a", "the", "of"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestCacheReloadGenerations(t *testing.T) {
+	dir := t.TempDir()
+	path1 := filepath.Join(dir, "stop_tokens1.txt")
+	path2 := filepath.Join(dir, "stop_tokens2.txt")
+	uri1 := "file://" + path1
+	uri2 := "file://" + path2
+
+	for _, path := range []string{path1, path2} {
+		if err := ioutil.WriteFile(path, []byte("a\nthe\n"), 0644); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	cache := NewCache()
+	for _, uri := range []string{uri1, uri2} {
+		if _, err := cache.Get(uri); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	if err := ioutil.WriteFile(path1, []byte("a\nthe\nof\n"), 0644); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if _, err := cache.Reload(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// Only the generation of the changed resource is incremented.
+	if cache.Generation(uri1) != 1 {
+		t.Fatalf("`%v` is not `%v`\n", cache.Generation(uri1), 1)
+	}
+	if cache.Generation(uri2) != 0 {
+		t.Fatalf("`%v` is not `%v`\n", cache.Generation(uri2), 0)
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	u, err := url.Parse("s3://my-bucket/dict/stop_tokens.txt")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if objectKey(u) != "dict/stop_tokens.txt" {
+		t.Fatalf("`%v` is not `%v`\n", objectKey(u), "dict/stop_tokens.txt")
+	}
+}
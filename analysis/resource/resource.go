@@ -0,0 +1,103 @@
+package resource
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/mosuka/phalanx/clients"
+	"github.com/mosuka/phalanx/errors"
+)
+
+// requestTimeout is the timeout to read a resource from an object storage.
+const requestTimeout = 10 * time.Second
+
+// Load reads the resource, such as a word list or synonym rules, at the URI.
+// The URI can be file://, s3:// or minio://.
+func Load(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "file":
+		return ioutil.ReadFile(u.Path)
+	case "s3":
+		return loadS3(uri, u)
+	case "minio":
+		return loadMinio(uri, u)
+	default:
+		return nil, errors.ErrInvalidUri
+	}
+}
+
+// objectKey returns the key of the object in the bucket, i.e. the path without the leading slash.
+func objectKey(u *url.URL) string {
+	return strings.TrimPrefix(u.Path, "/")
+}
+
+func loadS3(uri string, u *url.URL) ([]byte, error) {
+	client, err := clients.NewS3ClientWithUri(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(u.Host),
+		Key:    aws.String(objectKey(u)),
+	}
+
+	object, err := client.GetObject(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Body.Close()
+
+	return ioutil.ReadAll(object.Body)
+}
+
+func loadMinio(uri string, u *url.URL) ([]byte, error) {
+	client, err := clients.NewMinioClientWithUri(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	object, err := client.GetObject(ctx, u.Host, objectKey(u), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	return ioutil.ReadAll(object)
+}
+
+// Lines splits the resource into lines.
+// Empty lines and comment lines starting with # are skipped.
+func Lines(data []byte) []string {
+	lines := make([]string, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := string(bytes.TrimSpace(scanner.Bytes()))
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
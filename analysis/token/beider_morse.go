@@ -0,0 +1,159 @@
+package token
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	ApproxRuleType = "approx"
+	ExactRuleType  = "exact"
+)
+
+// The max number of codes of a word, as the alternatives of each letter multiply.
+const beiderMorseMaxCodes = 20
+
+// beiderMorseRule maps a sequence of letters to its alternative phonemes.
+// The rule applies only if the next letter is one of next, or next is empty.
+type beiderMorseRule struct {
+	pattern      string
+	next         string
+	alternatives []string
+}
+
+// beiderMorseRules is a simplified set of the generic rules of the Beider-Morse Phonetic Matching.
+// Longer patterns come first.
+var beiderMorseRules = []beiderMorseRule{
+	{pattern: "tsch", alternatives: []string{"tS"}},
+	{pattern: "dzh", alternatives: []string{"dZ"}},
+	{pattern: "sch", alternatives: []string{"S", "sk"}},
+	{pattern: "tch", alternatives: []string{"tS"}},
+	{pattern: "tsh", alternatives: []string{"tS"}},
+	{pattern: "ch", alternatives: []string{"tS", "x", "k"}},
+	{pattern: "ck", alternatives: []string{"k"}},
+	{pattern: "cz", alternatives: []string{"tS"}},
+	{pattern: "ph", alternatives: []string{"f"}},
+	{pattern: "th", alternatives: []string{"t"}},
+	{pattern: "sh", alternatives: []string{"S"}},
+	{pattern: "sz", alternatives: []string{"S", "s"}},
+	{pattern: "zh", alternatives: []string{"Z"}},
+	{pattern: "kh", alternatives: []string{"x"}},
+	{pattern: "gh", alternatives: []string{"g"}},
+	{pattern: "dz", alternatives: []string{"dz"}},
+	{pattern: "ts", alternatives: []string{"ts"}},
+	{pattern: "tz", alternatives: []string{"ts"}},
+	{pattern: "qu", alternatives: []string{"kv", "k"}},
+	{pattern: "ou", alternatives: []string{"u"}},
+	{pattern: "oo", alternatives: []string{"u"}},
+	{pattern: "ee", alternatives: []string{"i"}},
+	{pattern: "ie", alternatives: []string{"i"}},
+	{pattern: "ei", alternatives: []string{"aj", "i"}},
+	{pattern: "ey", alternatives: []string{"aj", "i"}},
+	{pattern: "ai", alternatives: []string{"aj", "e"}},
+	{pattern: "ay", alternatives: []string{"aj", "e"}},
+	{pattern: "au", alternatives: []string{"au", "o"}},
+	{pattern: "c", next: "eiy", alternatives: []string{"ts", "s"}},
+	{pattern: "c", alternatives: []string{"k"}},
+	{pattern: "g", next: "eiy", alternatives: []string{"g", "dZ"}},
+	{pattern: "j", alternatives: []string{"j", "dZ"}},
+	{pattern: "w", alternatives: []string{"v"}},
+	{pattern: "x", alternatives: []string{"ks"}},
+	{pattern: "q", alternatives: []string{"k"}},
+	{pattern: "y", alternatives: []string{"i"}},
+	{pattern: "z", alternatives: []string{"z", "ts"}},
+}
+
+// beiderMorseApprox merges the phonemes that are hard to tell apart in names.
+var beiderMorseApprox = strings.NewReplacer(
+	"e", "i",
+	"o", "u",
+	"h", "",
+	"S", "s",
+	"Z", "z",
+)
+
+// NewBeiderMorse returns an encoder based on the Beider-Morse Phonetic Matching.
+// It is a lite version with a generic rule set that does not detect the language of the word.
+// The approx rule type merges similar phonemes so that more spellings match.
+func NewBeiderMorse(ruleType string) PhoneticEncoder {
+	return func(word string) []string {
+		str := removeDiacritics(strings.ToLower(word))
+
+		codes := []string{""}
+		for i := 0; i < len(str); {
+			if str[i] < 'a' || str[i] > 'z' {
+				i++
+				continue
+			}
+
+			length := 1
+			alternatives := []string{str[i : i+1]}
+			for _, rule := range beiderMorseRules {
+				if !strings.HasPrefix(str[i:], rule.pattern) {
+					continue
+				}
+				end := i + len(rule.pattern)
+				if rule.next != "" && (end >= len(str) || strings.IndexByte(rule.next, str[end]) < 0) {
+					continue
+				}
+				length = len(rule.pattern)
+				alternatives = rule.alternatives
+				break
+			}
+			i += length
+
+			next := make([]string, 0, len(codes)*len(alternatives))
+			for _, code := range codes {
+				for _, alternative := range alternatives {
+					if len(next) >= beiderMorseMaxCodes {
+						break
+					}
+					next = append(next, code+alternative)
+				}
+			}
+			codes = next
+		}
+
+		ret := make([]string, 0, len(codes))
+		seen := make(map[string]bool)
+		for _, code := range codes {
+			if ruleType == ApproxRuleType {
+				code = beiderMorseApprox.Replace(code)
+			}
+			code = collapseRepeats(code)
+			if code == "" || seen[code] {
+				continue
+			}
+			seen[code] = true
+			ret = append(ret, code)
+		}
+
+		return ret
+	}
+}
+
+// removeDiacritics removes the diacritical marks, e.g. "é" to "e".
+func removeDiacritics(str string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(str) {
+		if !unicode.Is(unicode.Mn, r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// collapseRepeats replaces the repeated letters with a single letter.
+func collapseRepeats(str string) string {
+	var builder strings.Builder
+	var last rune
+	for _, r := range str {
+		if r != last {
+			builder.WriteRune(r)
+		}
+		last = r
+	}
+	return builder.String()
+}
@@ -0,0 +1,58 @@
+package token
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/blugelabs/bluge/analysis/lang/cjk"
+)
+
+// CJKBigramFilter outputs adjacent CJK characters as bigrams.
+// The unicode tokenizer outputs each Han, Hiragana and Katakana character as an ideographic token,
+// while it keeps Hangul words as they are, so Hangul tokens are also treated as ideographic.
+type CJKBigramFilter struct {
+	filter *cjk.BigramFilter
+}
+
+func NewCJKBigramFilter(outputUnigrams bool) *CJKBigramFilter {
+	return &CJKBigramFilter{
+		filter: cjk.NewBigramFilter(outputUnigrams),
+	}
+}
+
+func isHangul(term []byte) bool {
+	for _, r := range string(term) {
+		if !unicode.Is(unicode.Hangul, r) {
+			return false
+		}
+	}
+	return len(term) > 0
+}
+
+func (f *CJKBigramFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.Type != analysis.Ideographic && isHangul(token.Term) {
+			token.Type = analysis.Ideographic
+		}
+	}
+
+	return f.filter.Filter(input)
+}
+
+// Create new CJKBigramFilter with given options.
+// Options example:
+// {
+//   "output_unigrams": false
+// }
+func NewCJKBigramFilterWithOptions(opts map[string]interface{}) (*CJKBigramFilter, error) {
+	outputUnigrams := false
+	if outputUnigramsValue, ok := opts["output_unigrams"]; ok {
+		outputUnigrams, ok = outputUnigramsValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("output_unigrams option is unexpected: %v", outputUnigramsValue)
+		}
+	}
+
+	return NewCJKBigramFilter(outputUnigrams), nil
+}
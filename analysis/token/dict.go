@@ -3,7 +3,6 @@ package token
 import (
 	"fmt"
 
-	"github.com/blugelabs/bluge/analysis"
 	"github.com/blugelabs/bluge/analysis/token"
 )
 
@@ -15,27 +14,16 @@ import (
 //     "softest",
 //     "ball"
 //   ],
+//   "words_uri": "file:///etc/phalanx/words.txt",
 //   "min_word_size": 5,
 //   "min_sub_word_size": 2,
 //   "max_sub_word_size": 15,
 //   "only_longest_match": false
 // }
 func NewDictionaryCompoundFilterWithOptions(opts map[string]interface{}) (*token.DictionaryCompoundFilter, error) {
-	wordsValue, ok := opts["words"]
-	if !ok {
-		return nil, fmt.Errorf("words option does not exist")
-	}
-	words, ok := wordsValue.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("words option is unexpected")
-	}
-	wordMap := analysis.NewTokenMap()
-	for _, word := range words {
-		str, ok := word.(string)
-		if !ok {
-			return nil, fmt.Errorf("word is unexpected")
-		}
-		wordMap.AddToken(str)
+	wordMap, err := newTokenMapWithOptions(opts, "words", "words_uri")
+	if err != nil {
+		return nil, err
 	}
 
 	minWordSizeValue, ok := opts["min_word_size"]
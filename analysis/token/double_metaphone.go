@@ -0,0 +1,575 @@
+package token
+
+import (
+	"strings"
+)
+
+// NewDoubleMetaphone returns the Double Metaphone encoder with the max length of the codes.
+// The encoder returns the primary code, and the alternate code if it differs.
+func NewDoubleMetaphone(maxCodeLength int) PhoneticEncoder {
+	return func(word string) []string {
+		str := strings.ToUpper(strings.TrimSpace(word))
+		if str == "" {
+			return nil
+		}
+
+		m := &doubleMetaphone{
+			value:         []rune(str),
+			maxCodeLength: maxCodeLength,
+		}
+		primary, alternate := m.encode()
+		if primary == "" {
+			return nil
+		}
+		if alternate == "" || alternate == primary {
+			return []string{primary}
+		}
+		return []string{primary, alternate}
+	}
+}
+
+type doubleMetaphone struct {
+	value         []rune
+	primary       strings.Builder
+	alternate     strings.Builder
+	maxCodeLength int
+}
+
+func (m *doubleMetaphone) at(index int) rune {
+	if index < 0 || index >= len(m.value) {
+		return 0
+	}
+	return m.value[index]
+}
+
+// contains reports whether the substring of the given length at the index is one of the candidates.
+func (m *doubleMetaphone) contains(start int, length int, candidates ...string) bool {
+	if start < 0 || start+length > len(m.value) {
+		return false
+	}
+	target := string(m.value[start : start+length])
+	for _, candidate := range candidates {
+		if target == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *doubleMetaphone) isVowel(r rune) bool {
+	return strings.ContainsRune("AEIOUY", r)
+}
+
+func (m *doubleMetaphone) isSlavoGermanic() bool {
+	str := string(m.value)
+	return strings.Contains(str, "W") || strings.Contains(str, "K") || strings.Contains(str, "CZ") || strings.Contains(str, "WITZ")
+}
+
+func (m *doubleMetaphone) isSilentStart() bool {
+	return m.contains(0, 2, "GN", "KN", "PN", "WR", "PS")
+}
+
+func (m *doubleMetaphone) append(primary string, alternate string) {
+	m.primary.WriteString(primary)
+	m.alternate.WriteString(alternate)
+}
+
+func (m *doubleMetaphone) appendBoth(str string) {
+	m.append(str, str)
+}
+
+func (m *doubleMetaphone) isComplete() bool {
+	return m.maxCodeLength > 0 && m.primary.Len() >= m.maxCodeLength && m.alternate.Len() >= m.maxCodeLength
+}
+
+func (m *doubleMetaphone) truncate(str string) string {
+	if m.maxCodeLength > 0 && len(str) > m.maxCodeLength {
+		return str[:m.maxCodeLength]
+	}
+	return str
+}
+
+// skip returns the index after the letter, skipping it twice if the next letter is one of the given letters.
+func (m *doubleMetaphone) skip(index int, letters ...string) int {
+	if m.contains(index+1, 1, letters...) {
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *doubleMetaphone) encode() (string, string) {
+	slavoGermanic := m.isSlavoGermanic()
+
+	index := 0
+	if m.isSilentStart() {
+		index = 1
+	}
+	for index < len(m.value) && !m.isComplete() {
+		switch m.value[index] {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			// Vowels are encoded only at the beginning.
+			if index == 0 {
+				m.appendBoth("A")
+			}
+			index++
+		case 'B':
+			m.appendBoth("P")
+			index = m.skip(index, "B")
+		case 'Ç':
+			m.appendBoth("S")
+			index++
+		case 'C':
+			index = m.handleC(index)
+		case 'D':
+			index = m.handleD(index)
+		case 'F':
+			m.appendBoth("F")
+			index = m.skip(index, "F")
+		case 'G':
+			index = m.handleG(index, slavoGermanic)
+		case 'H':
+			index = m.handleH(index)
+		case 'J':
+			index = m.handleJ(index, slavoGermanic)
+		case 'K':
+			m.appendBoth("K")
+			index = m.skip(index, "K")
+		case 'L':
+			index = m.handleL(index)
+		case 'M':
+			m.appendBoth("M")
+			if m.at(index+1) == 'M' || (m.contains(index-1, 3, "UMB") && (index+1 == len(m.value)-1 || m.contains(index+2, 2, "ER"))) {
+				index += 2
+			} else {
+				index++
+			}
+		case 'N':
+			m.appendBoth("N")
+			index = m.skip(index, "N")
+		case 'Ñ':
+			m.appendBoth("N")
+			index++
+		case 'P':
+			if m.at(index+1) == 'H' {
+				m.appendBoth("F")
+				index += 2
+			} else {
+				m.appendBoth("P")
+				index = m.skip(index, "P", "B")
+			}
+		case 'Q':
+			m.appendBoth("K")
+			index = m.skip(index, "Q")
+		case 'R':
+			index = m.handleR(index, slavoGermanic)
+		case 'S':
+			index = m.handleS(index, slavoGermanic)
+		case 'T':
+			index = m.handleT(index)
+		case 'V':
+			m.appendBoth("F")
+			index = m.skip(index, "V")
+		case 'W':
+			index = m.handleW(index)
+		case 'X':
+			index = m.handleX(index)
+		case 'Z':
+			index = m.handleZ(index, slavoGermanic)
+		default:
+			index++
+		}
+	}
+
+	return m.truncate(m.primary.String()), m.truncate(m.alternate.String())
+}
+
+func (m *doubleMetaphone) handleC(index int) int {
+	switch {
+	case m.conditionC0(index):
+		// Various Germanic, e.g. "BACHER".
+		m.appendBoth("K")
+		return index + 2
+	case index == 0 && m.contains(index, 6, "CAESAR"):
+		m.appendBoth("S")
+		return index + 2
+	case m.contains(index, 2, "CH"):
+		return m.handleCH(index)
+	case m.contains(index, 2, "CZ") && !m.contains(index-2, 4, "WICZ"):
+		m.append("S", "X")
+		return index + 2
+	case m.contains(index+1, 3, "CIA"):
+		m.appendBoth("X")
+		return index + 3
+	case m.contains(index, 2, "CC") && !(index == 1 && m.at(0) == 'M'):
+		return m.handleCC(index)
+	case m.contains(index, 2, "CK", "CG", "CQ"):
+		m.appendBoth("K")
+		return index + 2
+	case m.contains(index, 2, "CI", "CE", "CY"):
+		if m.contains(index, 3, "CIO", "CIE", "CIA") {
+			m.append("S", "X")
+		} else {
+			m.appendBoth("S")
+		}
+		return index + 2
+	default:
+		m.appendBoth("K")
+		if m.contains(index+1, 2, " C", " Q", " G") {
+			return index + 3
+		}
+		if m.contains(index+1, 1, "C", "K", "Q") && !m.contains(index+1, 2, "CE", "CI") {
+			return index + 2
+		}
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) conditionC0(index int) bool {
+	if m.contains(index, 4, "CHIA") {
+		return true
+	}
+	if index <= 1 || m.isVowel(m.at(index-2)) || !m.contains(index-1, 3, "ACH") {
+		return false
+	}
+	c := m.at(index + 2)
+	return (c != 'I' && c != 'E') || m.contains(index-2, 6, "BACHER", "MACHER")
+}
+
+func (m *doubleMetaphone) handleCC(index int) int {
+	if m.contains(index+2, 1, "I", "E", "H") && !m.contains(index+2, 2, "HU") {
+		// "ACCIDENT", "ACCEDE" and "SUCCEED".
+		if (index == 1 && m.at(index-1) == 'A') || m.contains(index-1, 5, "UCCEE", "UCCES") {
+			m.appendBoth("KS")
+		} else {
+			// "BACCI" and "BERTUCCI".
+			m.appendBoth("X")
+		}
+		return index + 3
+	}
+
+	m.appendBoth("K")
+	return index + 2
+}
+
+func (m *doubleMetaphone) handleCH(index int) int {
+	switch {
+	case index > 0 && m.contains(index, 4, "CHAE"):
+		// "MICHAEL".
+		m.append("K", "X")
+	case m.conditionCH0(index), m.conditionCH1(index):
+		// Greek roots, e.g. "CHEMISTRY", and Germanic, e.g. "ORCHESTRA".
+		m.appendBoth("K")
+	case index > 0:
+		if m.contains(0, 2, "MC") {
+			m.appendBoth("K")
+		} else {
+			m.append("X", "K")
+		}
+	default:
+		m.appendBoth("X")
+	}
+	return index + 2
+}
+
+func (m *doubleMetaphone) conditionCH0(index int) bool {
+	if index != 0 {
+		return false
+	}
+	if !m.contains(index+1, 5, "HARAC", "HARIS") && !m.contains(index+1, 3, "HOR", "HYM", "HIA", "HEM") {
+		return false
+	}
+	return !m.contains(0, 5, "CHORE")
+}
+
+func (m *doubleMetaphone) conditionCH1(index int) bool {
+	return m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") ||
+		m.contains(index-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		m.contains(index+2, 1, "T", "S") ||
+		((m.contains(index-1, 1, "A", "O", "U", "E") || index == 0) &&
+			(m.contains(index+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || index+1 == len(m.value)-1))
+}
+
+func (m *doubleMetaphone) handleD(index int) int {
+	switch {
+	case m.contains(index, 2, "DG"):
+		if m.contains(index+2, 1, "I", "E", "Y") {
+			// "EDGE".
+			m.appendBoth("J")
+			return index + 3
+		}
+		// "EDGAR".
+		m.appendBoth("TK")
+		return index + 2
+	case m.contains(index, 2, "DT", "DD"):
+		m.appendBoth("T")
+		return index + 2
+	default:
+		m.appendBoth("T")
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleG(index int, slavoGermanic bool) int {
+	switch {
+	case m.at(index+1) == 'H':
+		return m.handleGH(index)
+	case m.at(index+1) == 'N':
+		if index == 1 && m.isVowel(m.at(0)) && !slavoGermanic {
+			m.append("KN", "N")
+		} else if !m.contains(index+2, 2, "EY") && m.at(index+1) != 'Y' && !slavoGermanic {
+			m.append("N", "KN")
+		} else {
+			m.appendBoth("KN")
+		}
+		return index + 2
+	case m.contains(index+1, 2, "LI") && !slavoGermanic:
+		m.append("KL", "L")
+		return index + 2
+	case index == 0 && (m.at(index+1) == 'Y' || m.contains(index+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		m.append("K", "J")
+		return index + 2
+	case (m.contains(index+1, 2, "ER") || m.at(index+1) == 'Y') &&
+		!m.contains(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!m.contains(index-1, 1, "E", "I") &&
+		!m.contains(index-1, 3, "RGY", "OGY"):
+		m.append("K", "J")
+		return index + 2
+	case m.contains(index+1, 1, "E", "I", "Y") || m.contains(index-1, 4, "AGGI", "OGGI"):
+		if m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") || m.contains(index+1, 2, "ET") {
+			m.appendBoth("K")
+		} else if m.contains(index+1, 3, "IER") {
+			m.appendBoth("J")
+		} else {
+			m.append("J", "K")
+		}
+		return index + 2
+	case m.at(index+1) == 'G':
+		m.appendBoth("K")
+		return index + 2
+	default:
+		m.appendBoth("K")
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleGH(index int) int {
+	switch {
+	case index > 0 && !m.isVowel(m.at(index-1)):
+		m.appendBoth("K")
+	case index == 0:
+		if m.at(index+2) == 'I' {
+			m.appendBoth("J")
+		} else {
+			m.appendBoth("K")
+		}
+	case (index > 1 && m.contains(index-2, 1, "B", "H", "D")) ||
+		(index > 2 && m.contains(index-3, 1, "B", "H", "D")) ||
+		(index > 3 && m.contains(index-4, 1, "B", "H")):
+		// Silent, e.g. "HUGH" and "BOUGH".
+	default:
+		if index > 2 && m.at(index-1) == 'U' && m.contains(index-3, 1, "C", "G", "L", "R", "T") {
+			// "LAUGH" and "TOUGH".
+			m.appendBoth("F")
+		} else if index > 0 && m.at(index-1) != 'I' {
+			m.appendBoth("K")
+		}
+	}
+	return index + 2
+}
+
+func (m *doubleMetaphone) handleH(index int) int {
+	// Only keep H between vowels or at the beginning before a vowel.
+	if (index == 0 || m.isVowel(m.at(index-1))) && m.isVowel(m.at(index+1)) {
+		m.appendBoth("H")
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *doubleMetaphone) handleJ(index int, slavoGermanic bool) int {
+	if m.contains(index, 4, "JOSE") || m.contains(0, 4, "SAN ") {
+		// Spanish pronunciation, e.g. "JOSE" and "SAN JACINTO".
+		if (index == 0 && m.at(index+4) == ' ') || len(m.value) == 4 || m.contains(0, 4, "SAN ") {
+			m.appendBoth("H")
+		} else {
+			m.append("J", "H")
+		}
+		return index + 1
+	}
+
+	switch {
+	case index == 0:
+		m.append("J", "A")
+	case m.isVowel(m.at(index-1)) && !slavoGermanic && (m.at(index+1) == 'A' || m.at(index+1) == 'O'):
+		m.append("J", "H")
+	case index == len(m.value)-1:
+		m.append("J", "")
+	case !m.contains(index+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !m.contains(index-1, 1, "S", "K", "L"):
+		m.appendBoth("J")
+	}
+	return m.skip(index, "J")
+}
+
+func (m *doubleMetaphone) handleL(index int) int {
+	if m.at(index+1) != 'L' {
+		m.appendBoth("L")
+		return index + 1
+	}
+
+	// Spanish, e.g. "CABRILLO" and "GALLEGOS".
+	length := len(m.value)
+	if (index == length-3 && m.contains(index-1, 4, "ILLO", "ILLA", "ALLE")) ||
+		((m.contains(length-2, 2, "AS", "OS") || m.contains(length-1, 1, "A", "O")) && m.contains(index-1, 4, "ALLE")) {
+		m.append("L", "")
+	} else {
+		m.appendBoth("L")
+	}
+	return index + 2
+}
+
+func (m *doubleMetaphone) handleR(index int, slavoGermanic bool) int {
+	// French, e.g. "ROGIER".
+	if index == len(m.value)-1 && !slavoGermanic && m.contains(index-2, 2, "IE") && !m.contains(index-4, 2, "ME", "MA") {
+		m.append("", "R")
+	} else {
+		m.appendBoth("R")
+	}
+	return m.skip(index, "R")
+}
+
+func (m *doubleMetaphone) handleS(index int, slavoGermanic bool) int {
+	switch {
+	case m.contains(index-1, 3, "ISL", "YSL"):
+		// Silent, e.g. "ISLAND".
+		return index + 1
+	case index == 0 && m.contains(index, 5, "SUGAR"):
+		m.append("X", "S")
+		return index + 1
+	case m.contains(index, 2, "SH"):
+		if m.contains(index+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic.
+			m.appendBoth("S")
+		} else {
+			m.appendBoth("X")
+		}
+		return index + 2
+	case m.contains(index, 3, "SIO", "SIA") || m.contains(index, 4, "SIAN"):
+		if slavoGermanic {
+			m.appendBoth("S")
+		} else {
+			m.append("S", "X")
+		}
+		return index + 3
+	case (index == 0 && m.contains(index+1, 1, "M", "N", "L", "W")) || m.contains(index+1, 1, "Z"):
+		// German and Anglicisations, e.g. "SMITH" and "SCHMIDT".
+		m.append("S", "X")
+		return m.skip(index, "Z")
+	case m.contains(index, 2, "SC"):
+		return m.handleSC(index)
+	default:
+		if index == len(m.value)-1 && m.contains(index-2, 2, "AI", "OI") {
+			// French, e.g. "RESNAIS".
+			m.append("", "S")
+		} else {
+			m.appendBoth("S")
+		}
+		return m.skip(index, "S", "Z")
+	}
+}
+
+func (m *doubleMetaphone) handleSC(index int) int {
+	switch {
+	case m.at(index+2) == 'H':
+		if m.contains(index+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+			// Dutch, e.g. "SCHOOL" and "SCHENKER".
+			if m.contains(index+3, 2, "ER", "EN") {
+				m.append("X", "SK")
+			} else {
+				m.appendBoth("SK")
+			}
+		} else if index == 0 && !m.isVowel(m.at(3)) && m.at(3) != 'W' {
+			m.append("X", "S")
+		} else {
+			m.appendBoth("X")
+		}
+	case m.contains(index+2, 1, "I", "E", "Y"):
+		m.appendBoth("S")
+	default:
+		m.appendBoth("SK")
+	}
+	return index + 3
+}
+
+func (m *doubleMetaphone) handleT(index int) int {
+	switch {
+	case m.contains(index, 4, "TION"), m.contains(index, 3, "TIA", "TCH"):
+		m.appendBoth("X")
+		return index + 3
+	case m.contains(index, 2, "TH") || m.contains(index, 3, "TTH"):
+		if m.contains(index+2, 2, "OM", "AM") || m.contains(0, 4, "VAN ", "VON ") || m.contains(0, 3, "SCH") {
+			// "THOMAS" and "THAMES".
+			m.appendBoth("T")
+		} else {
+			m.append("0", "T")
+		}
+		return index + 2
+	default:
+		m.appendBoth("T")
+		return m.skip(index, "T", "D")
+	}
+}
+
+func (m *doubleMetaphone) handleW(index int) int {
+	switch {
+	case m.contains(index, 2, "WR"):
+		m.appendBoth("R")
+		return index + 2
+	case index == 0 && (m.isVowel(m.at(index+1)) || m.contains(index, 2, "WH")):
+		if m.isVowel(m.at(index + 1)) {
+			// "WASSERMAN" matches "VASSERMAN".
+			m.append("A", "F")
+		} else {
+			m.appendBoth("A")
+		}
+		return index + 1
+	case (index == len(m.value)-1 && m.isVowel(m.at(index-1))) ||
+		m.contains(index-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		m.contains(0, 3, "SCH"):
+		// Polish, e.g. "FILIPOWICZ".
+		m.append("", "F")
+		return index + 1
+	case m.contains(index, 4, "WICZ", "WITZ"):
+		m.append("TS", "FX")
+		return index + 4
+	default:
+		return index + 1
+	}
+}
+
+func (m *doubleMetaphone) handleX(index int) int {
+	if index == 0 {
+		m.appendBoth("S")
+		return index + 1
+	}
+
+	// French, e.g. "BREAUX".
+	if !(index == len(m.value)-1 && (m.contains(index-3, 3, "IAU", "EAU") || m.contains(index-2, 2, "AU", "OU"))) {
+		m.appendBoth("KS")
+	}
+	return m.skip(index, "C", "X")
+}
+
+func (m *doubleMetaphone) handleZ(index int, slavoGermanic bool) int {
+	if m.at(index+1) == 'H' {
+		// Chinese pinyin, e.g. "ZHAO".
+		m.appendBoth("J")
+		return index + 2
+	}
+
+	if m.contains(index+1, 2, "ZO", "ZI", "ZA") || (slavoGermanic && index > 0 && m.at(index-1) != 'T') {
+		m.append("S", "TS")
+	} else {
+		m.appendBoth("S")
+	}
+	return m.skip(index, "Z")
+}
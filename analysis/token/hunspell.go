@@ -0,0 +1,465 @@
+package token
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/mosuka/phalanx/analysis/resource"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// The flag types of the FLAG directive in .aff files.
+const (
+	hunspellShortFlag = "short"
+	hunspellLongFlag  = "long"
+	hunspellNumFlag   = "num"
+	hunspellUTF8Flag  = "UTF-8"
+)
+
+// hunspellAffix is a PFX or SFX rule in .aff files.
+type hunspellAffix struct {
+	flag      string
+	cross     bool
+	strip     string
+	affix     string
+	condition *regexp.Regexp
+}
+
+// HunspellDictionary is the affix rules and the words of a Hunspell dictionary.
+// It supports the SET, FLAG, AF, NEEDAFFIX, PFX and SFX directives of .aff files.
+type HunspellDictionary struct {
+	flagType   string
+	aliases    []string
+	needAffix  string
+	prefixes   []*hunspellAffix
+	suffixes   []*hunspellAffix
+	words      map[string][]map[string]bool
+	ignoreCase bool
+}
+
+// hunspellEncoding returns the encoding in the SET directive of .aff files.
+func hunspellEncoding(aff []byte) string {
+	for _, line := range resource.Lines(aff) {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "SET" {
+			return fields[1]
+		}
+	}
+	return "UTF-8"
+}
+
+// decodeHunspell converts the data in the given encoding to UTF-8.
+func decodeHunspell(data []byte, encodingName string) ([]byte, error) {
+	name := strings.ToLower(encodingName)
+	if name == "utf-8" || name == "utf8" {
+		return data, nil
+	}
+	// e.g. "ISO8859-1" to "iso-8859-1".
+	if strings.HasPrefix(name, "iso8859") {
+		name = "iso-8859" + strings.TrimPrefix(name, "iso8859")
+	}
+	encoding, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("encoding is unexpected: %v", encodingName)
+	}
+	return encoding.NewDecoder().Bytes(data)
+}
+
+func NewHunspellDictionary(aff []byte, dic []byte, ignoreCase bool) (*HunspellDictionary, error) {
+	encodingName := hunspellEncoding(aff)
+	aff, err := decodeHunspell(aff, encodingName)
+	if err != nil {
+		return nil, err
+	}
+	dic, err = decodeHunspell(dic, encodingName)
+	if err != nil {
+		return nil, err
+	}
+
+	dictionary := &HunspellDictionary{
+		flagType:   hunspellShortFlag,
+		aliases:    make([]string, 0),
+		prefixes:   make([]*hunspellAffix, 0),
+		suffixes:   make([]*hunspellAffix, 0),
+		words:      make(map[string][]map[string]bool),
+		ignoreCase: ignoreCase,
+	}
+
+	if err := dictionary.parseAff(resource.Lines(aff)); err != nil {
+		return nil, err
+	}
+	if err := dictionary.parseDic(resource.Lines(dic)); err != nil {
+		return nil, err
+	}
+
+	return dictionary, nil
+}
+
+func (d *HunspellDictionary) parseAff(lines []string) error {
+	// The header of the affix rules, e.g. "SFX S Y 2".
+	headers := make(map[string]bool)
+	crosses := make(map[string]bool)
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "FLAG":
+			switch fields[1] {
+			case hunspellLongFlag, hunspellNumFlag, hunspellUTF8Flag:
+				d.flagType = fields[1]
+			default:
+				return fmt.Errorf("flag type is unexpected: %v", fields[1])
+			}
+		case "AF":
+			// The first AF line is the number of the aliases.
+			if _, err := strconv.Atoi(fields[1]); err == nil && len(d.aliases) == 0 && len(fields) == 2 {
+				continue
+			}
+			d.aliases = append(d.aliases, fields[1])
+		case "NEEDAFFIX":
+			d.needAffix = fields[1]
+		case "PFX", "SFX":
+			key := fields[0] + " " + fields[1]
+			if !headers[key] {
+				if len(fields) < 4 {
+					return fmt.Errorf("affix header is unexpected: %v", line)
+				}
+				headers[key] = true
+				crosses[key] = fields[2] == "Y"
+				continue
+			}
+			if len(fields) < 5 {
+				return fmt.Errorf("affix rule is unexpected: %v", line)
+			}
+
+			affix := &hunspellAffix{
+				flag:  fields[1],
+				cross: crosses[key],
+				strip: fields[2],
+				affix: fields[3],
+			}
+			if affix.strip == "0" {
+				affix.strip = ""
+			}
+			// The continuation flags of the affix are ignored.
+			if i := strings.Index(affix.affix, "/"); i >= 0 {
+				affix.affix = affix.affix[:i]
+			}
+			if affix.affix == "0" {
+				affix.affix = ""
+			}
+			if d.ignoreCase {
+				affix.strip = strings.ToLower(affix.strip)
+				affix.affix = strings.ToLower(affix.affix)
+			}
+
+			condition := fields[4]
+			if fields[0] == "PFX" {
+				condition = "^(?:" + condition + ")"
+			} else {
+				condition = "(?:" + condition + ")$"
+			}
+			var err error
+			affix.condition, err = regexp.Compile(condition)
+			if err != nil {
+				return fmt.Errorf("affix condition is unexpected: %v", fields[4])
+			}
+
+			if fields[0] == "PFX" {
+				d.prefixes = append(d.prefixes, affix)
+			} else {
+				d.suffixes = append(d.suffixes, affix)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseFlags splits the flags of a word in the flag type.
+func (d *HunspellDictionary) parseFlags(flags string) (map[string]bool, error) {
+	// The flags may be the number of an AF alias.
+	if len(d.aliases) > 0 {
+		if num, err := strconv.Atoi(flags); err == nil {
+			if num < 1 || num > len(d.aliases) {
+				return nil, fmt.Errorf("flag alias is unexpected: %v", flags)
+			}
+			flags = d.aliases[num-1]
+		}
+	}
+
+	ret := make(map[string]bool)
+	switch d.flagType {
+	case hunspellLongFlag:
+		runes := []rune(flags)
+		for i := 0; i+1 < len(runes); i += 2 {
+			ret[string(runes[i:i+2])] = true
+		}
+	case hunspellNumFlag:
+		for _, flag := range strings.Split(flags, ",") {
+			ret[strings.TrimSpace(flag)] = true
+		}
+	default:
+		for _, r := range flags {
+			ret[string(r)] = true
+		}
+	}
+	return ret, nil
+}
+
+func (d *HunspellDictionary) parseDic(lines []string) error {
+	for i, line := range lines {
+		// The first line is the number of the words.
+		if i == 0 {
+			if _, err := strconv.Atoi(line); err == nil {
+				continue
+			}
+		}
+
+		// The morphological fields after the word are ignored.
+		entry := strings.Fields(line)[0]
+		word, flags := entry, ""
+		if i := strings.Index(entry, "/"); i > 0 {
+			word, flags = entry[:i], entry[i+1:]
+		}
+		if d.ignoreCase {
+			word = strings.ToLower(word)
+		}
+
+		flagMap, err := d.parseFlags(flags)
+		if err != nil {
+			return err
+		}
+		d.words[word] = append(d.words[word], flagMap)
+	}
+
+	return nil
+}
+
+// hasFlags reports whether the word is in the dictionary with all the given flags.
+func (d *HunspellDictionary) hasFlags(word string, flags ...string) bool {
+	for _, flagMap := range d.words[word] {
+		ok := true
+		for _, flag := range flags {
+			if !flagMap[flag] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// stripSuffix returns the word without the suffix, or false if the suffix rule does not apply.
+func (a *hunspellAffix) stripSuffix(word string) (string, bool) {
+	if !strings.HasSuffix(word, a.affix) || len(word) <= len(a.affix) && a.strip == "" {
+		return "", false
+	}
+	candidate := word[:len(word)-len(a.affix)] + a.strip
+	return candidate, a.condition.MatchString(candidate)
+}
+
+// stripPrefix returns the word without the prefix, or false if the prefix rule does not apply.
+func (a *hunspellAffix) stripPrefix(word string) (string, bool) {
+	if !strings.HasPrefix(word, a.affix) || len(word) <= len(a.affix) && a.strip == "" {
+		return "", false
+	}
+	candidate := a.strip + word[len(a.affix):]
+	return candidate, a.condition.MatchString(candidate)
+}
+
+// Stem returns the stems of the word in the dictionary, removing a prefix, a suffix or both.
+func (d *HunspellDictionary) Stem(word string) []string {
+	if d.ignoreCase {
+		word = strings.ToLower(word)
+	}
+
+	stems := make([]string, 0)
+
+	if d.needAffix == "" {
+		if _, ok := d.words[word]; ok {
+			stems = append(stems, word)
+		}
+	} else {
+		for _, flagMap := range d.words[word] {
+			if !flagMap[d.needAffix] {
+				stems = append(stems, word)
+				break
+			}
+		}
+	}
+
+	for _, suffix := range d.suffixes {
+		candidate, ok := suffix.stripSuffix(word)
+		if !ok {
+			continue
+		}
+		if d.hasFlags(candidate, suffix.flag) {
+			stems = append(stems, candidate)
+		}
+		if !suffix.cross {
+			continue
+		}
+		for _, prefix := range d.prefixes {
+			if !prefix.cross {
+				continue
+			}
+			stem, ok := prefix.stripPrefix(candidate)
+			if ok && d.hasFlags(stem, suffix.flag, prefix.flag) {
+				stems = append(stems, stem)
+			}
+		}
+	}
+
+	for _, prefix := range d.prefixes {
+		candidate, ok := prefix.stripPrefix(word)
+		if ok && d.hasFlags(candidate, prefix.flag) {
+			stems = append(stems, candidate)
+		}
+	}
+
+	return stems
+}
+
+// HunspellFilter replaces tokens with their stems in a Hunspell dictionary.
+// The stems of a token are output on the same position, and tokens without stems are kept as they are.
+type HunspellFilter struct {
+	dictionary  *HunspellDictionary
+	dedup       bool
+	longestOnly bool
+}
+
+func NewHunspellFilter(dictionary *HunspellDictionary, dedup bool, longestOnly bool) *HunspellFilter {
+	return &HunspellFilter{
+		dictionary:  dictionary,
+		dedup:       dedup,
+		longestOnly: longestOnly,
+	}
+}
+
+func (f *HunspellFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	ret := make(analysis.TokenStream, 0, len(input))
+
+	for _, token := range input {
+		if token.KeyWord {
+			ret = append(ret, token)
+			continue
+		}
+
+		stems := f.dictionary.Stem(string(token.Term))
+		if len(stems) == 0 {
+			ret = append(ret, token)
+			continue
+		}
+
+		if f.dedup {
+			seen := make(map[string]bool)
+			uniqueStems := make([]string, 0, len(stems))
+			for _, stem := range stems {
+				if !seen[stem] {
+					seen[stem] = true
+					uniqueStems = append(uniqueStems, stem)
+				}
+			}
+			stems = uniqueStems
+		}
+
+		if f.longestOnly {
+			longest := stems[0]
+			for _, stem := range stems[1:] {
+				if utf8.RuneCountInString(stem) > utf8.RuneCountInString(longest) {
+					longest = stem
+				}
+			}
+			stems = []string{longest}
+		}
+
+		for i, stem := range stems {
+			if i == 0 {
+				token.Term = []byte(stem)
+				ret = append(ret, token)
+				continue
+			}
+			ret = append(ret, &analysis.Token{
+				Start:        token.Start,
+				End:          token.End,
+				Term:         []byte(stem),
+				PositionIncr: 0,
+				Type:         token.Type,
+				KeyWord:      token.KeyWord,
+			})
+		}
+	}
+
+	return ret
+}
+
+// Create new HunspellFilter with given options.
+// Options example:
+// {
+//   "aff_uri": "file:///path/to/en_US.aff",
+//   "dic_uri": "file:///path/to/en_US.dic",
+//   "dedup": true,
+//   "longest_only": false,
+//   "ignore_case": false
+// }
+func NewHunspellFilterWithOptions(opts map[string]interface{}) (*HunspellFilter, error) {
+	resources := make(map[string][]byte)
+	for _, option := range []string{"aff_uri", "dic_uri"} {
+		uriValue, ok := opts[option]
+		if !ok {
+			return nil, fmt.Errorf("%s option does not exist", option)
+		}
+		uri, ok := uriValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s option is unexpected: %v", option, uriValue)
+		}
+		data, err := resource.Get(uri)
+		if err != nil {
+			return nil, err
+		}
+		resources[option] = data
+	}
+
+	dedup := true
+	if dedupValue, ok := opts["dedup"]; ok {
+		dedup, ok = dedupValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("dedup option is unexpected: %v", dedupValue)
+		}
+	}
+
+	longestOnly := false
+	if longestOnlyValue, ok := opts["longest_only"]; ok {
+		longestOnly, ok = longestOnlyValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("longest_only option is unexpected: %v", longestOnlyValue)
+		}
+	}
+
+	ignoreCase := false
+	if ignoreCaseValue, ok := opts["ignore_case"]; ok {
+		ignoreCase, ok = ignoreCaseValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("ignore_case option is unexpected: %v", ignoreCaseValue)
+		}
+	}
+
+	dictionary, err := NewHunspellDictionary(resources["aff_uri"], resources["dic_uri"], ignoreCase)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHunspellFilter(dictionary, dedup, longestOnly), nil
+}
@@ -0,0 +1,327 @@
+package token
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge/analysis"
+)
+
+const (
+	HiraganaReadingForm = "hiragana"
+	KatakanaReadingForm = "katakana"
+	RomajiReadingForm   = "romaji"
+)
+
+// prolongedSoundMark is the katakana long vowel mark.
+const prolongedSoundMark = 'ー'
+
+// The offset between hiragana and katakana in Unicode.
+const kanaOffset = 'ア' - 'あ'
+
+func isHiragana(r rune) bool {
+	return r >= 'ぁ' && r <= 'ゖ'
+}
+
+func isKatakana(r rune) bool {
+	return (r >= 'ァ' && r <= 'ヺ') || r == prolongedSoundMark
+}
+
+// HiraganaToKatakana converts the hiragana in the text to katakana.
+func HiraganaToKatakana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if isHiragana(r) {
+			return r + kanaOffset
+		}
+		return r
+	}, text)
+}
+
+// KatakanaToHiragana converts the katakana in the text to hiragana.
+func KatakanaToHiragana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r != prolongedSoundMark && isKatakana(r) && isHiragana(r-kanaOffset) {
+			return r - kanaOffset
+		}
+		return r
+	}, text)
+}
+
+// romajiDigraphs are the katakana pairs romanized together (Hepburn).
+var romajiDigraphs = map[string]string{
+	"キャ": "kya", "キュ": "kyu", "キョ": "kyo",
+	"シャ": "sha", "シュ": "shu", "ショ": "sho", "シェ": "she",
+	"チャ": "cha", "チュ": "chu", "チョ": "cho", "チェ": "che",
+	"ニャ": "nya", "ニュ": "nyu", "ニョ": "nyo",
+	"ヒャ": "hya", "ヒュ": "hyu", "ヒョ": "hyo",
+	"ミャ": "mya", "ミュ": "myu", "ミョ": "myo",
+	"リャ": "rya", "リュ": "ryu", "リョ": "ryo",
+	"ギャ": "gya", "ギュ": "gyu", "ギョ": "gyo",
+	"ジャ": "ja", "ジュ": "ju", "ジョ": "jo", "ジェ": "je",
+	"ヂャ": "ja", "ヂュ": "ju", "ヂョ": "jo",
+	"ビャ": "bya", "ビュ": "byu", "ビョ": "byo",
+	"ピャ": "pya", "ピュ": "pyu", "ピョ": "pyo",
+	"ファ": "fa", "フィ": "fi", "フェ": "fe", "フォ": "fo",
+	"ウィ": "wi", "ウェ": "we", "ウォ": "wo",
+	"ヴァ": "va", "ヴィ": "vi", "ヴェ": "ve", "ヴォ": "vo",
+	"ティ": "ti", "ディ": "di", "トゥ": "tu", "ドゥ": "du",
+}
+
+// romajiMonographs are the romanizations of single katakana (Hepburn).
+var romajiMonographs = map[rune]string{
+	'ア': "a", 'イ': "i", 'ウ': "u", 'エ': "e", 'オ': "o",
+	'カ': "ka", 'キ': "ki", 'ク': "ku", 'ケ': "ke", 'コ': "ko",
+	'サ': "sa", 'シ': "shi", 'ス': "su", 'セ': "se", 'ソ': "so",
+	'タ': "ta", 'チ': "chi", 'ツ': "tsu", 'テ': "te", 'ト': "to",
+	'ナ': "na", 'ニ': "ni", 'ヌ': "nu", 'ネ': "ne", 'ノ': "no",
+	'ハ': "ha", 'ヒ': "hi", 'フ': "fu", 'ヘ': "he", 'ホ': "ho",
+	'マ': "ma", 'ミ': "mi", 'ム': "mu", 'メ': "me", 'モ': "mo",
+	'ヤ': "ya", 'ユ': "yu", 'ヨ': "yo",
+	'ラ': "ra", 'リ': "ri", 'ル': "ru", 'レ': "re", 'ロ': "ro",
+	'ワ': "wa", 'ヰ': "i", 'ヱ': "e", 'ヲ': "o", 'ン': "n",
+	'ガ': "ga", 'ギ': "gi", 'グ': "gu", 'ゲ': "ge", 'ゴ': "go",
+	'ザ': "za", 'ジ': "ji", 'ズ': "zu", 'ゼ': "ze", 'ゾ': "zo",
+	'ダ': "da", 'ヂ': "ji", 'ヅ': "zu", 'デ': "de", 'ド': "do",
+	'バ': "ba", 'ビ': "bi", 'ブ': "bu", 'ベ': "be", 'ボ': "bo",
+	'パ': "pa", 'ピ': "pi", 'プ': "pu", 'ペ': "pe", 'ポ': "po",
+	'ヴ': "vu",
+	'ァ': "a", 'ィ': "i", 'ゥ': "u", 'ェ': "e", 'ォ': "o",
+	'ャ': "ya", 'ュ': "yu", 'ョ': "yo", 'ヮ': "wa",
+}
+
+// KanaToRomaji converts the hiragana and katakana in the text to romaji (Hepburn).
+// A long vowel mark repeats the previous vowel.
+func KanaToRomaji(text string) string {
+	runes := []rune(HiraganaToKatakana(text))
+
+	var builder strings.Builder
+	doubleConsonant := false
+	for i := 0; i < len(runes); i++ {
+		var romaji string
+		if i+1 < len(runes) {
+			if str, ok := romajiDigraphs[string(runes[i:i+2])]; ok {
+				romaji = str
+				i++
+			}
+		}
+		if romaji == "" {
+			switch runes[i] {
+			case 'ッ':
+				doubleConsonant = true
+				continue
+			case prolongedSoundMark:
+				// Repeat the last vowel.
+				str := builder.String()
+				if len(str) > 0 && strings.ContainsRune("aiueo", rune(str[len(str)-1])) {
+					builder.WriteByte(str[len(str)-1])
+				}
+				continue
+			}
+			str, ok := romajiMonographs[runes[i]]
+			if !ok {
+				str = string(runes[i])
+			}
+			romaji = str
+		}
+		if doubleConsonant {
+			switch {
+			case strings.HasPrefix(romaji, "ch"):
+				builder.WriteByte('t')
+			case romaji[0] < utf8.RuneSelf && !strings.ContainsRune("aiueon", rune(romaji[0])):
+				builder.WriteByte(romaji[0])
+			}
+			doubleConsonant = false
+		}
+		builder.WriteString(romaji)
+	}
+
+	return builder.String()
+}
+
+// KatakanaStemFilter removes the trailing long vowel mark of katakana tokens,
+// so that "コンピューター" and "コンピュータ" match.
+type KatakanaStemFilter struct {
+	minLength int
+}
+
+func NewKatakanaStemFilter(minLength int) *KatakanaStemFilter {
+	return &KatakanaStemFilter{
+		minLength: minLength,
+	}
+}
+
+func (f *KatakanaStemFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.KeyWord {
+			continue
+		}
+		term := []rune(string(token.Term))
+		if len(term) < f.minLength || term[len(term)-1] != prolongedSoundMark {
+			continue
+		}
+		katakana := true
+		for _, r := range term {
+			if !isKatakana(r) {
+				katakana = false
+				break
+			}
+		}
+		if katakana {
+			token.Term = []byte(string(term[:len(term)-1]))
+		}
+	}
+
+	return input
+}
+
+// Create new KatakanaStemFilter with given options.
+// Options example:
+// {
+//   "min_length": 4
+// }
+func NewKatakanaStemFilterWithOptions(opts map[string]interface{}) (*KatakanaStemFilter, error) {
+	minLength := 4
+	if minLengthValue, ok := opts["min_length"]; ok {
+		minLengthNum, ok := minLengthValue.(float64)
+		if !ok {
+			return nil, fmt.Errorf("min_length option is unexpected: %v", minLengthValue)
+		}
+		minLength = int(minLengthNum)
+	}
+
+	return NewKatakanaStemFilter(minLength), nil
+}
+
+var japaneseDigits = map[rune]int64{
+	'〇': 0, '零': 0, '一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9,
+}
+
+func isJapaneseDigit(r rune) bool {
+	_, ok := japaneseDigits[r]
+	return ok
+}
+
+var japaneseSmallUnits = map[rune]int64{
+	'十': 10, '百': 100, '千': 1000,
+}
+
+var japaneseLargeUnits = map[rune]int64{
+	'万': 10000, '億': 100000000, '兆': 1000000000000,
+}
+
+// ParseJapaneseNumber parses a number written in kanji numerals and digits, such as "三千五百" or "2万".
+func ParseJapaneseNumber(text string) (int64, bool) {
+	if text == "" {
+		return 0, false
+	}
+
+	var total, section, current int64
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			current = current*10 + int64(r-'0')
+		case r >= '０' && r <= '９':
+			current = current*10 + int64(r-'０')
+		case isJapaneseDigit(r):
+			current = current*10 + japaneseDigits[r]
+		case japaneseSmallUnits[r] > 0:
+			if current == 0 {
+				current = 1
+			}
+			section += current * japaneseSmallUnits[r]
+			current = 0
+		case japaneseLargeUnits[r] > 0:
+			section += current
+			if section == 0 {
+				section = 1
+			}
+			total += section * japaneseLargeUnits[r]
+			section = 0
+			current = 0
+		default:
+			return 0, false
+		}
+	}
+
+	return total + section + current, true
+}
+
+// JapaneseNumberFilter normalizes numbers written in kanji numerals and full-width digits to Arabic numerals.
+type JapaneseNumberFilter struct{}
+
+func NewJapaneseNumberFilter() *JapaneseNumberFilter {
+	return &JapaneseNumberFilter{}
+}
+
+func (f *JapaneseNumberFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.KeyWord {
+			continue
+		}
+		// Numbers in ASCII digits are left as they are.
+		if utf8.RuneCount(token.Term) == len(token.Term) {
+			continue
+		}
+		if number, ok := ParseJapaneseNumber(string(token.Term)); ok {
+			token.Term = []byte(strconv.FormatInt(number, 10))
+			token.Type = analysis.Numeric
+		}
+	}
+
+	return input
+}
+
+// ReadingFormFilter converts the kana of tokens to hiragana, katakana or romaji.
+// Use the reading_form option of the kagome tokenizer to convert kanji to their readings.
+type ReadingFormFilter struct {
+	form string
+}
+
+func NewReadingFormFilter(form string) *ReadingFormFilter {
+	return &ReadingFormFilter{
+		form: form,
+	}
+}
+
+func (f *ReadingFormFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.KeyWord {
+			continue
+		}
+		switch f.form {
+		case HiraganaReadingForm:
+			token.Term = []byte(KatakanaToHiragana(string(token.Term)))
+		case KatakanaReadingForm:
+			token.Term = []byte(HiraganaToKatakana(string(token.Term)))
+		case RomajiReadingForm:
+			token.Term = []byte(KanaToRomaji(string(token.Term)))
+		}
+	}
+
+	return input
+}
+
+// Create new ReadingFormFilter with given options.
+// Options example:
+// {
+//   "form": "romaji"
+// }
+func NewReadingFormFilterWithOptions(opts map[string]interface{}) (*ReadingFormFilter, error) {
+	formValue, ok := opts["form"]
+	if !ok {
+		return nil, fmt.Errorf("form option does not exist")
+	}
+	form, ok := formValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("form option is unexpected: %v", formValue)
+	}
+	switch form {
+	case HiraganaReadingForm, KatakanaReadingForm, RomajiReadingForm:
+	default:
+		return nil, fmt.Errorf("form option is unexpected: %v", form)
+	}
+
+	return NewReadingFormFilter(form), nil
+}
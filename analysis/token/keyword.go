@@ -1,9 +1,6 @@
 package token
 
 import (
-	"fmt"
-
-	"github.com/blugelabs/bluge/analysis"
 	"github.com/blugelabs/bluge/analysis/token"
 )
 
@@ -13,24 +10,13 @@ import (
 //   "keywords": [
 //     "walk",
 //     "park"
-//   ]
+//   ],
+//   "keywords_uri": "file:///etc/phalanx/keywords.txt"
 // }
 func NewKeyWordMarkerFilterWithOptions(opts map[string]interface{}) (*token.KeyWordMarkerFilter, error) {
-	keywordsValue, ok := opts["keywords"]
-	if !ok {
-		return nil, fmt.Errorf("keywords option does not exist")
-	}
-	keywords, ok := keywordsValue.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("keywords option is unexpected")
-	}
-	keywordMap := analysis.NewTokenMap()
-	for _, keyword := range keywords {
-		str, ok := keyword.(string)
-		if !ok {
-			return nil, fmt.Errorf("keyword is unexpected: %v", keyword)
-		}
-		keywordMap.AddToken(str)
+	keywordMap, err := newTokenMapWithOptions(opts, "keywords", "keywords_uri")
+	if err != nil {
+		return nil, err
 	}
 
 	return token.NewKeyWordMarkerFilter(keywordMap), nil
@@ -0,0 +1,361 @@
+package token
+
+// The light stemmers below follow the algorithms of the stemmers in Apache Lucene.
+
+func hasRuneSuffix(s []rune, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		suffixRunes := []rune(suffix)
+		if len(s) < len(suffixRunes) {
+			continue
+		}
+		if string(s[len(s)-len(suffixRunes):]) == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+func hasRunePrefix(s []rune, prefix string) bool {
+	prefixRunes := []rune(prefix)
+	return len(s) >= len(prefixRunes) && string(s[:len(prefixRunes)]) == prefix
+}
+
+// MinimalEnglishStem removes the plural endings of English words, e.g. "queries" to "query".
+func MinimalEnglishStem(s []rune) []rune {
+	n := len(s)
+	if n < 3 || s[n-1] != 's' {
+		return s
+	}
+
+	switch s[n-2] {
+	case 'u', 's':
+		return s
+	case 'e':
+		if n > 3 && s[n-3] == 'i' && s[n-4] != 'a' && s[n-4] != 'e' {
+			s[n-3] = 'y'
+			return s[:n-2]
+		}
+		if s[n-3] == 'i' || s[n-3] == 'a' || s[n-3] == 'o' || s[n-3] == 'e' {
+			return s
+		}
+	}
+	return s[:n-1]
+}
+
+// BulgarianStem is the light stemmer for Bulgarian by Jacques Savoy.
+func BulgarianStem(s []rune) []rune {
+	if len(s) < 4 {
+		return s
+	}
+	if len(s) > 5 && hasRuneSuffix(s, "ища") {
+		return s[:len(s)-3]
+	}
+
+	s = bulgarianRemoveArticle(s)
+	s = bulgarianRemovePlural(s)
+
+	if len(s) > 3 {
+		if hasRuneSuffix(s, "я") {
+			s = s[:len(s)-1]
+		}
+		if hasRuneSuffix(s, "а", "о", "е") {
+			s = s[:len(s)-1]
+		}
+	}
+
+	if len(s) > 4 && hasRuneSuffix(s, "ен") {
+		s[len(s)-2] = 'н'
+		s = s[:len(s)-1]
+	}
+	if len(s) > 5 && s[len(s)-2] == 'ъ' {
+		s[len(s)-2] = s[len(s)-1]
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
+
+func bulgarianRemoveArticle(s []rune) []rune {
+	n := len(s)
+	switch {
+	case n > 6 && hasRuneSuffix(s, "ият"):
+		return s[:n-3]
+	case n > 5 && hasRuneSuffix(s, "ът", "то", "те", "та", "ия"):
+		return s[:n-2]
+	case n > 4 && hasRuneSuffix(s, "ят"):
+		return s[:n-2]
+	}
+	return s
+}
+
+func bulgarianRemovePlural(s []rune) []rune {
+	n := len(s)
+	if n > 6 {
+		switch {
+		case hasRuneSuffix(s, "овци"):
+			// Replaced with "о".
+			return s[:n-3]
+		case hasRuneSuffix(s, "ове"):
+			return s[:n-3]
+		case hasRuneSuffix(s, "еве"):
+			s[n-3] = 'й'
+			return s[:n-2]
+		}
+	}
+	if n > 5 {
+		switch {
+		case hasRuneSuffix(s, "ища"):
+			return s[:n-3]
+		case hasRuneSuffix(s, "та"):
+			return s[:n-2]
+		case hasRuneSuffix(s, "ци"):
+			s[n-2] = 'к'
+			return s[:n-1]
+		case hasRuneSuffix(s, "зи"):
+			s[n-2] = 'г'
+			return s[:n-1]
+		case s[n-3] == 'е' && s[n-1] == 'и':
+			s[n-3] = 'я'
+			return s[:n-1]
+		}
+	}
+	if n > 4 {
+		switch {
+		case hasRuneSuffix(s, "си"):
+			s[n-2] = 'х'
+			return s[:n-1]
+		case hasRuneSuffix(s, "и"):
+			return s[:n-1]
+		}
+	}
+	return s
+}
+
+// CzechStem is the light stemmer for Czech by Ljiljana Dolamic and Jacques Savoy.
+func CzechStem(s []rune) []rune {
+	s = czechRemoveCase(s)
+	s = czechRemovePossessives(s)
+	if len(s) > 0 {
+		s = czechNormalize(s)
+	}
+	return s
+}
+
+func czechRemoveCase(s []rune) []rune {
+	n := len(s)
+	switch {
+	case n > 7 && hasRuneSuffix(s, "atech"):
+		return s[:n-5]
+	case n > 6 && hasRuneSuffix(s, "ětem", "etem", "atům"):
+		return s[:n-4]
+	case n > 5 && hasRuneSuffix(s, "ech", "ich", "ích", "ého", "ěmi", "emi", "ému", "ěte", "ete", "ěti", "eti", "ího", "iho", "ími", "ímu", "imu", "ách", "ata", "aty", "ých", "ama", "ami", "ové", "ovi", "ými"):
+		return s[:n-3]
+	case n > 4 && hasRuneSuffix(s, "em", "es", "ém", "ím", "ům", "at", "ám", "os", "us", "ým", "mi", "ou"):
+		return s[:n-2]
+	case n > 3:
+		switch s[n-1] {
+		case 'a', 'e', 'i', 'o', 'u', 'ů', 'y', 'á', 'é', 'í', 'ý', 'ě':
+			return s[:n-1]
+		}
+	}
+	return s
+}
+
+func czechRemovePossessives(s []rune) []rune {
+	if len(s) > 5 && hasRuneSuffix(s, "ov", "in", "ův") {
+		return s[:len(s)-2]
+	}
+	return s
+}
+
+func czechNormalize(s []rune) []rune {
+	n := len(s)
+	switch {
+	case hasRuneSuffix(s, "čt"):
+		s[n-2] = 'c'
+		s[n-1] = 'k'
+		return s
+	case hasRuneSuffix(s, "št"):
+		s[n-2] = 's'
+		s[n-1] = 'k'
+		return s
+	}
+
+	switch s[n-1] {
+	case 'c', 'č':
+		s[n-1] = 'k'
+		return s
+	case 'z', 'ž':
+		s[n-1] = 'h'
+		return s
+	}
+
+	if n > 1 && s[n-2] == 'e' {
+		s[n-2] = s[n-1]
+		return s[:n-1]
+	}
+	if n > 2 && s[n-2] == 'ů' {
+		s[n-2] = 'o'
+	}
+	return s
+}
+
+// The prefixes removed by the Indonesian stemmer, which restrict the suffixes to remove.
+const (
+	indonesianRemovedKe = 1 << iota
+	indonesianRemovedPeng
+	indonesianRemovedDi
+	indonesianRemovedMeng
+	indonesianRemovedTer
+	indonesianRemovedBer
+	indonesianRemovedPe
+)
+
+// indonesianStemmer is the stemmer for Indonesian by Fadillah Z Tala.
+type indonesianStemmer struct {
+	s            []rune
+	numSyllables int
+	flags        int
+}
+
+// IndonesianStem removes the particles, the possessive pronouns and the derivational affixes of Indonesian words,
+// e.g. "kecelakaan" to "celaka".
+func IndonesianStem(s []rune) []rune {
+	stemmer := &indonesianStemmer{
+		s: s,
+	}
+	for _, r := range s {
+		if stemmer.isVowel(r) {
+			stemmer.numSyllables++
+		}
+	}
+
+	if stemmer.numSyllables > 2 {
+		stemmer.removeParticle()
+	}
+	if stemmer.numSyllables > 2 {
+		stemmer.removePossessivePronoun()
+	}
+	stemmer.stemDerivational()
+
+	return stemmer.s
+}
+
+func (st *indonesianStemmer) isVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
+
+func (st *indonesianStemmer) removeSuffixOf(length int) {
+	st.numSyllables--
+	st.s = st.s[:len(st.s)-length]
+}
+
+func (st *indonesianStemmer) removePrefixOf(length int, flag int) {
+	st.flags |= flag
+	st.numSyllables--
+	st.s = st.s[length:]
+}
+
+func (st *indonesianStemmer) stemDerivational() {
+	oldLength := len(st.s)
+	if st.numSyllables > 2 {
+		st.removeFirstOrderPrefix()
+	}
+	if oldLength != len(st.s) {
+		oldLength = len(st.s)
+		if st.numSyllables > 2 {
+			st.removeSuffix()
+		}
+		if oldLength != len(st.s) && st.numSyllables > 2 {
+			st.removeSecondOrderPrefix()
+		}
+	} else {
+		if st.numSyllables > 2 {
+			st.removeSecondOrderPrefix()
+		}
+		if st.numSyllables > 2 {
+			st.removeSuffix()
+		}
+	}
+}
+
+func (st *indonesianStemmer) removeParticle() {
+	if hasRuneSuffix(st.s, "kah", "lah", "pun") {
+		st.removeSuffixOf(3)
+	}
+}
+
+func (st *indonesianStemmer) removePossessivePronoun() {
+	switch {
+	case hasRuneSuffix(st.s, "ku", "mu"):
+		st.removeSuffixOf(2)
+	case hasRuneSuffix(st.s, "nya"):
+		st.removeSuffixOf(3)
+	}
+}
+
+func (st *indonesianStemmer) removeFirstOrderPrefix() {
+	s := st.s
+	switch {
+	case hasRunePrefix(s, "meng"):
+		st.removePrefixOf(4, indonesianRemovedMeng)
+	case hasRunePrefix(s, "meny") && len(s) > 4 && st.isVowel(s[4]):
+		s[3] = 's'
+		st.removePrefixOf(3, indonesianRemovedMeng)
+	case hasRunePrefix(s, "men"), hasRunePrefix(s, "mem"):
+		st.removePrefixOf(3, indonesianRemovedMeng)
+	case hasRunePrefix(s, "me"):
+		st.removePrefixOf(2, indonesianRemovedMeng)
+	case hasRunePrefix(s, "peng"):
+		st.removePrefixOf(4, indonesianRemovedPeng)
+	case hasRunePrefix(s, "peny") && len(s) > 4 && st.isVowel(s[4]):
+		s[3] = 's'
+		st.removePrefixOf(3, indonesianRemovedPeng)
+	case hasRunePrefix(s, "peny"):
+		st.removePrefixOf(4, indonesianRemovedPeng)
+	case hasRunePrefix(s, "pen") && len(s) > 3 && st.isVowel(s[3]):
+		s[2] = 't'
+		st.removePrefixOf(2, indonesianRemovedPeng)
+	case hasRunePrefix(s, "pen"), hasRunePrefix(s, "pem"):
+		st.removePrefixOf(3, indonesianRemovedPeng)
+	case hasRunePrefix(s, "di"):
+		st.removePrefixOf(2, indonesianRemovedDi)
+	case hasRunePrefix(s, "ter"):
+		st.removePrefixOf(3, indonesianRemovedTer)
+	case hasRunePrefix(s, "ke"):
+		st.removePrefixOf(2, indonesianRemovedKe)
+	}
+}
+
+func (st *indonesianStemmer) removeSecondOrderPrefix() {
+	s := st.s
+	switch {
+	case hasRunePrefix(s, "ber"):
+		st.removePrefixOf(3, indonesianRemovedBer)
+	case len(s) == 7 && string(s) == "belajar":
+		st.removePrefixOf(3, indonesianRemovedBer)
+	case hasRunePrefix(s, "be") && len(s) > 4 && !st.isVowel(s[2]) && s[3] == 'e' && s[4] == 'r':
+		st.removePrefixOf(2, indonesianRemovedBer)
+	case hasRunePrefix(s, "per"):
+		st.removePrefixOf(3, 0)
+	case len(s) == 7 && string(s) == "pelajar":
+		st.removePrefixOf(3, 0)
+	case hasRunePrefix(s, "pe"):
+		st.removePrefixOf(2, indonesianRemovedPe)
+	}
+}
+
+func (st *indonesianStemmer) removeSuffix() {
+	switch {
+	case hasRuneSuffix(st.s, "kan") && st.flags&(indonesianRemovedKe|indonesianRemovedPeng|indonesianRemovedPe) == 0:
+		st.removeSuffixOf(3)
+	case hasRuneSuffix(st.s, "an") && st.flags&(indonesianRemovedDi|indonesianRemovedMeng|indonesianRemovedTer) == 0:
+		st.removeSuffixOf(2)
+	case hasRuneSuffix(st.s, "i") && !hasRuneSuffix(st.s, "si") && st.flags&(indonesianRemovedBer|indonesianRemovedKe|indonesianRemovedPeng) == 0:
+		st.removeSuffixOf(1)
+	}
+}
@@ -0,0 +1,202 @@
+package token
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/blugelabs/bluge/analysis"
+)
+
+// PatternReplaceFilter replaces the parts of tokens that match the pattern with the replacement.
+// The replacement can refer to the capture groups, e.g. "$1".
+// Tokens that become empty are removed.
+type PatternReplaceFilter struct {
+	pattern     *regexp.Regexp
+	replacement []byte
+	all         bool
+}
+
+func NewPatternReplaceFilter(pattern *regexp.Regexp, replacement string, all bool) *PatternReplaceFilter {
+	return &PatternReplaceFilter{
+		pattern:     pattern,
+		replacement: []byte(replacement),
+		all:         all,
+	}
+}
+
+func (f *PatternReplaceFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	output := make(analysis.TokenStream, 0, len(input))
+	// Positions of the removed tokens are added to the next token.
+	pendingIncr := 0
+	for _, token := range input {
+		if !token.KeyWord {
+			if f.all {
+				token.Term = f.pattern.ReplaceAll(token.Term, f.replacement)
+			} else if match := f.pattern.FindSubmatchIndex(token.Term); match != nil {
+				term := make([]byte, 0, len(token.Term))
+				term = append(term, token.Term[:match[0]]...)
+				term = f.pattern.Expand(term, f.replacement, token.Term, match)
+				term = append(term, token.Term[match[1]:]...)
+				token.Term = term
+			}
+		}
+
+		if len(token.Term) == 0 {
+			pendingIncr += token.PositionIncr
+			continue
+		}
+		token.PositionIncr += pendingIncr
+		pendingIncr = 0
+		output = append(output, token)
+	}
+
+	return output
+}
+
+// Create new PatternReplaceFilter with given options.
+// Options example:
+// {
+//   "pattern": "(\\d+)-(\\d+)",
+//   "replacement": "$1$2",
+//   "all": true
+// }
+func NewPatternReplaceFilterWithOptions(opts map[string]interface{}) (*PatternReplaceFilter, error) {
+	patternValue, ok := opts["pattern"]
+	if !ok {
+		return nil, fmt.Errorf("pattern option does not exist")
+	}
+	patternStr, ok := patternValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("pattern option is unexpected: %v", patternValue)
+	}
+	pattern, err := regexp.Compile(patternStr)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement := ""
+	if replacementValue, ok := opts["replacement"]; ok {
+		replacement, ok = replacementValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("replacement option is unexpected: %v", replacementValue)
+		}
+	}
+
+	all := true
+	if allValue, ok := opts["all"]; ok {
+		all, ok = allValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("all option is unexpected: %v", allValue)
+		}
+	}
+
+	return NewPatternReplaceFilter(pattern, replacement, all), nil
+}
+
+// PatternCaptureFilter emits the capture groups of the patterns that match tokens,
+// at the same positions as the tokens.
+// If a pattern has no capture groups, the whole match is emitted.
+type PatternCaptureFilter struct {
+	patterns         []*regexp.Regexp
+	preserveOriginal bool
+}
+
+func NewPatternCaptureFilter(patterns []*regexp.Regexp, preserveOriginal bool) *PatternCaptureFilter {
+	return &PatternCaptureFilter{
+		patterns:         patterns,
+		preserveOriginal: preserveOriginal,
+	}
+}
+
+func (f *PatternCaptureFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	output := make(analysis.TokenStream, 0, len(input))
+	for _, token := range input {
+		if token.KeyWord {
+			output = append(output, token)
+			continue
+		}
+
+		captures := make([][]byte, 0)
+		seen := make(map[string]bool)
+		if f.preserveOriginal {
+			seen[string(token.Term)] = true
+		}
+		for _, pattern := range f.patterns {
+			for _, match := range pattern.FindAllSubmatch(token.Term, -1) {
+				groups := match[1:]
+				if len(groups) == 0 {
+					groups = match[:1]
+				}
+				for _, group := range groups {
+					if len(group) == 0 || seen[string(group)] {
+						continue
+					}
+					seen[string(group)] = true
+					captures = append(captures, group)
+				}
+			}
+		}
+
+		// Tokens that do not match are kept as they are.
+		if f.preserveOriginal || len(captures) == 0 {
+			output = append(output, token)
+		}
+		for i, capture := range captures {
+			positionIncr := 0
+			if i == 0 && !f.preserveOriginal {
+				positionIncr = token.PositionIncr
+			}
+			output = append(output, &analysis.Token{
+				Start:        token.Start,
+				End:          token.End,
+				Term:         append([]byte{}, capture...),
+				PositionIncr: positionIncr,
+				Type:         token.Type,
+			})
+		}
+	}
+
+	return output
+}
+
+// Create new PatternCaptureFilter with given options.
+// Options example:
+// {
+//   "patterns": [
+//     "(\\p{Ll}+|\\p{Lu}\\p{Ll}+|\\p{Lu}+)",
+//     "(\\d+)"
+//   ],
+//   "preserve_original": true
+// }
+func NewPatternCaptureFilterWithOptions(opts map[string]interface{}) (*PatternCaptureFilter, error) {
+	patternsValue, ok := opts["patterns"]
+	if !ok {
+		return nil, fmt.Errorf("patterns option does not exist")
+	}
+	patternList, ok := patternsValue.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("patterns option is unexpected")
+	}
+	patterns := make([]*regexp.Regexp, 0, len(patternList))
+	for _, patternValue := range patternList {
+		patternStr, ok := patternValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("pattern is unexpected: %v", patternValue)
+		}
+		pattern, err := regexp.Compile(patternStr)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	preserveOriginal := true
+	if preserveOriginalValue, ok := opts["preserve_original"]; ok {
+		preserveOriginal, ok = preserveOriginalValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("preserve_original option is unexpected: %v", preserveOriginalValue)
+		}
+	}
+
+	return NewPatternCaptureFilter(patterns, preserveOriginal), nil
+}
@@ -0,0 +1,380 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blugelabs/bluge/analysis"
+)
+
+const (
+	SoundexEncoder         = "soundex"
+	RefinedSoundexEncoder  = "refined_soundex"
+	MetaphoneEncoder       = "metaphone"
+	DoubleMetaphoneEncoder = "double_metaphone"
+	BeiderMorseEncoder     = "beider_morse"
+)
+
+// PhoneticEncoder returns the phonetic codes of a word.
+// Some encoders return more than one code for a word.
+type PhoneticEncoder func(word string) []string
+
+// lettersOnly returns the ASCII letters of the word in upper case.
+func lettersOnly(word string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToUpper(word) {
+		if r >= 'A' && r <= 'Z' {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// soundexMapping is the American Soundex code of each letter from A to Z.
+// '0' is a vowel and '-' is a letter that is ignored (H, W).
+const soundexMapping = "0123012-02245501262301-202"
+
+// Soundex encodes the word with American Soundex, e.g. "Robert" to "R163".
+func Soundex(word string) []string {
+	str := lettersOnly(word)
+	if str == "" {
+		return nil
+	}
+
+	code := []byte{str[0]}
+	last := soundexMapping[str[0]-'A']
+	for i := 1; i < len(str) && len(code) < 4; i++ {
+		current := soundexMapping[str[i]-'A']
+		switch current {
+		case '-':
+			// H and W do not separate letters with the same code.
+			continue
+		case '0':
+		default:
+			if current != last {
+				code = append(code, current)
+			}
+		}
+		last = current
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+
+	return []string{string(code)}
+}
+
+// refinedSoundexMapping is the Refined Soundex code of each letter from A to Z.
+const refinedSoundexMapping = "01360240043788015936020505"
+
+// RefinedSoundex encodes the word with Refined Soundex, e.g. "Testing" to "T6036084".
+// Unlike Soundex, the length of the code is not fixed.
+func RefinedSoundex(word string) []string {
+	str := lettersOnly(word)
+	if str == "" {
+		return nil
+	}
+
+	code := []byte{str[0]}
+	var last byte
+	for i := 0; i < len(str); i++ {
+		current := refinedSoundexMapping[str[i]-'A']
+		if current == last {
+			continue
+		}
+		code = append(code, current)
+		last = current
+	}
+
+	return []string{string(code)}
+}
+
+func isMetaphoneVowel(c byte) bool {
+	return c == 'A' || c == 'E' || c == 'I' || c == 'O' || c == 'U'
+}
+
+// NewMetaphone returns the Metaphone encoder with the max length of the codes.
+func NewMetaphone(maxCodeLength int) PhoneticEncoder {
+	return func(word string) []string {
+		code := metaphone(lettersOnly(word), maxCodeLength)
+		if code == "" {
+			return nil
+		}
+		return []string{code}
+	}
+}
+
+func metaphone(str string, maxCodeLength int) string {
+	if str == "" {
+		return ""
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(str) {
+			return 0
+		}
+		return str[i]
+	}
+	isNextVowel := func(i int) bool {
+		return isMetaphoneVowel(at(i + 1))
+	}
+
+	var code strings.Builder
+	start := 0
+	// Exceptions at the beginning of the word.
+	switch {
+	case strings.HasPrefix(str, "AE"):
+		code.WriteByte('E')
+		start = 2
+	case strings.HasPrefix(str, "GN"), strings.HasPrefix(str, "KN"), strings.HasPrefix(str, "PN"):
+		code.WriteByte('N')
+		start = 2
+	case strings.HasPrefix(str, "WR"):
+		code.WriteByte('R')
+		start = 2
+	case strings.HasPrefix(str, "WH"):
+		code.WriteByte('W')
+		start = 2
+	case str[0] == 'X':
+		code.WriteByte('S')
+		start = 1
+	}
+
+	for i := start; i < len(str) && (maxCodeLength <= 0 || code.Len() < maxCodeLength); i++ {
+		c := str[i]
+		// Double letters except C produce a single code.
+		if c != 'C' && i > 0 && at(i-1) == c {
+			continue
+		}
+
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				code.WriteByte(c)
+			}
+		case 'B':
+			// Silent in "MB" at the end.
+			if !(i == len(str)-1 && at(i-1) == 'M') {
+				code.WriteByte('B')
+			}
+		case 'C':
+			switch {
+			case at(i+1) == 'I' && at(i+2) == 'A':
+				code.WriteByte('X')
+			case at(i+1) == 'H':
+				if at(i-1) == 'S' {
+					code.WriteByte('K')
+				} else {
+					code.WriteByte('X')
+				}
+				i++
+			case at(i+1) == 'I' || at(i+1) == 'E' || at(i+1) == 'Y':
+				if at(i-1) != 'S' {
+					code.WriteByte('S')
+				}
+			default:
+				code.WriteByte('K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && (at(i+2) == 'E' || at(i+2) == 'I' || at(i+2) == 'Y') {
+				code.WriteByte('J')
+				i += 2
+			} else {
+				code.WriteByte('T')
+			}
+		case 'G':
+			switch {
+			case at(i+1) == 'H' && i+2 < len(str) && !isMetaphoneVowel(at(i+2)):
+				// Silent in "GH" not at the end or before a vowel.
+			case at(i+1) == 'N' && (i+2 == len(str) || (at(i+2) == 'E' && at(i+3) == 'D' && i+4 == len(str))):
+				// Silent in "GN" and "GNED" at the end.
+			case (at(i+1) == 'I' || at(i+1) == 'E' || at(i+1) == 'Y') && at(i-1) != 'G':
+				code.WriteByte('J')
+			default:
+				code.WriteByte('K')
+			}
+		case 'H':
+			if i == len(str)-1 {
+				break
+			}
+			if i > 0 && strings.IndexByte("CSPTG", at(i-1)) >= 0 {
+				break
+			}
+			if isNextVowel(i) {
+				code.WriteByte('H')
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				code.WriteByte('K')
+			}
+		case 'P':
+			if at(i+1) == 'H' {
+				code.WriteByte('F')
+			} else {
+				code.WriteByte('P')
+			}
+		case 'Q':
+			code.WriteByte('K')
+		case 'S':
+			switch {
+			case at(i+1) == 'H':
+				code.WriteByte('X')
+				i++
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				code.WriteByte('X')
+			default:
+				code.WriteByte('S')
+			}
+		case 'T':
+			switch {
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				code.WriteByte('X')
+			case at(i+1) == 'H':
+				code.WriteByte('0')
+				i++
+			case at(i+1) == 'C' && at(i+2) == 'H':
+				// Silent in "TCH".
+			default:
+				code.WriteByte('T')
+			}
+		case 'V':
+			code.WriteByte('F')
+		case 'W', 'Y':
+			if isNextVowel(i) {
+				code.WriteByte(c)
+			}
+		case 'X':
+			code.WriteString("KS")
+		case 'Z':
+			code.WriteByte('S')
+		default:
+			// F, J, L, M, N and R.
+			code.WriteByte(c)
+		}
+	}
+
+	ret := code.String()
+	if maxCodeLength > 0 && len(ret) > maxCodeLength {
+		ret = ret[:maxCodeLength]
+	}
+	return ret
+}
+
+// PhoneticFilter replaces tokens with their phonetic codes,
+// or adds the codes at the same positions as the tokens.
+type PhoneticFilter struct {
+	encoder PhoneticEncoder
+	replace bool
+}
+
+func NewPhoneticFilter(encoder PhoneticEncoder, replace bool) *PhoneticFilter {
+	return &PhoneticFilter{
+		encoder: encoder,
+		replace: replace,
+	}
+}
+
+func (f *PhoneticFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	output := make(analysis.TokenStream, 0, len(input))
+	for _, token := range input {
+		if token.KeyWord {
+			output = append(output, token)
+			continue
+		}
+
+		codes := f.encoder(string(token.Term))
+		// Tokens without codes, such as numbers, are kept as they are.
+		if len(codes) == 0 {
+			output = append(output, token)
+			continue
+		}
+
+		positionIncr := token.PositionIncr
+		if !f.replace {
+			output = append(output, token)
+			positionIncr = 0
+		}
+		for _, code := range codes {
+			if !f.replace && code == string(token.Term) {
+				continue
+			}
+			output = append(output, &analysis.Token{
+				Start:        token.Start,
+				End:          token.End,
+				Term:         []byte(code),
+				PositionIncr: positionIncr,
+				Type:         token.Type,
+			})
+			positionIncr = 0
+		}
+	}
+
+	return output
+}
+
+// Create new PhoneticFilter with given options.
+// Options example:
+// {
+//   "encoder": "double_metaphone",
+//   "replace": false,
+//   "max_code_length": 4,
+//   "rule_type": "approx"
+// }
+func NewPhoneticFilterWithOptions(opts map[string]interface{}) (*PhoneticFilter, error) {
+	encoderValue, ok := opts["encoder"]
+	if !ok {
+		return nil, fmt.Errorf("encoder option does not exist")
+	}
+	encoderStr, ok := encoderValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("encoder option is unexpected: %v", encoderValue)
+	}
+
+	replace := true
+	if replaceValue, ok := opts["replace"]; ok {
+		replace, ok = replaceValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("replace option is unexpected: %v", replaceValue)
+		}
+	}
+
+	maxCodeLength := 4
+	if maxCodeLengthValue, ok := opts["max_code_length"]; ok {
+		maxCodeLengthNum, ok := maxCodeLengthValue.(float64)
+		if !ok {
+			return nil, fmt.Errorf("max_code_length option is unexpected: %v", maxCodeLengthValue)
+		}
+		maxCodeLength = int(maxCodeLengthNum)
+	}
+
+	ruleType := ApproxRuleType
+	if ruleTypeValue, ok := opts["rule_type"]; ok {
+		ruleType, ok = ruleTypeValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("rule_type option is unexpected: %v", ruleTypeValue)
+		}
+		switch ruleType {
+		case ApproxRuleType, ExactRuleType:
+		default:
+			return nil, fmt.Errorf("rule_type option is unexpected: %v", ruleType)
+		}
+	}
+
+	var encoder PhoneticEncoder
+	switch encoderStr {
+	case SoundexEncoder:
+		encoder = Soundex
+	case RefinedSoundexEncoder:
+		encoder = RefinedSoundex
+	case MetaphoneEncoder:
+		encoder = NewMetaphone(maxCodeLength)
+	case DoubleMetaphoneEncoder:
+		encoder = NewDoubleMetaphone(maxCodeLength)
+	case BeiderMorseEncoder:
+		encoder = NewBeiderMorse(ruleType)
+	default:
+		return nil, fmt.Errorf("encoder option is unexpected: %v", encoderStr)
+	}
+
+	return NewPhoneticFilter(encoder, replace), nil
+}
@@ -0,0 +1,148 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/blevesearch/snowballstem/arabic"
+	"github.com/blevesearch/snowballstem/danish"
+	"github.com/blevesearch/snowballstem/dutch"
+	"github.com/blevesearch/snowballstem/english"
+	"github.com/blevesearch/snowballstem/finnish"
+	"github.com/blevesearch/snowballstem/french"
+	"github.com/blevesearch/snowballstem/german"
+	"github.com/blevesearch/snowballstem/hungarian"
+	"github.com/blevesearch/snowballstem/irish"
+	"github.com/blevesearch/snowballstem/italian"
+	"github.com/blevesearch/snowballstem/norwegian"
+	"github.com/blevesearch/snowballstem/porter"
+	"github.com/blevesearch/snowballstem/portuguese"
+	"github.com/blevesearch/snowballstem/romanian"
+	"github.com/blevesearch/snowballstem/russian"
+	"github.com/blevesearch/snowballstem/spanish"
+	"github.com/blevesearch/snowballstem/swedish"
+	"github.com/blevesearch/snowballstem/tamil"
+	"github.com/blevesearch/snowballstem/turkish"
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/blugelabs/bluge/analysis/lang/ckb"
+	"github.com/blugelabs/bluge/analysis/lang/de"
+	"github.com/blugelabs/bluge/analysis/lang/en"
+	"github.com/blugelabs/bluge/analysis/lang/es"
+	"github.com/blugelabs/bluge/analysis/lang/fr"
+	"github.com/blugelabs/bluge/analysis/lang/hi"
+	"github.com/blugelabs/bluge/analysis/lang/it"
+	"github.com/blugelabs/bluge/analysis/lang/pt"
+)
+
+// Maps for the stemmers of the stemmer token filter.
+var (
+	SnowballStemmer_value = map[string]func(*snowballstem.Env) bool{
+		"arabic":     arabic.Stem,
+		"danish":     danish.Stem,
+		"dutch":      dutch.Stem,
+		"english":    english.Stem,
+		"finnish":    finnish.Stem,
+		"french":     french.Stem,
+		"german":     german.Stem,
+		"hungarian":  hungarian.Stem,
+		"irish":      irish.Stem,
+		"italian":    italian.Stem,
+		"norwegian":  norwegian.Stem,
+		"porter":     porter.Stem,
+		"portuguese": portuguese.Stem,
+		"romanian":   romanian.Stem,
+		"russian":    russian.Stem,
+		"spanish":    spanish.Stem,
+		"swedish":    swedish.Stem,
+		"tamil":      tamil.Stem,
+		"turkish":    turkish.Stem,
+	}
+
+	Stemmer_value = map[string]func() analysis.TokenFilter{
+		"bulgarian":          func() analysis.TokenFilter { return NewRuneStemmerFilter(BulgarianStem) },
+		"czech":              func() analysis.TokenFilter { return NewRuneStemmerFilter(CzechStem) },
+		"hindi":              func() analysis.TokenFilter { return hi.StemmerFilter() },
+		"indonesian":         func() analysis.TokenFilter { return NewRuneStemmerFilter(IndonesianStem) },
+		"light_french":       func() analysis.TokenFilter { return fr.LightStemmerFilter() },
+		"light_german":       func() analysis.TokenFilter { return de.LightStemmerFilter() },
+		"light_italian":      func() analysis.TokenFilter { return it.LightStemmerFilter() },
+		"light_portuguese":   func() analysis.TokenFilter { return pt.LightStemmerFilter() },
+		"light_spanish":      func() analysis.TokenFilter { return es.LightStemmerFilter() },
+		"minimal_english":    func() analysis.TokenFilter { return NewRuneStemmerFilter(MinimalEnglishStem) },
+		"minimal_french":     func() analysis.TokenFilter { return fr.MinimalStemmerFilter() },
+		"possessive_english": func() analysis.TokenFilter { return en.NewPossessiveFilter() },
+		"sorani":             func() analysis.TokenFilter { return ckb.StemmerFilter() },
+	}
+)
+
+// SnowballStemmerFilter stems tokens with a Snowball stemmer.
+type SnowballStemmerFilter struct {
+	stem func(*snowballstem.Env) bool
+}
+
+func NewSnowballStemmerFilter(stem func(*snowballstem.Env) bool) *SnowballStemmerFilter {
+	return &SnowballStemmerFilter{
+		stem: stem,
+	}
+}
+
+func (f *SnowballStemmerFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.KeyWord {
+			continue
+		}
+		env := snowballstem.NewEnv(string(token.Term))
+		f.stem(env)
+		token.Term = []byte(env.Current())
+	}
+
+	return input
+}
+
+// RuneStemmerFilter stems tokens with a stemmer working on runes.
+type RuneStemmerFilter struct {
+	stem func([]rune) []rune
+}
+
+func NewRuneStemmerFilter(stem func([]rune) []rune) *RuneStemmerFilter {
+	return &RuneStemmerFilter{
+		stem: stem,
+	}
+}
+
+func (f *RuneStemmerFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.KeyWord {
+			continue
+		}
+		token.Term = []byte(string(f.stem([]rune(string(token.Term)))))
+	}
+
+	return input
+}
+
+// Create new stemmer filter with given options.
+// The Snowball stemmers and the light and minimal stemmers are available.
+// Options example:
+// {
+//   "language": "light_german"
+// }
+func NewStemmerFilterWithOptions(opts map[string]interface{}) (analysis.TokenFilter, error) {
+	languageValue, ok := opts["language"]
+	if !ok {
+		return nil, fmt.Errorf("language option does not exist")
+	}
+	language, ok := languageValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("language option is unexpected: %v", languageValue)
+	}
+
+	if stem, ok := SnowballStemmer_value[language]; ok {
+		return NewSnowballStemmerFilter(stem), nil
+	}
+	if newStemmer, ok := Stemmer_value[language]; ok {
+		return newStemmer(), nil
+	}
+
+	return nil, fmt.Errorf("language option is unexpected: %v", language)
+}
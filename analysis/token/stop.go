@@ -1,9 +1,6 @@
 package token
 
 import (
-	"fmt"
-
-	"github.com/blugelabs/bluge/analysis"
 	"github.com/blugelabs/bluge/analysis/token"
 )
 
@@ -44,24 +41,13 @@ import (
 //     "was",
 //     "will",
 //     "with"
-//   ]
+//   ],
+//   "stop_tokens_uri": "file:///etc/phalanx/stop_tokens.txt"
 // }
 func NewStopTokensFilterWithOptions(opts map[string]interface{}) (*token.StopTokensFilter, error) {
-	stopTokensValue, ok := opts["stop_tokens"]
-	if !ok {
-		return nil, fmt.Errorf("stop_tokens option does not exist")
-	}
-	stopTokens, ok := stopTokensValue.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("stop_tokens option is unexpected")
-	}
-	stopTokenMap := analysis.NewTokenMap()
-	for _, stopToken := range stopTokens {
-		token, ok := stopToken.(string)
-		if !ok {
-			return nil, fmt.Errorf("base_form is unexpected: %v", stopToken)
-		}
-		stopTokenMap.AddToken(token)
+	stopTokenMap, err := newTokenMapWithOptions(opts, "stop_tokens", "stop_tokens_uri")
+	if err != nil {
+		return nil, err
 	}
 
 	return token.NewStopTokensFilter(stopTokenMap), nil
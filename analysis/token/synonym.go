@@ -0,0 +1,435 @@
+package token
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/mosuka/phalanx/analysis/resource"
+)
+
+const (
+	SolrSynonymFormat    = "solr"
+	WordNetSynonymFormat = "wordnet"
+)
+
+// wordNetRegexp matches a line of the WordNet prolog format, e.g. s(100001740,1,'entity',n,1,0).
+var wordNetRegexp = regexp.MustCompile(`^s\((\d+),\d+,'((?:[^']|'')+)',`)
+
+// SynonymMap maps a phrase to the phrases that replace it.
+// A phrase is a sequence of words. The replacements include the phrase itself if it is kept.
+type SynonymMap struct {
+	synonyms       map[string][][]string
+	maxInputLength int
+	ignoreCase     bool
+}
+
+func NewSynonymMap(ignoreCase bool) *SynonymMap {
+	return &SynonymMap{
+		synonyms:   make(map[string][][]string),
+		ignoreCase: ignoreCase,
+	}
+}
+
+func (m *SynonymMap) key(words []string) string {
+	key := strings.Join(words, " ")
+	if m.ignoreCase {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// Add maps the input phrase to the output phrase.
+func (m *SynonymMap) Add(input []string, output []string) {
+	key := m.key(input)
+	for _, phrase := range m.synonyms[key] {
+		if strings.Join(phrase, " ") == strings.Join(output, " ") {
+			return
+		}
+	}
+	m.synonyms[key] = append(m.synonyms[key], output)
+
+	if len(input) > m.maxInputLength {
+		m.maxInputLength = len(input)
+	}
+}
+
+// AddEquivalent maps each phrase to all of the phrases.
+// If expand is false, each phrase is mapped to the first phrase only.
+func (m *SynonymMap) AddEquivalent(phrases [][]string, expand bool) {
+	for _, input := range phrases {
+		if expand {
+			for _, output := range phrases {
+				m.Add(input, output)
+			}
+		} else {
+			m.Add(input, phrases[0])
+		}
+	}
+}
+
+func (m *SynonymMap) lookup(words []string) ([][]string, bool) {
+	phrases, ok := m.synonyms[m.key(words)]
+	return phrases, ok
+}
+
+func splitPhrases(str string) [][]string {
+	phrases := make([][]string, 0)
+	for _, phraseStr := range strings.Split(str, ",") {
+		phrase := strings.Fields(phraseStr)
+		if len(phrase) > 0 {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
+
+// ParseSolrSynonyms parses the rules in the Solr format.
+// "a, b, c" defines equivalent phrases and "a, b => c" replaces a and b with c.
+func ParseSolrSynonyms(m *SynonymMap, rules []string, expand bool) error {
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" || strings.HasPrefix(rule, "#") {
+			continue
+		}
+
+		sides := strings.Split(rule, "=>")
+		switch len(sides) {
+		case 1:
+			phrases := splitPhrases(sides[0])
+			if len(phrases) == 0 {
+				return fmt.Errorf("synonym rule is unexpected: %s", rule)
+			}
+			m.AddEquivalent(phrases, expand)
+		case 2:
+			inputs := splitPhrases(sides[0])
+			outputs := splitPhrases(sides[1])
+			if len(inputs) == 0 || len(outputs) == 0 {
+				return fmt.Errorf("synonym rule is unexpected: %s", rule)
+			}
+			for _, input := range inputs {
+				for _, output := range outputs {
+					m.Add(input, output)
+				}
+			}
+		default:
+			return fmt.Errorf("synonym rule is unexpected: %s", rule)
+		}
+	}
+
+	return nil
+}
+
+// ParseWordNetSynonyms parses the rules in the WordNet prolog format.
+// The words of a synset are equivalent.
+func ParseWordNetSynonyms(m *SynonymMap, rules []string, expand bool) error {
+	synsetIds := make([]string, 0)
+	synsets := make(map[string][][]string)
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		matches := wordNetRegexp.FindStringSubmatch(rule)
+		if matches == nil {
+			return fmt.Errorf("synonym rule is unexpected: %s", rule)
+		}
+		phrase := strings.Fields(strings.ReplaceAll(matches[2], "''", "'"))
+		if _, ok := synsets[matches[1]]; !ok {
+			synsetIds = append(synsetIds, matches[1])
+		}
+		synsets[matches[1]] = append(synsets[matches[1]], phrase)
+	}
+
+	for _, synsetId := range synsetIds {
+		m.AddEquivalent(synsets[synsetId], expand)
+	}
+
+	return nil
+}
+
+// SynonymFilter replaces phrases with their synonyms.
+// Multi-word synonyms are placed on the positions of the input phrase.
+// The synonym filter keeps the positions of the following tokens,
+// and the words of a synonym longer than the input phrase are placed on its last position.
+// The synonym graph filter places the words of every synonym on consecutive positions,
+// so phrase queries match any of the synonyms. The following tokens are placed right after the input phrase
+// if it is kept, so phrase queries across the input phrase match as well, and the words of a longer synonym
+// overlap the following tokens. If the input phrase is replaced, the following tokens are placed right after
+// the longest synonym.
+type SynonymFilter struct {
+	synonyms *SynonymMap
+	graph    bool
+}
+
+func NewSynonymFilter(synonyms *SynonymMap, graph bool) *SynonymFilter {
+	return &SynonymFilter{
+		synonyms: synonyms,
+		graph:    graph,
+	}
+}
+
+// positionedToken is a token with its absolute position in the stream.
+type positionedToken struct {
+	token    *analysis.Token
+	position int
+}
+
+func (f *SynonymFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	if f.synonyms.maxInputLength == 0 {
+		return input
+	}
+
+	positions := make([]int, len(input))
+	position := 0
+	for i, token := range input {
+		position += token.PositionIncr
+		positions[i] = position
+	}
+
+	tokens := make([]positionedToken, 0, len(input))
+	// The number of positions the following tokens are moved by the synonym graph.
+	shift := 0
+	for i := 0; i < len(input); {
+		matched := false
+		maxLength := f.synonyms.maxInputLength
+		if maxLength > len(input)-i {
+			maxLength = len(input) - i
+		}
+		for length := maxLength; length > 0; length-- {
+			words := make([]string, length)
+			for j := 0; j < length; j++ {
+				words[j] = string(input[i+j].Term)
+			}
+			phrases, ok := f.synonyms.lookup(words)
+			if !ok {
+				continue
+			}
+			inputPositions := make([]int, length)
+			for j := 0; j < length; j++ {
+				inputPositions[j] = positions[i+j] + shift
+			}
+			expanded, width := f.expand(input[i:i+length], inputPositions, phrases)
+			tokens = append(tokens, expanded...)
+			if f.graph {
+				shift += width - length
+			}
+			i += length
+			matched = true
+			break
+		}
+		if !matched {
+			tokens = append(tokens, positionedToken{token: input[i], position: positions[i] + shift})
+			i++
+		}
+	}
+
+	// The words of the synonyms overlapping the following tokens are moved among them.
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].position < tokens[j].position
+	})
+
+	output := make(analysis.TokenStream, 0, len(tokens))
+	position = 0
+	for _, token := range tokens {
+		token.token.PositionIncr = token.position - position
+		position = token.position
+		output = append(output, token.token)
+	}
+
+	return output
+}
+
+// expand returns the tokens of the phrases that replace the input tokens on the positions,
+// and the number of positions the following tokens are placed after in the synonym graph.
+func (f *SynonymFilter) expand(input analysis.TokenStream, positions []int, phrases [][]string) ([]positionedToken, int) {
+	inputWords := make([]string, len(input))
+	for i, token := range input {
+		inputWords[i] = string(token.Term)
+	}
+	inputKey := f.synonyms.key(inputWords)
+
+	// The tokens of each phrase by relative position.
+	width := len(input)
+	kept := false
+	longest := 0
+	for _, phrase := range phrases {
+		if f.synonyms.key(phrase) == inputKey {
+			kept = true
+		}
+		if len(phrase) > longest {
+			longest = len(phrase)
+		}
+	}
+	if f.graph {
+		width = longest
+	}
+	columns := make([]analysis.TokenStream, width)
+
+	// The input phrase keeps its own tokens, placed before the synonyms.
+	if kept {
+		for i, token := range input {
+			position := i
+			if position >= width {
+				position = width - 1
+			}
+			columns[position] = append(columns[position], token)
+		}
+	}
+
+	for _, phrase := range phrases {
+		if f.synonyms.key(phrase) == inputKey {
+			continue
+		}
+		for i, word := range phrase {
+			position := i
+			if position >= width {
+				position = width - 1
+			}
+			source := input[len(input)-1]
+			if i < len(input) {
+				source = input[i]
+			}
+			columns[position] = append(columns[position], &analysis.Token{
+				Start: source.Start,
+				End:   source.End,
+				Term:  []byte(word),
+				Type:  source.Type,
+			})
+		}
+	}
+
+	// Positions without tokens are left empty so that the following tokens do not move.
+	output := make([]positionedToken, 0)
+	for i, column := range columns {
+		position := positions[len(positions)-1] + i - len(positions) + 1
+		if i < len(positions) {
+			position = positions[i]
+		}
+		for _, token := range column {
+			output = append(output, positionedToken{token: token, position: position})
+		}
+	}
+
+	if kept {
+		return output, len(input)
+	}
+	return output, longest
+}
+
+func newSynonymMapWithOptions(opts map[string]interface{}) (*SynonymMap, error) {
+	format := SolrSynonymFormat
+	if formatValue, ok := opts["format"]; ok {
+		format, ok = formatValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("format option is unexpected: %v", formatValue)
+		}
+	}
+
+	expand := true
+	if expandValue, ok := opts["expand"]; ok {
+		expand, ok = expandValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expand option is unexpected: %v", expandValue)
+		}
+	}
+
+	ignoreCase := false
+	if ignoreCaseValue, ok := opts["ignore_case"]; ok {
+		ignoreCase, ok = ignoreCaseValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("ignore_case option is unexpected: %v", ignoreCaseValue)
+		}
+	}
+
+	rules := make([]string, 0)
+	synonymsValue, synonymsOk := opts["synonyms"]
+	if synonymsOk {
+		synonyms, ok := synonymsValue.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("synonyms option is unexpected")
+		}
+		for _, synonym := range synonyms {
+			str, ok := synonym.(string)
+			if !ok {
+				return nil, fmt.Errorf("synonym is unexpected: %v", synonym)
+			}
+			rules = append(rules, str)
+		}
+	}
+	synonymsUriValue, synonymsUriOk := opts["synonyms_uri"]
+	if synonymsUriOk {
+		synonymsUri, ok := synonymsUriValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("synonyms_uri option is unexpected: %v", synonymsUriValue)
+		}
+		data, err := resource.Get(synonymsUri)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, resource.Lines(data)...)
+	}
+	if !synonymsOk && !synonymsUriOk {
+		return nil, fmt.Errorf("synonyms option does not exist")
+	}
+
+	synonymMap := NewSynonymMap(ignoreCase)
+	switch format {
+	case SolrSynonymFormat:
+		if err := ParseSolrSynonyms(synonymMap, rules, expand); err != nil {
+			return nil, err
+		}
+	case WordNetSynonymFormat:
+		if err := ParseWordNetSynonyms(synonymMap, rules, expand); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("format option is unexpected: %v", format)
+	}
+
+	return synonymMap, nil
+}
+
+// Create new SynonymFilter with given options.
+// Options example:
+// {
+//   "synonyms": [
+//     "i-pod, i pod => ipod",
+//     "universe, cosmos",
+//     "ny, new york"
+//   ],
+//   "synonyms_uri": "file:///etc/phalanx/synonyms.txt",
+//   "format": "solr",
+//   "expand": true,
+//   "ignore_case": false
+// }
+func NewSynonymFilterWithOptions(opts map[string]interface{}) (*SynonymFilter, error) {
+	synonymMap, err := newSynonymMapWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSynonymFilter(synonymMap, false), nil
+}
+
+// Create new SynonymGraphFilter with given options.
+// Options example:
+// {
+//   "synonyms": [
+//     "ny, new york"
+//   ],
+//   "format": "solr",
+//   "expand": true,
+//   "ignore_case": false
+// }
+func NewSynonymGraphFilterWithOptions(opts map[string]interface{}) (*SynonymFilter, error) {
+	synonymMap, err := newSynonymMapWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSynonymFilter(synonymMap, true), nil
+}
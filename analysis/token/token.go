@@ -3,22 +3,34 @@ package token
 type TokenFilter string
 
 const (
-	ApostropheTokenFilter         TokenFilter = "apostrophe"
-	CamelCaseTokenFilter          TokenFilter = "camel_case"
-	DictionaryCompoundTokenFilter TokenFilter = "dictionary_compound"
-	EdgeNgramTokenFilter          TokenFilter = "edge_ngram"
-	ElisionTokenFilter            TokenFilter = "elision"
-	KeywordMarkerTokenFilter      TokenFilter = "keyword_marker"
-	LengthTokenFilter             TokenFilter = "length"
-	LowerCaseTokenFilter          TokenFilter = "lower_case"
-	NgramTokenFilter              TokenFilter = "ngram"
-	PorterStemmerTokenFilter      TokenFilter = "porter_stemmer"
-	ReverseTokenFilter            TokenFilter = "reverse"
-	ShingleTokenFilter            TokenFilter = "shingle"
-	StopTokensTokenFilter         TokenFilter = "stop_tokens"
-	TruncateTokenFilter           TokenFilter = "truncate"
-	UnicodeNormalizeTokenFilter   TokenFilter = "unicode_normalize"
-	UniqueTermTokenFilter         TokenFilter = "unique_term"
+	ApostropheTokenFilter           TokenFilter = "apostrophe"
+	CamelCaseTokenFilter            TokenFilter = "camel_case"
+	CJKBigramTokenFilter            TokenFilter = "cjk_bigram"
+	DictionaryCompoundTokenFilter   TokenFilter = "dictionary_compound"
+	EdgeNgramTokenFilter            TokenFilter = "edge_ngram"
+	ElisionTokenFilter              TokenFilter = "elision"
+	HunspellTokenFilter             TokenFilter = "hunspell"
+	JapaneseKatakanaStemTokenFilter TokenFilter = "japanese_katakana_stem"
+	JapaneseNumberTokenFilter       TokenFilter = "japanese_number"
+	JapaneseReadingFormTokenFilter  TokenFilter = "japanese_reading_form"
+	KeywordMarkerTokenFilter        TokenFilter = "keyword_marker"
+	LengthTokenFilter               TokenFilter = "length"
+	LowerCaseTokenFilter            TokenFilter = "lower_case"
+	NgramTokenFilter                TokenFilter = "ngram"
+	PatternCaptureTokenFilter       TokenFilter = "pattern_capture"
+	PatternReplaceTokenFilter       TokenFilter = "pattern_replace"
+	PhoneticTokenFilter             TokenFilter = "phonetic"
+	PorterStemmerTokenFilter        TokenFilter = "porter_stemmer"
+	ReverseTokenFilter              TokenFilter = "reverse"
+	ShingleTokenFilter              TokenFilter = "shingle"
+	StemmerTokenFilter              TokenFilter = "stemmer"
+	StopTokensTokenFilter           TokenFilter = "stop_tokens"
+	SynonymTokenFilter              TokenFilter = "synonym"
+	SynonymGraphTokenFilter         TokenFilter = "synonym_graph"
+	TruncateTokenFilter             TokenFilter = "truncate"
+	UnicodeNormalizeTokenFilter     TokenFilter = "unicode_normalize"
+	UniqueTermTokenFilter           TokenFilter = "unique_term"
+	WordDelimiterGraphTokenFilter   TokenFilter = "word_delimiter_graph"
 )
 
 type TokenFilterSetting struct {
@@ -0,0 +1,332 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge/analysis"
+)
+
+type wordDelimiterClass int
+
+const (
+	delimiterClass wordDelimiterClass = iota
+	lowerClass
+	upperClass
+	digitClass
+)
+
+func classifyWordDelimiterRune(r rune) wordDelimiterClass {
+	switch {
+	case unicode.IsLower(r):
+		return lowerClass
+	case unicode.IsUpper(r), unicode.IsLetter(r):
+		return upperClass
+	case unicode.IsDigit(r):
+		return digitClass
+	default:
+		return delimiterClass
+	}
+}
+
+// subword is a part of a token split by the word delimiter graph filter.
+// start and end are the byte offsets in the term.
+type subword struct {
+	start   int
+	end     int
+	numeric bool
+}
+
+// WordDelimiterGraphOptions are the options of WordDelimiterGraphFilter.
+type WordDelimiterGraphOptions struct {
+	GenerateWordParts     bool
+	GenerateNumberParts   bool
+	CatenateWords         bool
+	CatenateNumbers       bool
+	CatenateAll           bool
+	SplitOnCaseChange     bool
+	SplitOnNumerics       bool
+	PreserveOriginal      bool
+	StemEnglishPossessive bool
+	ProtectedWords        analysis.TokenMap
+}
+
+func NewWordDelimiterGraphOptions() WordDelimiterGraphOptions {
+	return WordDelimiterGraphOptions{
+		GenerateWordParts:     true,
+		GenerateNumberParts:   true,
+		CatenateWords:         false,
+		CatenateNumbers:       false,
+		CatenateAll:           false,
+		SplitOnCaseChange:     true,
+		SplitOnNumerics:       true,
+		PreserveOriginal:      false,
+		StemEnglishPossessive: true,
+	}
+}
+
+// WordDelimiterGraphFilter splits tokens into subwords on punctuation, case changes and letter-number transitions,
+// e.g. "iPhone12Pro" into "i", "Phone", "12" and "Pro".
+// The subwords occupy consecutive positions, and the catenated subwords and the original token
+// are placed on the position of their first subword, so phrase queries match either of them.
+type WordDelimiterGraphFilter struct {
+	options WordDelimiterGraphOptions
+}
+
+func NewWordDelimiterGraphFilter(options WordDelimiterGraphOptions) *WordDelimiterGraphFilter {
+	return &WordDelimiterGraphFilter{
+		options: options,
+	}
+}
+
+func (f *WordDelimiterGraphFilter) split(term []byte) []subword {
+	if f.options.StemEnglishPossessive {
+		str := string(term)
+		if strings.HasSuffix(str, "'s") || strings.HasSuffix(str, "'S") {
+			term = term[:len(term)-2]
+		}
+	}
+
+	subwords := make([]subword, 0)
+	var current *subword
+	lastClass := delimiterClass
+	for i := 0; i < len(term); {
+		r, size := utf8.DecodeRune(term[i:])
+		class := classifyWordDelimiterRune(r)
+		_, lastSize := utf8.DecodeLastRune(term[:i])
+
+		split := false
+		switch {
+		case class == delimiterClass:
+			split = true
+		case current == nil:
+		case f.options.SplitOnCaseChange && lastClass == lowerClass && class == upperClass:
+			// "PowerShot" to "Power" and "Shot".
+			split = true
+		case f.options.SplitOnCaseChange && lastClass == upperClass && class == lowerClass && i-current.start > lastSize:
+			// "XMLParser" to "XML" and "Parser". The last upper case letter starts the next subword.
+			current.end = i - lastSize
+			subwords = append(subwords, *current)
+			current = &subword{start: i - lastSize}
+		case f.options.SplitOnNumerics && (lastClass == digitClass) != (class == digitClass):
+			split = true
+		}
+
+		if split && current != nil {
+			current.end = i
+			subwords = append(subwords, *current)
+			current = nil
+		}
+		if class != delimiterClass && current == nil {
+			current = &subword{start: i}
+		}
+		lastClass = class
+		i += size
+	}
+	if current != nil {
+		current.end = len(term)
+		subwords = append(subwords, *current)
+	}
+
+	for i := range subwords {
+		numeric := true
+		for _, r := range string(term[subwords[i].start:subwords[i].end]) {
+			if !unicode.IsDigit(r) {
+				numeric = false
+				break
+			}
+		}
+		subwords[i].numeric = numeric
+	}
+
+	return subwords
+}
+
+func (f *WordDelimiterGraphFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	output := make(analysis.TokenStream, 0, len(input))
+	// Positions of the dropped tokens are added to the next token.
+	pendingIncr := 0
+	for _, token := range input {
+		if token.KeyWord || (f.options.ProtectedWords != nil && f.options.ProtectedWords[string(token.Term)]) {
+			token.PositionIncr += pendingIncr
+			pendingIncr = 0
+			output = append(output, token)
+			continue
+		}
+
+		subwords := f.split(token.Term)
+		if len(subwords) == 1 && subwords[0].start == 0 && subwords[0].end == len(token.Term) {
+			token.PositionIncr += pendingIncr
+			pendingIncr = 0
+			output = append(output, token)
+			continue
+		}
+
+		tokens := f.expand(token, subwords)
+		if len(tokens) == 0 {
+			pendingIncr += token.PositionIncr
+			continue
+		}
+		tokens[0].PositionIncr += pendingIncr
+		pendingIncr = 0
+		output = append(output, tokens...)
+	}
+
+	return output
+}
+
+func (f *WordDelimiterGraphFilter) newToken(token *analysis.Token, term []byte, subwords []subword) *analysis.Token {
+	start := token.Start
+	end := token.End
+	// Offsets of the subwords are available only if the term has not been changed by the preceding filters.
+	if token.End-token.Start == len(token.Term) {
+		start = token.Start + subwords[0].start
+		end = token.Start + subwords[len(subwords)-1].end
+	}
+	tokenType := token.Type
+	if subwords[0].numeric && len(subwords) == 1 {
+		tokenType = analysis.Numeric
+	}
+	return &analysis.Token{
+		Start: start,
+		End:   end,
+		Term:  term,
+		Type:  tokenType,
+	}
+}
+
+func (f *WordDelimiterGraphFilter) catenate(term []byte, subwords []subword) []byte {
+	catenated := make([]byte, 0)
+	for _, subword := range subwords {
+		catenated = append(catenated, term[subword.start:subword.end]...)
+	}
+	return catenated
+}
+
+func (f *WordDelimiterGraphFilter) expand(token *analysis.Token, subwords []subword) analysis.TokenStream {
+	// The tokens placed on the position of each subword.
+	columns := make([]analysis.TokenStream, len(subwords))
+
+	// Catenations that are the same as the preserved original token are omitted.
+	if f.options.CatenateAll && len(subwords) > 1 {
+		catenated := f.catenate(token.Term, subwords)
+		if !f.options.PreserveOriginal || string(catenated) != string(token.Term) {
+			columns[0] = append(columns[0], f.newToken(token, catenated, subwords))
+		}
+	}
+
+	// Runs of the subwords of the same kind are catenated.
+	for i := 0; i < len(subwords); {
+		j := i + 1
+		for j < len(subwords) && subwords[j].numeric == subwords[i].numeric {
+			j++
+		}
+		catenate := f.options.CatenateWords
+		if subwords[i].numeric {
+			catenate = f.options.CatenateNumbers
+		}
+		// The run of all subwords is already added by catenate_all.
+		allCatenated := f.options.CatenateAll && i == 0 && j == len(subwords)
+		if catenate && j-i > 1 && !allCatenated {
+			catenated := f.catenate(token.Term, subwords[i:j])
+			if !f.options.PreserveOriginal || string(catenated) != string(token.Term) {
+				columns[i] = append(columns[i], f.newToken(token, catenated, subwords[i:j]))
+			}
+		}
+		i = j
+	}
+
+	for i, subword := range subwords {
+		generate := f.options.GenerateWordParts
+		if subword.numeric {
+			generate = f.options.GenerateNumberParts
+		}
+		if generate {
+			columns[i] = append(columns[i], f.newToken(token, token.Term[subword.start:subword.end], subwords[i:i+1]))
+		}
+	}
+
+	// The original token is placed on the position of the first subword.
+	// Columns without tokens do not take positions.
+	output := make(analysis.TokenStream, 0)
+	if f.options.PreserveOriginal {
+		output = append(output, token)
+	}
+	positionIncr := token.PositionIncr
+	if f.options.PreserveOriginal {
+		positionIncr = 0
+	}
+	for _, column := range columns {
+		if len(column) == 0 {
+			continue
+		}
+		for i, t := range column {
+			if i == 0 {
+				t.PositionIncr = positionIncr
+			} else {
+				t.PositionIncr = 0
+			}
+			output = append(output, t)
+		}
+		positionIncr = 1
+	}
+
+	return output
+}
+
+// Create new WordDelimiterGraphFilter with given options.
+// Options example:
+// {
+//   "generate_word_parts": true,
+//   "generate_number_parts": true,
+//   "catenate_words": false,
+//   "catenate_numbers": false,
+//   "catenate_all": false,
+//   "split_on_case_change": true,
+//   "split_on_numerics": true,
+//   "preserve_original": false,
+//   "stem_english_possessive": true,
+//   "protected_words": [
+//     "C++"
+//   ],
+//   "protected_words_uri": "file:///etc/phalanx/protected_words.txt"
+// }
+func NewWordDelimiterGraphFilterWithOptions(opts map[string]interface{}) (*WordDelimiterGraphFilter, error) {
+	options := NewWordDelimiterGraphOptions()
+
+	boolOptions := map[string]*bool{
+		"generate_word_parts":     &options.GenerateWordParts,
+		"generate_number_parts":   &options.GenerateNumberParts,
+		"catenate_words":          &options.CatenateWords,
+		"catenate_numbers":        &options.CatenateNumbers,
+		"catenate_all":            &options.CatenateAll,
+		"split_on_case_change":    &options.SplitOnCaseChange,
+		"split_on_numerics":       &options.SplitOnNumerics,
+		"preserve_original":       &options.PreserveOriginal,
+		"stem_english_possessive": &options.StemEnglishPossessive,
+	}
+	for name, option := range boolOptions {
+		value, ok := opts[name]
+		if !ok {
+			continue
+		}
+		*option, ok = value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s option is unexpected: %v", name, value)
+		}
+	}
+
+	_, protectedWordsOk := opts["protected_words"]
+	_, protectedWordsUriOk := opts["protected_words_uri"]
+	if protectedWordsOk || protectedWordsUriOk {
+		protectedWords, err := newTokenMapWithOptions(opts, "protected_words", "protected_words_uri")
+		if err != nil {
+			return nil, err
+		}
+		options.ProtectedWords = protectedWords
+	}
+
+	return NewWordDelimiterGraphFilter(options), nil
+}
@@ -0,0 +1,50 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/mosuka/phalanx/analysis/resource"
+)
+
+// newTokenMapWithOptions makes a token map from the words in the words option
+// and the words in the resource at the URI option, one word per line.
+func newTokenMapWithOptions(opts map[string]interface{}, wordsOption string, uriOption string) (analysis.TokenMap, error) {
+	wordsValue, wordsOk := opts[wordsOption]
+	uriValue, uriOk := opts[uriOption]
+	if !wordsOk && !uriOk {
+		return nil, fmt.Errorf("%s option does not exist", wordsOption)
+	}
+
+	tokenMap := analysis.NewTokenMap()
+
+	if wordsOk {
+		words, ok := wordsValue.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s option is unexpected", wordsOption)
+		}
+		for _, word := range words {
+			str, ok := word.(string)
+			if !ok {
+				return nil, fmt.Errorf("word is unexpected: %v", word)
+			}
+			tokenMap.AddToken(str)
+		}
+	}
+
+	if uriOk {
+		uri, ok := uriValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s option is unexpected: %v", uriOption, uriValue)
+		}
+		data, err := resource.Get(uri)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range resource.Lines(data) {
+			tokenMap.AddToken(line)
+		}
+	}
+
+	return tokenMap, nil
+}
@@ -0,0 +1,152 @@
+package tokenizer
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge/analysis"
+)
+
+// Maps for the character classes of the tokenizers.
+var (
+	CharClass_value = map[string]func(rune) bool{
+		"letter":      unicode.IsLetter,
+		"digit":       unicode.IsDigit,
+		"whitespace":  unicode.IsSpace,
+		"punctuation": unicode.IsPunct,
+		"symbol":      unicode.IsSymbol,
+	}
+)
+
+// newCharMatcher returns a function that reports whether a character is in one of the classes or is one of the chars.
+func newCharMatcher(classes []func(rune) bool, chars map[rune]bool) func(rune) bool {
+	return func(r rune) bool {
+		if chars[r] {
+			return true
+		}
+		for _, class := range classes {
+			if class(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// newCharMatcherWithOptions returns a character matcher from the list option of class names and single characters.
+// If the option does not exist, ok is false.
+func newCharMatcherWithOptions(opts map[string]interface{}, optionName string, allowChars bool) (matcher func(rune) bool, ok bool, err error) {
+	value, ok := opts[optionName]
+	if !ok {
+		return nil, false, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%s option is unexpected", optionName)
+	}
+
+	classes := make([]func(rune) bool, 0)
+	chars := make(map[rune]bool)
+	for _, item := range list {
+		str, ok := item.(string)
+		if !ok {
+			return nil, false, fmt.Errorf("%s option is unexpected: %v", optionName, item)
+		}
+		if class, ok := CharClass_value[str]; ok {
+			classes = append(classes, class)
+			continue
+		}
+		if allowChars && utf8.RuneCountInString(str) == 1 {
+			r, _ := utf8.DecodeRuneInString(str)
+			chars[r] = true
+			continue
+		}
+		return nil, false, fmt.Errorf("%s option is unexpected: %v", optionName, str)
+	}
+
+	return newCharMatcher(classes, chars), true, nil
+}
+
+// CharacterGroupTokenizer splits text on the given characters.
+// Tokens longer than the max token length are split at the max token length.
+type CharacterGroupTokenizer struct {
+	isDelimiter    func(rune) bool
+	maxTokenLength int
+}
+
+func NewCharacterGroupTokenizer(isDelimiter func(rune) bool, maxTokenLength int) *CharacterGroupTokenizer {
+	return &CharacterGroupTokenizer{
+		isDelimiter:    isDelimiter,
+		maxTokenLength: maxTokenLength,
+	}
+}
+
+func (t *CharacterGroupTokenizer) Tokenize(input []byte) analysis.TokenStream {
+	ret := make(analysis.TokenStream, 0)
+	start := -1
+	length := 0
+	flush := func(end int) {
+		if start >= 0 {
+			ret = append(ret, &analysis.Token{
+				Start:        start,
+				End:          end,
+				Term:         input[start:end],
+				PositionIncr: 1,
+				Type:         analysis.AlphaNumeric,
+			})
+		}
+		start = -1
+		length = 0
+	}
+
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRune(input[i:])
+		if t.isDelimiter(r) {
+			flush(i)
+		} else {
+			if t.maxTokenLength > 0 && length >= t.maxTokenLength {
+				flush(i)
+			}
+			if start < 0 {
+				start = i
+			}
+			length++
+		}
+		i += size
+	}
+	flush(len(input))
+
+	return ret
+}
+
+// Create new CharGroupTokenizer with given options.
+// Options example:
+// {
+//   "tokenize_on_chars": [
+//     "whitespace",
+//     "-",
+//     "\n"
+//   ],
+//   "max_token_length": 255
+// }
+func NewCharGroupTokenizerWithOptions(opts map[string]interface{}) (*CharacterGroupTokenizer, error) {
+	isDelimiter, ok, err := newCharMatcherWithOptions(opts, "tokenize_on_chars", true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("tokenize_on_chars option does not exist")
+	}
+
+	maxTokenLength := 255
+	if maxTokenLengthValue, ok := opts["max_token_length"]; ok {
+		maxTokenLengthNum, ok := maxTokenLengthValue.(float64)
+		if !ok {
+			return nil, fmt.Errorf("max_token_length option is unexpected: %v", maxTokenLengthValue)
+		}
+		maxTokenLength = int(maxTokenLengthNum)
+	}
+
+	return NewCharacterGroupTokenizer(isDelimiter, maxTokenLength), nil
+}
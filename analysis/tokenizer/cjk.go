@@ -0,0 +1,46 @@
+package tokenizer
+
+import (
+	"fmt"
+
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/blugelabs/bluge/analysis/tokenizer"
+	phalanxtoken "github.com/mosuka/phalanx/analysis/token"
+)
+
+// BigramTokenizer splits text with the unicode tokenizer and outputs adjacent CJK characters as bigrams,
+// for Chinese and Korean text, and Japanese text that is not covered by the kagome tokenizer.
+// It is the same as the unicode tokenizer followed by the cjk_bigram token filter.
+// Other tokens are output as they are.
+type BigramTokenizer struct {
+	tokenizer analysis.Tokenizer
+	filter    analysis.TokenFilter
+}
+
+func NewBigramTokenizer(outputUnigrams bool) *BigramTokenizer {
+	return &BigramTokenizer{
+		tokenizer: tokenizer.NewUnicodeTokenizer(),
+		filter:    phalanxtoken.NewCJKBigramFilter(outputUnigrams),
+	}
+}
+
+func (t *BigramTokenizer) Tokenize(input []byte) analysis.TokenStream {
+	return t.filter.Filter(t.tokenizer.Tokenize(input))
+}
+
+// Create new CJKBigramTokenizer with given options.
+// Options example:
+// {
+//   "output_unigrams": false
+// }
+func NewCJKBigramTokenizerWithOptions(opts map[string]interface{}) (*BigramTokenizer, error) {
+	outputUnigrams := false
+	if outputUnigramsValue, ok := opts["output_unigrams"]; ok {
+		outputUnigrams, ok = outputUnigramsValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("output_unigrams option is unexpected: %v", outputUnigramsValue)
+		}
+	}
+
+	return NewBigramTokenizer(outputUnigrams), nil
+}
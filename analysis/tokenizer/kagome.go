@@ -1,19 +1,166 @@
 package tokenizer
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/blugelabs/bluge/analysis"
-	"github.com/ikawaha/blugeplugin/analysis/lang/ja"
 	"github.com/ikawaha/kagome-dict/dict"
 	"github.com/ikawaha/kagome-dict/ipa"
 	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/mosuka/phalanx/analysis/resource"
+	phalanxtoken "github.com/mosuka/phalanx/analysis/token"
 )
 
+const (
+	posHierarchy      = 4
+	defaultPOSFeature = "*"
+)
+
+// Maps for the segmentation modes of Kagome.
+var (
+	KagomeMode_value = map[string]tokenizer.TokenizeMode{
+		"normal":   tokenizer.Normal,
+		"search":   tokenizer.Search,
+		"extended": tokenizer.Extended,
+	}
+)
+
+// Reading forms of Kagome tokens.
+const (
+	KatakanaReadingForm = "katakana"
+	RomajiReadingForm   = "romaji"
+)
+
+var kagomeSentenceSplitter = filter.SentenceSplitter{
+	Delim:               []rune{'。', '．', '！', '!', '？', '?'},
+	Follower:            []rune{'.', '｣', '」', '』', ')', '）', '｝', '}', '〉', '》'},
+	SkipWhiteSpace:      false,
+	DoubleLineFeedSplit: true,
+	MaxRuneLen:          128,
+}
+
+// JapaneseTokenizer splits Japanese text into morphemes.
+// The part of speech of each morpheme is available only here,
+// so the part-of-speech stop, base form and reading form are applied by the tokenizer.
+type JapaneseTokenizer struct {
+	tokenizer      *tokenizer.Tokenizer
+	mode           tokenizer.TokenizeMode
+	stopTagFilter  *filter.POSFilter
+	baseFormFilter *filter.POSFilter
+	readingForm    string
+}
+
+func (t *JapaneseTokenizer) Tokenize(input []byte) analysis.TokenStream {
+	scanner := bufio.NewScanner(bytes.NewReader(input))
+	scanner.Split(kagomeSentenceSplitter.ScanSentences)
+	offset := 0
+	prevIncr := 0
+	var ret analysis.TokenStream
+	for scanner.Scan() {
+		sentence := scanner.Text()
+		tokens := t.tokenizer.Analyze(sentence, t.mode)
+		before := len(tokens)
+		if t.stopTagFilter != nil {
+			t.stopTagFilter.Drop(&tokens)
+		}
+		after := 0
+		if len(tokens) > 0 {
+			after = tokens[len(tokens)-1].Index + 1
+		}
+		for i, v := range tokens {
+			start := offset + v.Position
+			end := offset + v.Position + len(v.Surface)
+			term := input[start:end]
+			if t.baseFormFilter != nil && t.baseFormFilter.Match(v.POS()) {
+				if baseForm, ok := v.BaseForm(); ok && baseForm != defaultPOSFeature {
+					term = []byte(baseForm)
+				}
+			}
+			if t.readingForm != "" {
+				if reading, ok := kagomeReading(v); ok && reading != defaultPOSFeature {
+					switch t.readingForm {
+					case KatakanaReadingForm:
+						term = []byte(reading)
+					case RomajiReadingForm:
+						term = []byte(phalanxtoken.KanaToRomaji(reading))
+					}
+				}
+			}
+			incr := 0
+			if i == 0 {
+				incr = prevIncr + v.Index + 1
+				prevIncr = 0
+			} else {
+				incr = v.Index - tokens[i-1].Index
+			}
+			ret = append(ret, &analysis.Token{
+				Start:        start,
+				End:          end,
+				Term:         term,
+				PositionIncr: incr,
+				Type:         analysis.Ideographic,
+				KeyWord:      false,
+			})
+		}
+		offset += len(sentence)
+		prevIncr = prevIncr + (before - after)
+	}
+	return ret
+}
+
+// kagomeReading returns the reading of the token.
+// The readings of the tokens in user dictionaries are not available from Token.Reading.
+func kagomeReading(token tokenizer.Token) (string, bool) {
+	if token.Class == tokenizer.USER {
+		features := token.Features()
+		if len(features) < 3 {
+			return "", false
+		}
+		return strings.ReplaceAll(features[2], "/", ""), true
+	}
+
+	return token.Reading()
+}
+
+func newPOSList(optionName string, opts map[string]interface{}, pad bool) ([]filter.POS, error) {
+	posValue, ok := opts[optionName]
+	if !ok {
+		return nil, nil
+	}
+	posList, ok := posValue.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s option is unexpected", optionName)
+	}
+
+	ps := make([]filter.POS, 0, len(posList))
+	for _, posIntr := range posList {
+		posStr, ok := posIntr.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s option is unexpected: %v", optionName, posIntr)
+		}
+		pos := strings.Split(posStr, "-")
+		if pad {
+			for i := len(pos); i < posHierarchy; i++ {
+				pos = append(pos, defaultPOSFeature)
+			}
+		}
+		ps = append(ps, pos)
+	}
+
+	return ps, nil
+}
+
 // Create new KagomeTokenizer with given options.
 // Options example:
 // {
 //   "dictionary": "IPADIC",
+//   "user_dictionary_uri": "file:///etc/phalanx/userdict.txt",
+//   "mode": "search",
 //   "stop_tags": [
 //     "接続詞",
 //     "助詞",
@@ -47,7 +194,8 @@ import (
 //     "動詞",
 //     "形容詞",
 //     "形容動詞"
-//   ]
+//   ],
+//   "reading_form": "katakana"
 // }
 func NewKagomeTokenizerWithOptions(opts map[string]interface{}) (analysis.Tokenizer, error) {
 	dictionaryValue, ok := opts["dictionary"]
@@ -64,41 +212,84 @@ func NewKagomeTokenizerWithOptions(opts map[string]interface{}) (analysis.Tokeni
 		dictionary = ipa.Dict()
 	case "UniDIC":
 		dictionary = uni.Dict()
+	default:
+		return nil, fmt.Errorf("dict option is unexpected: %v", dictionaryStr)
 	}
 
-	stopTagsValue, ok := opts["stop_tags"]
-	if !ok {
-		return nil, fmt.Errorf("stop_tags option does not exist")
+	tokenizerOpts := []tokenizer.Option{tokenizer.OmitBosEos()}
+	if userDictionaryUriValue, ok := opts["user_dictionary_uri"]; ok {
+		userDictionaryUri, ok := userDictionaryUriValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("user_dictionary_uri option is unexpected: %v", userDictionaryUriValue)
+		}
+		data, err := resource.Get(userDictionaryUri)
+		if err != nil {
+			return nil, err
+		}
+		records, err := dict.NewUserDicRecords(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		userDictionary, err := records.NewUserDict()
+		if err != nil {
+			return nil, err
+		}
+		tokenizerOpts = append(tokenizerOpts, tokenizer.UserDict(userDictionary))
 	}
-	stopTags, ok := stopTagsValue.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("stop_tags option is unexpected")
+
+	// search is the default mode for compatibility.
+	mode := tokenizer.Search
+	if modeValue, ok := opts["mode"]; ok {
+		modeStr, ok := modeValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("mode option is unexpected: %v", modeValue)
+		}
+		mode, ok = KagomeMode_value[modeStr]
+		if !ok {
+			return nil, fmt.Errorf("mode option is unexpected: %v", modeStr)
+		}
 	}
-	stopTagsTokenMap := analysis.NewTokenMap()
-	for _, stopTag := range stopTags {
-		token, ok := stopTag.(string)
+
+	readingForm := ""
+	if readingFormValue, ok := opts["reading_form"]; ok {
+		readingForm, ok = readingFormValue.(string)
 		if !ok {
-			return nil, fmt.Errorf("stop_tag is unexpected: %v", stopTag)
+			return nil, fmt.Errorf("reading_form option is unexpected: %v", readingFormValue)
+		}
+		switch readingForm {
+		case KatakanaReadingForm, RomajiReadingForm:
+		default:
+			return nil, fmt.Errorf("reading_form option is unexpected: %v", readingForm)
 		}
-		stopTagsTokenMap.AddToken(token)
 	}
 
-	baseFormsValue, ok := opts["base_forms"]
-	if !ok {
-		return nil, fmt.Errorf("stop_tags option does not exist")
+	kagomeTokenizer, err := tokenizer.New(dictionary, tokenizerOpts...)
+	if err != nil {
+		return nil, err
 	}
-	baseForms, ok := baseFormsValue.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("stop_tags option is unexpected")
+
+	ret := &JapaneseTokenizer{
+		tokenizer:   kagomeTokenizer,
+		mode:        mode,
+		readingForm: readingForm,
 	}
-	baseFormsTokenMap := analysis.NewTokenMap()
-	for _, baseForm := range baseForms {
-		token, ok := baseForm.(string)
-		if !ok {
-			return nil, fmt.Errorf("stop_tag is unexpected: %v", baseForm)
-		}
-		baseFormsTokenMap.AddToken(token)
+
+	// stop_tags and base_forms are optional.
+	stopTags, err := newPOSList("stop_tags", opts, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(stopTags) > 0 {
+		ret.stopTagFilter = filter.NewPOSFilter(stopTags...)
+	}
+
+	baseForms, err := newPOSList("base_forms", opts, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(baseForms) > 0 {
+		ret.baseFormFilter = filter.NewPOSFilter(baseForms...)
 	}
 
-	return ja.NewJapaneseTokenizer(dictionary, ja.StopTagsFilter(stopTagsTokenMap), ja.BaseFormFilter(baseFormsTokenMap)), nil
+	return ret, nil
 }
@@ -0,0 +1,145 @@
+package tokenizer
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge/analysis"
+)
+
+// CharacterNgramTokenizer splits text into runs of token characters and outputs the n-grams of each run.
+// The edge n-gram tokenizer outputs only the n-grams at the beginning of each run, for autocomplete.
+type CharacterNgramTokenizer struct {
+	minGram     int
+	maxGram     int
+	edge        bool
+	isTokenChar func(rune) bool
+}
+
+func NewCharacterNgramTokenizer(minGram int, maxGram int, edge bool, isTokenChar func(rune) bool) *CharacterNgramTokenizer {
+	return &CharacterNgramTokenizer{
+		minGram:     minGram,
+		maxGram:     maxGram,
+		edge:        edge,
+		isTokenChar: isTokenChar,
+	}
+}
+
+func (t *CharacterNgramTokenizer) Tokenize(input []byte) analysis.TokenStream {
+	ret := make(analysis.TokenStream, 0)
+
+	// The byte offsets of the characters of the current run, and the offset of its end.
+	offsets := make([]int, 0)
+	flush := func(end int) {
+		offsets = append(offsets, end)
+		runeCount := len(offsets) - 1
+		for i := 0; i < runeCount; i++ {
+			for n := t.minGram; n <= t.maxGram && i+n <= runeCount; n++ {
+				gramStart := offsets[i]
+				gramEnd := offsets[i+n]
+				ret = append(ret, &analysis.Token{
+					Start:        gramStart,
+					End:          gramEnd,
+					Term:         input[gramStart:gramEnd],
+					PositionIncr: 1,
+					Type:         analysis.AlphaNumeric,
+				})
+			}
+			if t.edge {
+				break
+			}
+		}
+		offsets = offsets[:0]
+	}
+
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRune(input[i:])
+		if t.isTokenChar == nil || t.isTokenChar(r) {
+			offsets = append(offsets, i)
+		} else if len(offsets) > 0 {
+			flush(i)
+		}
+		i += size
+	}
+	if len(offsets) > 0 {
+		flush(len(input))
+	}
+
+	return ret
+}
+
+func newNgramTokenizerWithOptions(opts map[string]interface{}, edge bool) (*CharacterNgramTokenizer, error) {
+	minGram := 1
+	if minGramValue, ok := opts["min_gram"]; ok {
+		minGramNum, ok := minGramValue.(float64)
+		if !ok {
+			return nil, fmt.Errorf("min_gram option is unexpected: %v", minGramValue)
+		}
+		minGram = int(minGramNum)
+	}
+
+	maxGram := 2
+	if maxGramValue, ok := opts["max_gram"]; ok {
+		maxGramNum, ok := maxGramValue.(float64)
+		if !ok {
+			return nil, fmt.Errorf("max_gram option is unexpected: %v", maxGramValue)
+		}
+		maxGram = int(maxGramNum)
+	}
+
+	if minGram < 1 || maxGram < minGram {
+		return nil, fmt.Errorf("min_gram and max_gram options are unexpected: %d, %d", minGram, maxGram)
+	}
+
+	// All characters are token characters by default.
+	isTokenChar, tokenCharsOk, err := newCharMatcherWithOptions(opts, "token_chars", false)
+	if err != nil {
+		return nil, err
+	}
+	if customTokenCharsValue, ok := opts["custom_token_chars"]; ok {
+		customTokenChars, ok := customTokenCharsValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom_token_chars option is unexpected: %v", customTokenCharsValue)
+		}
+		chars := make(map[rune]bool)
+		for _, r := range customTokenChars {
+			chars[r] = true
+		}
+		classes := make([]func(rune) bool, 0)
+		if tokenCharsOk {
+			classes = append(classes, isTokenChar)
+		}
+		isTokenChar = newCharMatcher(classes, chars)
+	}
+
+	return NewCharacterNgramTokenizer(minGram, maxGram, edge, isTokenChar), nil
+}
+
+// Create new NgramTokenizer with given options.
+// Options example:
+// {
+//   "min_gram": 1,
+//   "max_gram": 2,
+//   "token_chars": [
+//     "letter",
+//     "digit"
+//   ],
+//   "custom_token_chars": "+-_"
+// }
+func NewNgramTokenizerWithOptions(opts map[string]interface{}) (*CharacterNgramTokenizer, error) {
+	return newNgramTokenizerWithOptions(opts, false)
+}
+
+// Create new EdgeNgramTokenizer with given options.
+// Options example:
+// {
+//   "min_gram": 1,
+//   "max_gram": 10,
+//   "token_chars": [
+//     "letter",
+//     "digit"
+//   ]
+// }
+func NewEdgeNgramTokenizerWithOptions(opts map[string]interface{}) (*CharacterNgramTokenizer, error) {
+	return newNgramTokenizerWithOptions(opts, true)
+}
@@ -0,0 +1,151 @@
+package tokenizer
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge/analysis"
+)
+
+// PathTokenizer outputs the ancestors of a path, e.g. "/usr/local/bin" to
+// "/usr", "/usr/local" and "/usr/local/bin", on the same position.
+// In reverse, it outputs the descendants, e.g. "www.example.com" with the delimiter "." to
+// "www.example.com", "example.com" and "com".
+type PathTokenizer struct {
+	delimiter   []byte
+	replacement []byte
+	reverse     bool
+	skip        int
+}
+
+func NewPathTokenizer(delimiter string, replacement string, reverse bool, skip int) *PathTokenizer {
+	return &PathTokenizer{
+		delimiter:   []byte(delimiter),
+		replacement: []byte(replacement),
+		reverse:     reverse,
+		skip:        skip,
+	}
+}
+
+func (t *PathTokenizer) newToken(input []byte, start int, end int) *analysis.Token {
+	return &analysis.Token{
+		Start:        start,
+		End:          end,
+		Term:         bytes.ReplaceAll(input[start:end], t.delimiter, t.replacement),
+		PositionIncr: 0,
+		Type:         analysis.AlphaNumeric,
+	}
+}
+
+func (t *PathTokenizer) Tokenize(input []byte) analysis.TokenStream {
+	ret := make(analysis.TokenStream, 0)
+	if len(input) == 0 {
+		return ret
+	}
+
+	// The offsets of the delimiters.
+	delimiters := make([]int, 0)
+	for i := 0; i < len(input); {
+		if bytes.HasPrefix(input[i:], t.delimiter) {
+			delimiters = append(delimiters, i)
+			i += len(t.delimiter)
+			continue
+		}
+		_, size := utf8.DecodeRune(input[i:])
+		i += size
+	}
+
+	if !t.reverse {
+		// Each component starts with the delimiter before it, e.g. "/usr" and "/local".
+		ends := make([]int, 0)
+		for _, delimiter := range delimiters {
+			if delimiter > 0 {
+				ends = append(ends, delimiter)
+			}
+		}
+		if len(delimiters) == 0 || delimiters[len(delimiters)-1]+len(t.delimiter) < len(input) {
+			ends = append(ends, len(input))
+		}
+		if t.skip >= len(ends) {
+			return ret
+		}
+		start := 0
+		if t.skip > 0 {
+			start = ends[t.skip-1]
+		}
+		for _, end := range ends[t.skip:] {
+			ret = append(ret, t.newToken(input, start, end))
+		}
+	} else {
+		// Each component ends with the delimiter after it, e.g. "www." and "example.".
+		starts := []int{0}
+		for _, delimiter := range delimiters {
+			if start := delimiter + len(t.delimiter); start < len(input) {
+				starts = append(starts, start)
+			}
+		}
+		if t.skip >= len(starts) {
+			return ret
+		}
+		end := len(input)
+		if t.skip > 0 {
+			end = starts[len(starts)-t.skip]
+		}
+		for _, start := range starts[:len(starts)-t.skip] {
+			ret = append(ret, t.newToken(input, start, end))
+		}
+	}
+
+	if len(ret) > 0 {
+		ret[0].PositionIncr = 1
+	}
+
+	return ret
+}
+
+// Create new PathHierarchyTokenizer with given options.
+// Options example:
+// {
+//   "delimiter": "/",
+//   "replacement": "/",
+//   "reverse": false,
+//   "skip": 0
+// }
+func NewPathHierarchyTokenizerWithOptions(opts map[string]interface{}) (*PathTokenizer, error) {
+	delimiter := "/"
+	if delimiterValue, ok := opts["delimiter"]; ok {
+		delimiter, ok = delimiterValue.(string)
+		if !ok || delimiter == "" {
+			return nil, fmt.Errorf("delimiter option is unexpected: %v", delimiterValue)
+		}
+	}
+
+	// The delimiter is output as it is by default.
+	replacement := delimiter
+	if replacementValue, ok := opts["replacement"]; ok {
+		replacement, ok = replacementValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("replacement option is unexpected: %v", replacementValue)
+		}
+	}
+
+	reverse := false
+	if reverseValue, ok := opts["reverse"]; ok {
+		reverse, ok = reverseValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("reverse option is unexpected: %v", reverseValue)
+		}
+	}
+
+	skip := 0
+	if skipValue, ok := opts["skip"]; ok {
+		skipNum, ok := skipValue.(float64)
+		if !ok || skipNum < 0 {
+			return nil, fmt.Errorf("skip option is unexpected: %v", skipValue)
+		}
+		skip = int(skipNum)
+	}
+
+	return NewPathTokenizer(delimiter, replacement, reverse, skip), nil
+}
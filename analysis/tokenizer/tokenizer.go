@@ -3,15 +3,20 @@ package tokenizer
 type Tokenizer string
 
 const (
-	CharacterTokenizer   Tokenizer = "character"
-	ExceptionTokenizer   Tokenizer = "exception"
-	KagomeTokenizer      Tokenizer = "kagome"
-	LetterTokenizer      Tokenizer = "letter"
-	RegexpTokenizer      Tokenizer = "regexp"
-	SingleTokenTokenizer Tokenizer = "single_token"
-	UnicodeTokenizer     Tokenizer = "unicode"
-	WebTokenizer         Tokenizer = "web"
-	WhitespaceTokenizer  Tokenizer = "whitespace"
+	CharacterTokenizer     Tokenizer = "character"
+	CharGroupTokenizer     Tokenizer = "char_group"
+	CJKBigramTokenizer     Tokenizer = "cjk_bigram"
+	EdgeNgramTokenizer     Tokenizer = "edge_ngram"
+	ExceptionTokenizer     Tokenizer = "exception"
+	KagomeTokenizer        Tokenizer = "kagome"
+	LetterTokenizer        Tokenizer = "letter"
+	NgramTokenizer         Tokenizer = "ngram"
+	PathHierarchyTokenizer Tokenizer = "path_hierarchy"
+	RegexpTokenizer        Tokenizer = "regexp"
+	SingleTokenTokenizer   Tokenizer = "single_token"
+	UnicodeTokenizer       Tokenizer = "unicode"
+	WebTokenizer           Tokenizer = "web"
+	WhitespaceTokenizer    Tokenizer = "whitespace"
 )
 
 type TokenizerSetting struct {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/joho/godotenv"
 	homedir "github.com/mitchellh/go-homedir"
+	"github.com/mosuka/phalanx/analysis/resource"
 	phalanxcluster "github.com/mosuka/phalanx/cluster"
 	"github.com/mosuka/phalanx/logging"
 	phalanxmetastore "github.com/mosuka/phalanx/metastore"
@@ -32,6 +33,8 @@ const defaultHttpPort int = 8000
 
 const defaultIndexMetastoreUri string = "file:///var/lib/phalanx/metastore"
 
+const defaultResourceReloadInterval time.Duration = resource.DefaultReloadInterval
+
 const defaultCertificateFile string = ""
 const defaultKeyFile string = ""
 const defaultCommonName string = ""
@@ -56,6 +59,8 @@ var (
 
 	indexMetastoreUri string
 
+	resourceReloadInterval time.Duration
+
 	certificateFile string
 	keyFile         string
 	commonName      string
@@ -96,6 +101,8 @@ var (
 
 			indexMetastoreUri = viper.GetString("index_metastore_uri")
 
+			resourceReloadInterval = viper.GetDuration("resource_reload_interval")
+
 			certificateFile = viper.GetString("certificate_file")
 			keyFile = viper.GetString("key_file")
 			commonName = viper.GetString("common_name")
@@ -150,7 +157,7 @@ var (
 			}
 
 			// Create index manager
-			indexService, err := server.NewIndexService(cluster, metastore, certificateFile, commonName, logger)
+			indexService, err := server.NewIndexService(cluster, metastore, resourceReloadInterval, certificateFile, commonName, logger)
 			if err != nil {
 				return err
 			}
@@ -322,6 +329,8 @@ func init() {
 
 	phalanxCmd.Flags().StringVar(&indexMetastoreUri, "index-metastore-uri", defaultIndexMetastoreUri, "index metastore URI.")
 
+	phalanxCmd.Flags().DurationVar(&resourceReloadInterval, "resource-reload-interval", defaultResourceReloadInterval, "interval to check the analysis resources, such as word lists, for changes (e.g. 60s)")
+
 	phalanxCmd.Flags().StringVar(&certificateFile, "certificate-file", defaultCertificateFile, "path to the client server TLS certificate file")
 	phalanxCmd.Flags().StringVar(&keyFile, "key-file", defaultKeyFile, "path to the client server TLS key file")
 	phalanxCmd.Flags().StringVar(&commonName, "common-name", defaultCommonName, "certificate common name")
@@ -350,6 +359,8 @@ func init() {
 
 	_ = viper.BindPFlag("index_metastore_uri", phalanxCmd.Flags().Lookup("index-metastore-uri"))
 
+	_ = viper.BindPFlag("resource_reload_interval", phalanxCmd.Flags().Lookup("resource-reload-interval"))
+
 	_ = viper.BindPFlag("certificate_file", phalanxCmd.Flags().Lookup("certificate-file"))
 	_ = viper.BindPFlag("key_file", phalanxCmd.Flags().Lookup("key-file"))
 	_ = viper.BindPFlag("common_name", phalanxCmd.Flags().Lookup("common-name"))
@@ -15,6 +15,7 @@ var (
 	ErrUnsupportedDirectoryType    = errors.New("unsupported directory type")
 
 	ErrDocumentIdDoesNotExist = errors.New("document ID does not exist")
+	ErrDocumentDoesNotExist   = errors.New("document does not exist")
 	ErrInvalidDocument        = errors.New("invalid document")
 
 	ErrInvalidUri = errors.New("invalid URI")
@@ -48,6 +49,16 @@ var (
 
 	ErrUnknownHighlighterType = errors.New("unknown query type")
 
+	ErrUnknownFusionType = errors.New("unknown fusion type")
+
+	ErrUnknownSuggesterType = errors.New("unknown suggester type")
+
+	ErrUnknownProcessorType = errors.New("unknown processor type")
+	ErrPipelineDoesNotExist = errors.New("pipeline does not exist")
+	ErrInvalidPipeline      = errors.New("invalid pipeline")
+	ErrPipelineInUse        = errors.New("pipeline is in use")
+	ErrDocumentDropped      = errors.New("document dropped")
+
 	ErrNodeDoesNotFound = errors.New("node not found")
 	ErrInvalidData      = errors.New("invalid data")
 
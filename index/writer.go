@@ -5,7 +5,6 @@ import (
 	"sync"
 
 	"github.com/blugelabs/bluge"
-	phalanxanalyzer "github.com/mosuka/phalanx/analysis/analyzer"
 	"github.com/mosuka/phalanx/directory"
 	"github.com/mosuka/phalanx/errors"
 	"github.com/mosuka/phalanx/mapping"
@@ -96,7 +95,7 @@ func (i *IndexWriters) open(indexName string, shardName string, indexMetadata *m
 		config.DefaultSearchField = mapping.AllFieldName
 	}
 
-	config.DefaultSearchAnalyzer, err = phalanxanalyzer.NewAnalyzer(indexMetadata.DefaultAnalyzer)
+	config.DefaultSearchAnalyzer, err = indexMetadata.AnalyzerCache().Get(indexMetadata.DefaultAnalyzer)
 	if err != nil {
 		i.logger.Warn(err.Error(), zap.Any("default_analyzer", indexMetadata.DefaultAnalyzer))
 	}
@@ -0,0 +1,60 @@
+package ingest
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// Condition decides whether a processor runs for a document.
+// All of the specified checks must be satisfied.
+type Condition struct {
+	Field     string      `json:"field"`
+	Exists    *bool       `json:"exists,omitempty"`
+	Equals    interface{} `json:"equals,omitempty"`
+	NotEquals interface{} `json:"not_equals,omitempty"`
+	Matches   string      `json:"matches,omitempty"`
+
+	matchesRegexp *regexp.Regexp
+}
+
+func (c *Condition) compile() error {
+	if c.Field == "" {
+		return fmt.Errorf("field option does not exist")
+	}
+
+	if c.Matches != "" {
+		matchesRegexp, err := regexp.Compile(c.Matches)
+		if err != nil {
+			return err
+		}
+		c.matchesRegexp = matchesRegexp
+	}
+
+	return nil
+}
+
+func (c *Condition) Match(fields map[string]interface{}) bool {
+	value, exists := fields[c.Field]
+
+	if c.Exists != nil && *c.Exists != exists {
+		return false
+	}
+
+	if c.Equals != nil && !reflect.DeepEqual(value, c.Equals) {
+		return false
+	}
+
+	if c.NotEquals != nil && reflect.DeepEqual(value, c.NotEquals) {
+		return false
+	}
+
+	if c.matchesRegexp != nil {
+		strValue, ok := value.(string)
+		if !ok || !c.matchesRegexp.MatchString(strValue) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,165 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type ConvertType string
+
+const (
+	ConvertTypeInteger ConvertType = "integer"
+	ConvertTypeFloat   ConvertType = "float"
+	ConvertTypeString  ConvertType = "string"
+	ConvertTypeBoolean ConvertType = "boolean"
+)
+
+type ConvertProcessorOptions struct {
+	Field         string      `json:"field"`
+	Type          ConvertType `json:"type"`
+	TargetField   string      `json:"target_field"`
+	IgnoreMissing bool        `json:"ignore_missing"`
+}
+
+func NewConvertProcessorOptions() ConvertProcessorOptions {
+	return ConvertProcessorOptions{}
+}
+
+// Create new ConvertProcessor with given options.
+// Options example:
+// {
+//   "field": "price",
+//   "type": "float",
+//   "target_field": "price",
+//   "ignore_missing": false
+// }
+func NewConvertProcessorWithMap(opts map[string]interface{}) (*ConvertProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewConvertProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewConvertProcessorWithOptions(options)
+}
+
+func NewConvertProcessorWithOptions(opts ConvertProcessorOptions) (*ConvertProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	switch opts.Type {
+	case ConvertTypeInteger, ConvertTypeFloat, ConvertTypeString, ConvertTypeBoolean:
+	default:
+		return nil, fmt.Errorf("type option is unexpected: %v", opts.Type)
+	}
+
+	// target_field is optional.
+	targetField := opts.TargetField
+	if targetField == "" {
+		targetField = opts.Field
+	}
+
+	return &ConvertProcessor{
+		field:         opts.Field,
+		convertType:   opts.Type,
+		targetField:   targetField,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// ConvertProcessor converts the type of a field value.
+// Arrays are converted element by element.
+type ConvertProcessor struct {
+	field         string
+	convertType   ConvertType
+	targetField   string
+	ignoreMissing bool
+}
+
+func (p *ConvertProcessor) Process(fields map[string]interface{}) error {
+	value, ok, err := getFieldValue(fields, p.field, p.ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+
+	if values, ok := value.([]interface{}); ok {
+		newValues := make([]interface{}, len(values))
+		for i, v := range values {
+			newValues[i], err = p.convert(v)
+			if err != nil {
+				return err
+			}
+		}
+		fields[p.targetField] = newValues
+		return nil
+	}
+
+	newValue, err := p.convert(value)
+	if err != nil {
+		return err
+	}
+	fields[p.targetField] = newValue
+
+	return nil
+}
+
+func (p *ConvertProcessor) convert(value interface{}) (interface{}, error) {
+	switch p.convertType {
+	case ConvertTypeInteger:
+		switch v := value.(type) {
+		case float64:
+			return math.Trunc(v), nil
+		case string:
+			i64Value, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return float64(i64Value), nil
+		case bool:
+			if v {
+				return 1.0, nil
+			}
+			return 0.0, nil
+		}
+	case ConvertTypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			return strconv.ParseFloat(strings.TrimSpace(v), 64)
+		case bool:
+			if v {
+				return 1.0, nil
+			}
+			return 0.0, nil
+		}
+	case ConvertTypeString:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case ConvertTypeBoolean:
+		switch v := value.(type) {
+		case float64:
+			return v != 0, nil
+		case string:
+			return strconv.ParseBool(strings.TrimSpace(v))
+		case bool:
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot convert %v to %s", value, p.convertType)
+}
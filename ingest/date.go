@@ -0,0 +1,106 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mosuka/phalanx/mapping"
+)
+
+type DateProcessorOptions struct {
+	Field         string   `json:"field"`
+	TargetField   string   `json:"target_field"`
+	Formats       []string `json:"formats"`
+	Timezone      string   `json:"timezone"`
+	IgnoreMissing bool     `json:"ignore_missing"`
+}
+
+func NewDateProcessorOptions() DateProcessorOptions {
+	return DateProcessorOptions{
+		Formats:  []string{"RFC3339"},
+		Timezone: "UTC",
+	}
+}
+
+// Create new DateProcessor with given options.
+// Options example:
+// {
+//   "field": "published",
+//   "target_field": "published",
+//   "formats": ["2006-01-02 15:04:05", "epoch_millis"],
+//   "timezone": "Asia/Tokyo",
+//   "ignore_missing": false
+// }
+func NewDateProcessorWithMap(opts map[string]interface{}) (*DateProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewDateProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewDateProcessorWithOptions(options)
+}
+
+func NewDateProcessorWithOptions(opts DateProcessorOptions) (*DateProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if len(opts.Formats) == 0 {
+		return nil, fmt.Errorf("formats option does not exist")
+	}
+
+	if err := mapping.ValidateDateTimeFormats(opts.Formats); err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(opts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// target_field is optional.
+	targetField := opts.TargetField
+	if targetField == "" {
+		targetField = opts.Field
+	}
+
+	return &DateProcessor{
+		field:         opts.Field,
+		targetField:   targetField,
+		formats:       opts.Formats,
+		location:      location,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// DateProcessor parses a date with the first matching format and sets it in RFC 3339 format.
+// The formats are the same as the formats of datetime fields.
+// Formats without a time zone are parsed in the given time zone.
+type DateProcessor struct {
+	field         string
+	targetField   string
+	formats       []string
+	location      *time.Location
+	ignoreMissing bool
+}
+
+func (p *DateProcessor) Process(fields map[string]interface{}) error {
+	value, ok, err := getFieldValue(fields, p.field, p.ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+
+	datetimeValue, err := mapping.MakeDateTimeInLocation(value, p.formats, p.location)
+	if err != nil {
+		return err
+	}
+	fields[p.targetField] = datetimeValue.Format(time.RFC3339Nano)
+
+	return nil
+}
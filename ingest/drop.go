@@ -0,0 +1,42 @@
+package ingest
+
+import (
+	"encoding/json"
+
+	"github.com/mosuka/phalanx/errors"
+)
+
+type DropProcessorOptions struct{}
+
+func NewDropProcessorOptions() DropProcessorOptions {
+	return DropProcessorOptions{}
+}
+
+// Create new DropProcessor with given options.
+// Options example:
+// {}
+func NewDropProcessorWithMap(opts map[string]interface{}) (*DropProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewDropProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewDropProcessorWithOptions(options)
+}
+
+func NewDropProcessorWithOptions(opts DropProcessorOptions) (*DropProcessor, error) {
+	return &DropProcessor{}, nil
+}
+
+// DropProcessor silently drops the document.
+// It is usually combined with a condition.
+type DropProcessor struct{}
+
+func (p *DropProcessor) Process(fields map[string]interface{}) error {
+	return errors.ErrDocumentDropped
+}
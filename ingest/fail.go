@@ -0,0 +1,51 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type FailProcessorOptions struct {
+	Message string `json:"message"`
+}
+
+func NewFailProcessorOptions() FailProcessorOptions {
+	return FailProcessorOptions{
+		Message: "document failed",
+	}
+}
+
+// Create new FailProcessor with given options.
+// Options example:
+// {
+//   "message": "title is missing"
+// }
+func NewFailProcessorWithMap(opts map[string]interface{}) (*FailProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewFailProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewFailProcessorWithOptions(options)
+}
+
+func NewFailProcessorWithOptions(opts FailProcessorOptions) (*FailProcessor, error) {
+	return &FailProcessor{
+		message: opts.Message,
+	}, nil
+}
+
+// FailProcessor rejects the document with a message.
+// It is usually combined with a condition.
+type FailProcessor struct {
+	message string
+}
+
+func (p *FailProcessor) Process(fields map[string]interface{}) error {
+	return fmt.Errorf("%s", p.message)
+}
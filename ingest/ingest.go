@@ -0,0 +1,220 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/mosuka/phalanx/errors"
+	"github.com/mosuka/phalanx/mapping"
+	"github.com/mosuka/phalanx/proto"
+)
+
+// NonePipelineName disables the default pipeline of the index for a request.
+const NonePipelineName = "_none"
+
+// pipelineNameRegexp matches the pipeline names.
+// The names are also the file names of the pipelines in the metastore, so they cannot contain path separators.
+var pipelineNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// IsValidPipelineName returns whether the name can be used as a pipeline name.
+// The names starting with "_", such as NonePipelineName, are reserved.
+func IsValidPipelineName(pipelineName string) bool {
+	return pipelineNameRegexp.MatchString(pipelineName)
+}
+
+type ProcessorType int
+
+const (
+	ProcessorTypeUnknown ProcessorType = iota
+	ProcessorTypeConvert
+	ProcessorTypeDate
+	ProcessorTypeDrop
+	ProcessorTypeFail
+	ProcessorTypeLanguageDetect
+	ProcessorTypeLowercase
+	ProcessorTypeRegexExtract
+	ProcessorTypeRemove
+	ProcessorTypeRename
+	ProcessorTypeSet
+	ProcessorTypeSplit
+	ProcessorTypeTrim
+)
+
+// Maps for ProcessorType.
+var (
+	ProcessorType_name = map[ProcessorType]string{
+		ProcessorTypeUnknown:        "unknown",
+		ProcessorTypeConvert:        "convert",
+		ProcessorTypeDate:           "date",
+		ProcessorTypeDrop:           "drop",
+		ProcessorTypeFail:           "fail",
+		ProcessorTypeLanguageDetect: "language_detect",
+		ProcessorTypeLowercase:      "lowercase",
+		ProcessorTypeRegexExtract:   "regex_extract",
+		ProcessorTypeRemove:         "remove",
+		ProcessorTypeRename:         "rename",
+		ProcessorTypeSet:            "set",
+		ProcessorTypeSplit:          "split",
+		ProcessorTypeTrim:           "trim",
+	}
+	ProcessorType_value = map[string]ProcessorType{
+		"unknown":         ProcessorTypeUnknown,
+		"convert":         ProcessorTypeConvert,
+		"date":            ProcessorTypeDate,
+		"drop":            ProcessorTypeDrop,
+		"fail":            ProcessorTypeFail,
+		"language_detect": ProcessorTypeLanguageDetect,
+		"lowercase":       ProcessorTypeLowercase,
+		"regex_extract":   ProcessorTypeRegexExtract,
+		"remove":          ProcessorTypeRemove,
+		"rename":          ProcessorTypeRename,
+		"set":             ProcessorTypeSet,
+		"split":           ProcessorTypeSplit,
+		"trim":            ProcessorTypeTrim,
+	}
+)
+
+// Processor modifies the fields of a document in place.
+// Returning errors.ErrDocumentDropped drops the document without reporting an error.
+type Processor interface {
+	Process(fields map[string]interface{}) error
+}
+
+func NewProcessor(processorType string, processorOpts map[string]interface{}) (Processor, error) {
+	switch ProcessorType_value[processorType] {
+	case ProcessorTypeConvert:
+		return NewConvertProcessorWithMap(processorOpts)
+	case ProcessorTypeDate:
+		return NewDateProcessorWithMap(processorOpts)
+	case ProcessorTypeDrop:
+		return NewDropProcessorWithMap(processorOpts)
+	case ProcessorTypeFail:
+		return NewFailProcessorWithMap(processorOpts)
+	case ProcessorTypeLanguageDetect:
+		return NewLanguageDetectProcessorWithMap(processorOpts)
+	case ProcessorTypeLowercase:
+		return NewLowercaseProcessorWithMap(processorOpts)
+	case ProcessorTypeRegexExtract:
+		return NewRegexExtractProcessorWithMap(processorOpts)
+	case ProcessorTypeRemove:
+		return NewRemoveProcessorWithMap(processorOpts)
+	case ProcessorTypeRename:
+		return NewRenameProcessorWithMap(processorOpts)
+	case ProcessorTypeSet:
+		return NewSetProcessorWithMap(processorOpts)
+	case ProcessorTypeSplit:
+		return NewSplitProcessorWithMap(processorOpts)
+	case ProcessorTypeTrim:
+		return NewTrimProcessorWithMap(processorOpts)
+	default:
+		return nil, errors.ErrUnknownProcessorType
+	}
+}
+
+type ProcessorSetting struct {
+	Type      string                 `json:"type"`
+	Options   map[string]interface{} `json:"options,omitempty"`
+	Condition *Condition             `json:"if,omitempty"`
+}
+
+type PipelineSetting struct {
+	Description string             `json:"description"`
+	Processors  []ProcessorSetting `json:"processors"`
+}
+
+func NewPipelineSetting(source []byte) (*PipelineSetting, error) {
+	var pipelineSetting PipelineSetting
+	if err := json.Unmarshal(source, &pipelineSetting); err != nil {
+		return nil, err
+	}
+
+	return &pipelineSetting, nil
+}
+
+func (s *PipelineSetting) Marshal() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+type conditionalProcessor struct {
+	condition *Condition
+	processor Processor
+}
+
+func (p *conditionalProcessor) Process(fields map[string]interface{}) error {
+	if p.condition != nil && !p.condition.Match(fields) {
+		return nil
+	}
+
+	return p.processor.Process(fields)
+}
+
+// Pipeline runs processors in order.
+type Pipeline struct {
+	processors []Processor
+}
+
+func NewPipeline(setting *PipelineSetting) (*Pipeline, error) {
+	processors := make([]Processor, 0, len(setting.Processors))
+	for i, processorSetting := range setting.Processors {
+		processor, err := NewProcessor(processorSetting.Type, processorSetting.Options)
+		if err != nil {
+			return nil, fmt.Errorf("processor %d (%s): %w", i, processorSetting.Type, err)
+		}
+		// The condition is copied so that the setting can be shared by pipelines.
+		var condition *Condition
+		if processorSetting.Condition != nil {
+			tmpCondition := *processorSetting.Condition
+			if err := tmpCondition.compile(); err != nil {
+				return nil, fmt.Errorf("processor %d (%s): %w", i, processorSetting.Type, err)
+			}
+			condition = &tmpCondition
+		}
+		processors = append(processors, &conditionalProcessor{
+			condition: condition,
+			processor: processor,
+		})
+	}
+
+	return &Pipeline{
+		processors: processors,
+	}, nil
+}
+
+// Execute runs the pipeline on the document and returns the processed document.
+// It returns nil if a processor dropped the document.
+func (p *Pipeline) Execute(doc *proto.Document) (*proto.Document, error) {
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(doc.Fields, &fields); err != nil {
+		return nil, err
+	}
+
+	// The document ID can be modified by processors.
+	fields[mapping.IdFieldName] = doc.Id
+
+	for _, processor := range p.processors {
+		if err := processor.Process(fields); err != nil {
+			if err == errors.ErrDocumentDropped {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	id, ok := fields[mapping.IdFieldName].(string)
+	if !ok || id == "" {
+		return nil, errors.ErrDocumentIdDoesNotExist
+	}
+
+	fieldsBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Document{
+		Id:        id,
+		Score:     doc.Score,
+		Timestamp: doc.Timestamp,
+		Fields:    fieldsBytes,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mosuka/phalanx/analysis/analyzer"
+)
+
+const DefaultLanguageDetectTargetField = "language"
+
+type LanguageDetectProcessorOptions struct {
+	Field         string `json:"field"`
+	TargetField   string `json:"target_field"`
+	IgnoreMissing bool   `json:"ignore_missing"`
+}
+
+func NewLanguageDetectProcessorOptions() LanguageDetectProcessorOptions {
+	return LanguageDetectProcessorOptions{
+		TargetField: DefaultLanguageDetectTargetField,
+	}
+}
+
+// Create new LanguageDetectProcessor with given options.
+// Options example:
+// {
+//   "field": "description",
+//   "target_field": "language",
+//   "ignore_missing": false
+// }
+func NewLanguageDetectProcessorWithMap(opts map[string]interface{}) (*LanguageDetectProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewLanguageDetectProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewLanguageDetectProcessorWithOptions(options)
+}
+
+func NewLanguageDetectProcessorWithOptions(opts LanguageDetectProcessorOptions) (*LanguageDetectProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.TargetField == "" {
+		return nil, fmt.Errorf("target_field option does not exist")
+	}
+
+	return &LanguageDetectProcessor{
+		field:         opts.Field,
+		targetField:   opts.TargetField,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// LanguageDetectProcessor sets the ISO 639-1 code of the language of a text field.
+// The target field is not set if the language cannot be detected.
+type LanguageDetectProcessor struct {
+	field         string
+	targetField   string
+	ignoreMissing bool
+}
+
+func (p *LanguageDetectProcessor) Process(fields map[string]interface{}) error {
+	value, ok, err := getFieldValue(fields, p.field, p.ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+
+	strValue, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("unexpected string value: %v", value)
+	}
+
+	detector, err := analyzer.GetAnalyzerDetector()
+	if err != nil {
+		return err
+	}
+
+	if language, ok := detector.DetectLanguage(strValue); ok {
+		fields[p.targetField] = language
+	}
+
+	return nil
+}
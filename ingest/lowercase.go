@@ -0,0 +1,80 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type LowercaseProcessorOptions struct {
+	Field         string `json:"field"`
+	TargetField   string `json:"target_field"`
+	IgnoreMissing bool   `json:"ignore_missing"`
+}
+
+func NewLowercaseProcessorOptions() LowercaseProcessorOptions {
+	return LowercaseProcessorOptions{}
+}
+
+// Create new LowercaseProcessor with given options.
+// Options example:
+// {
+//   "field": "title",
+//   "target_field": "title",
+//   "ignore_missing": false
+// }
+func NewLowercaseProcessorWithMap(opts map[string]interface{}) (*LowercaseProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewLowercaseProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewLowercaseProcessorWithOptions(options)
+}
+
+func NewLowercaseProcessorWithOptions(opts LowercaseProcessorOptions) (*LowercaseProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	// target_field is optional.
+	targetField := opts.TargetField
+	if targetField == "" {
+		targetField = opts.Field
+	}
+
+	return &LowercaseProcessor{
+		field:         opts.Field,
+		targetField:   targetField,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// LowercaseProcessor converts a string field to lower case.
+type LowercaseProcessor struct {
+	field         string
+	targetField   string
+	ignoreMissing bool
+}
+
+func (p *LowercaseProcessor) Process(fields map[string]interface{}) error {
+	value, ok, err := getFieldValue(fields, p.field, p.ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+
+	newValue, err := applyToStrings(value, func(value string) (interface{}, error) {
+		return strings.ToLower(value), nil
+	})
+	if err != nil {
+		return err
+	}
+	fields[p.targetField] = newValue
+
+	return nil
+}
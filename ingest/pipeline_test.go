@@ -0,0 +1,119 @@
+package ingest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/mosuka/phalanx/proto"
+)
+
+func newTestPipeline(t *testing.T) *Pipeline {
+	pipelineFile := "../testdata/test_pipeline.json"
+
+	bytes, err := ioutil.ReadFile(pipelineFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	pipelineSetting, err := NewPipelineSetting(bytes)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	pipeline, err := NewPipeline(pipelineSetting)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return pipeline
+}
+
+func TestPipelineExecute(t *testing.T) {
+	pipeline := newTestPipeline(t)
+
+	doc := &proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"_id":"1","title":"  Phalanx  ","category":"SEARCH","tags":"go, search,,index","price":"12.5","published":"2022-01-02 03:04:05","sku":"ABC-123","body":"hello","status":"published"}`),
+	}
+
+	processedDoc, err := pipeline.Execute(doc)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if processedDoc.Id != "ABC-123" {
+		t.Fatalf("expected %v, but %v\n", "ABC-123", processedDoc.Id)
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(processedDoc.Fields, &fields); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	expected := map[string]interface{}{
+		"_id":        "ABC-123",
+		"title":      "Phalanx",
+		"category":   "search",
+		"tags":       []interface{}{"go", "search", "index"},
+		"price":      12.5,
+		"published":  "2022-01-02T03:04:05Z",
+		"sku":        "ABC-123",
+		"sku_prefix": "ABC",
+		"sku_number": "123",
+		"text":       "hello",
+	}
+	if !reflect.DeepEqual(expected, fields) {
+		t.Fatalf("expected %v, but %v\n", expected, fields)
+	}
+}
+
+func TestPipelineExecuteDrop(t *testing.T) {
+	pipeline := newTestPipeline(t)
+
+	doc := &proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"_id":"1","title":"Phalanx","status":"draft"}`),
+	}
+
+	processedDoc, err := pipeline.Execute(doc)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if processedDoc != nil {
+		t.Fatalf("expected %v, but %v\n", nil, processedDoc)
+	}
+}
+
+func TestPipelineExecuteFail(t *testing.T) {
+	pipeline := newTestPipeline(t)
+
+	doc := &proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"_id":"1","status":"published"}`),
+	}
+
+	_, err := pipeline.Execute(doc)
+	if err == nil {
+		t.Fatalf("expected error, but nil\n")
+	}
+	if err.Error() != "title is missing" {
+		t.Fatalf("expected %v, but %v\n", "title is missing", err.Error())
+	}
+}
+
+func TestIsValidPipelineName(t *testing.T) {
+	for _, pipelineName := range []string{"example", "nginx_logs", "v2-logs", "1st"} {
+		if !IsValidPipelineName(pipelineName) {
+			t.Fatalf("expected valid pipeline name: %v\n", pipelineName)
+		}
+	}
+
+	for _, pipelineName := range []string{"", NonePipelineName, "../example", "logs/nginx", "..", "example.json"} {
+		if IsValidPipelineName(pipelineName) {
+			t.Fatalf("expected invalid pipeline name: %v\n", pipelineName)
+		}
+	}
+}
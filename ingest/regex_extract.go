@@ -0,0 +1,110 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+type RegexExtractProcessorOptions struct {
+	Field         string `json:"field"`
+	Pattern       string `json:"pattern"`
+	IgnoreMissing bool   `json:"ignore_missing"`
+	IgnoreNoMatch bool   `json:"ignore_no_match"`
+}
+
+func NewRegexExtractProcessorOptions() RegexExtractProcessorOptions {
+	return RegexExtractProcessorOptions{}
+}
+
+// Create new RegexExtractProcessor with given options.
+// Options example:
+// {
+//   "field": "message",
+//   "pattern": "^(?P<client_ip>\\S+) (?P<method>[A-Z]+) (?P<path>\\S+)$",
+//   "ignore_missing": false,
+//   "ignore_no_match": false
+// }
+func NewRegexExtractProcessorWithMap(opts map[string]interface{}) (*RegexExtractProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewRegexExtractProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewRegexExtractProcessorWithOptions(options)
+}
+
+func NewRegexExtractProcessorWithOptions(opts RegexExtractProcessorOptions) (*RegexExtractProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.Pattern == "" {
+		return nil, fmt.Errorf("pattern option does not exist")
+	}
+
+	pattern, err := regexp.Compile(opts.Pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNamedGroup := false
+	for _, name := range pattern.SubexpNames() {
+		if name != "" {
+			hasNamedGroup = true
+			break
+		}
+	}
+	if !hasNamedGroup {
+		return nil, fmt.Errorf("pattern option has no named groups: %v", opts.Pattern)
+	}
+
+	return &RegexExtractProcessor{
+		field:         opts.Field,
+		pattern:       pattern,
+		ignoreMissing: opts.IgnoreMissing,
+		ignoreNoMatch: opts.IgnoreNoMatch,
+	}, nil
+}
+
+// RegexExtractProcessor sets the named groups of a regular expression as fields.
+type RegexExtractProcessor struct {
+	field         string
+	pattern       *regexp.Regexp
+	ignoreMissing bool
+	ignoreNoMatch bool
+}
+
+func (p *RegexExtractProcessor) Process(fields map[string]interface{}) error {
+	value, ok, err := getFieldValue(fields, p.field, p.ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+
+	strValue, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("unexpected string value: %v", value)
+	}
+
+	match := p.pattern.FindStringSubmatch(strValue)
+	if match == nil {
+		if p.ignoreNoMatch {
+			return nil
+		}
+		return fmt.Errorf("pattern does not match: %s", p.field)
+	}
+
+	for i, name := range p.pattern.SubexpNames() {
+		if name == "" || i >= len(match) {
+			continue
+		}
+		fields[name] = match[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mosuka/phalanx/mapping"
+)
+
+type RemoveProcessorOptions struct {
+	Fields        []string `json:"fields"`
+	IgnoreMissing bool     `json:"ignore_missing"`
+}
+
+func NewRemoveProcessorOptions() RemoveProcessorOptions {
+	return RemoveProcessorOptions{}
+}
+
+// Create new RemoveProcessor with given options.
+// Options example:
+// {
+//   "fields": ["tmp", "debug"],
+//   "ignore_missing": true
+// }
+func NewRemoveProcessorWithMap(opts map[string]interface{}) (*RemoveProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewRemoveProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewRemoveProcessorWithOptions(options)
+}
+
+func NewRemoveProcessorWithOptions(opts RemoveProcessorOptions) (*RemoveProcessor, error) {
+	if len(opts.Fields) == 0 {
+		return nil, fmt.Errorf("fields option does not exist")
+	}
+
+	for _, field := range opts.Fields {
+		if field == mapping.IdFieldName {
+			return nil, fmt.Errorf("fields option is unexpected: %v", field)
+		}
+	}
+
+	return &RemoveProcessor{
+		fields:        opts.Fields,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// RemoveProcessor removes fields.
+type RemoveProcessor struct {
+	fields        []string
+	ignoreMissing bool
+}
+
+func (p *RemoveProcessor) Process(fields map[string]interface{}) error {
+	for _, field := range p.fields {
+		if _, exists := fields[field]; !exists {
+			if p.ignoreMissing {
+				continue
+			}
+			return fmt.Errorf("field does not exist: %s", field)
+		}
+		delete(fields, field)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type RenameProcessorOptions struct {
+	Field         string `json:"field"`
+	TargetField   string `json:"target_field"`
+	IgnoreMissing bool   `json:"ignore_missing"`
+}
+
+func NewRenameProcessorOptions() RenameProcessorOptions {
+	return RenameProcessorOptions{}
+}
+
+// Create new RenameProcessor with given options.
+// Options example:
+// {
+//   "field": "body",
+//   "target_field": "text",
+//   "ignore_missing": false
+// }
+func NewRenameProcessorWithMap(opts map[string]interface{}) (*RenameProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewRenameProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewRenameProcessorWithOptions(options)
+}
+
+func NewRenameProcessorWithOptions(opts RenameProcessorOptions) (*RenameProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.TargetField == "" {
+		return nil, fmt.Errorf("target_field option does not exist")
+	}
+
+	return &RenameProcessor{
+		field:         opts.Field,
+		targetField:   opts.TargetField,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// RenameProcessor renames a field.
+type RenameProcessor struct {
+	field         string
+	targetField   string
+	ignoreMissing bool
+}
+
+func (p *RenameProcessor) Process(fields map[string]interface{}) error {
+	value, exists := fields[p.field]
+	if !exists {
+		if p.ignoreMissing {
+			return nil
+		}
+		return fmt.Errorf("field does not exist: %s", p.field)
+	}
+
+	delete(fields, p.field)
+	fields[p.targetField] = value
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type SetProcessorOptions struct {
+	Field    string      `json:"field"`
+	Value    interface{} `json:"value"`
+	CopyFrom string      `json:"copy_from"`
+	Override bool        `json:"override"`
+}
+
+func NewSetProcessorOptions() SetProcessorOptions {
+	return SetProcessorOptions{
+		Override: true,
+	}
+}
+
+// Create new SetProcessor with given options.
+// Options example:
+// {
+//   "field": "category",
+//   "value": "book",
+//   "override": true
+// }
+func NewSetProcessorWithMap(opts map[string]interface{}) (*SetProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewSetProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewSetProcessorWithOptions(options)
+}
+
+func NewSetProcessorWithOptions(opts SetProcessorOptions) (*SetProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.Value == nil && opts.CopyFrom == "" {
+		return nil, fmt.Errorf("value or copy_from option does not exist")
+	}
+
+	return &SetProcessor{
+		field:    opts.Field,
+		value:    opts.Value,
+		copyFrom: opts.CopyFrom,
+		override: opts.Override,
+	}, nil
+}
+
+// SetProcessor sets a field to a value or to the value of another field.
+type SetProcessor struct {
+	field    string
+	value    interface{}
+	copyFrom string
+	override bool
+}
+
+func (p *SetProcessor) Process(fields map[string]interface{}) error {
+	if _, exists := fields[p.field]; exists && !p.override {
+		return nil
+	}
+
+	if p.copyFrom != "" {
+		value, exists := fields[p.copyFrom]
+		if !exists {
+			return fmt.Errorf("field does not exist: %s", p.copyFrom)
+		}
+		fields[p.field] = value
+		return nil
+	}
+
+	fields[p.field] = p.value
+
+	return nil
+}
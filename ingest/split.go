@@ -0,0 +1,102 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type SplitProcessorOptions struct {
+	Field         string `json:"field"`
+	Separator     string `json:"separator"`
+	TargetField   string `json:"target_field"`
+	Trim          bool   `json:"trim"`
+	IgnoreMissing bool   `json:"ignore_missing"`
+}
+
+func NewSplitProcessorOptions() SplitProcessorOptions {
+	return SplitProcessorOptions{}
+}
+
+// Create new SplitProcessor with given options.
+// Options example:
+// {
+//   "field": "tags",
+//   "separator": ",",
+//   "target_field": "tags",
+//   "trim": true,
+//   "ignore_missing": false
+// }
+func NewSplitProcessorWithMap(opts map[string]interface{}) (*SplitProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewSplitProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewSplitProcessorWithOptions(options)
+}
+
+func NewSplitProcessorWithOptions(opts SplitProcessorOptions) (*SplitProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.Separator == "" {
+		return nil, fmt.Errorf("separator option does not exist")
+	}
+
+	// target_field is optional.
+	targetField := opts.TargetField
+	if targetField == "" {
+		targetField = opts.Field
+	}
+
+	return &SplitProcessor{
+		field:         opts.Field,
+		separator:     opts.Separator,
+		targetField:   targetField,
+		trim:          opts.Trim,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// SplitProcessor splits a string field into an array by a separator.
+type SplitProcessor struct {
+	field         string
+	separator     string
+	targetField   string
+	trim          bool
+	ignoreMissing bool
+}
+
+func (p *SplitProcessor) Process(fields map[string]interface{}) error {
+	value, ok, err := getFieldValue(fields, p.field, p.ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+
+	strValue, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("unexpected string value: %v", value)
+	}
+
+	items := strings.Split(strValue, p.separator)
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if p.trim {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+		}
+		values = append(values, item)
+	}
+	fields[p.targetField] = values
+
+	return nil
+}
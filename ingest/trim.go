@@ -0,0 +1,80 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type TrimProcessorOptions struct {
+	Field         string `json:"field"`
+	TargetField   string `json:"target_field"`
+	IgnoreMissing bool   `json:"ignore_missing"`
+}
+
+func NewTrimProcessorOptions() TrimProcessorOptions {
+	return TrimProcessorOptions{}
+}
+
+// Create new TrimProcessor with given options.
+// Options example:
+// {
+//   "field": "title",
+//   "target_field": "title",
+//   "ignore_missing": false
+// }
+func NewTrimProcessorWithMap(opts map[string]interface{}) (*TrimProcessor, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewTrimProcessorOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewTrimProcessorWithOptions(options)
+}
+
+func NewTrimProcessorWithOptions(opts TrimProcessorOptions) (*TrimProcessor, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	// target_field is optional.
+	targetField := opts.TargetField
+	if targetField == "" {
+		targetField = opts.Field
+	}
+
+	return &TrimProcessor{
+		field:         opts.Field,
+		targetField:   targetField,
+		ignoreMissing: opts.IgnoreMissing,
+	}, nil
+}
+
+// TrimProcessor removes leading and trailing white space from a string field.
+type TrimProcessor struct {
+	field         string
+	targetField   string
+	ignoreMissing bool
+}
+
+func (p *TrimProcessor) Process(fields map[string]interface{}) error {
+	value, ok, err := getFieldValue(fields, p.field, p.ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+
+	newValue, err := applyToStrings(value, func(value string) (interface{}, error) {
+		return strings.TrimSpace(value), nil
+	})
+	if err != nil {
+		return err
+	}
+	fields[p.targetField] = newValue
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package ingest
+
+import (
+	"fmt"
+)
+
+// getFieldValue returns the value of the field.
+// ok is false if the field is missing and missing fields are ignored.
+func getFieldValue(fields map[string]interface{}, field string, ignoreMissing bool) (interface{}, bool, error) {
+	value, exists := fields[field]
+	if !exists || value == nil {
+		if ignoreMissing {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("field does not exist: %s", field)
+	}
+
+	return value, true, nil
+}
+
+// applyToStrings applies the function to a string value or to each string of an array value.
+func applyToStrings(value interface{}, f func(string) (interface{}, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return f(v)
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, item := range v {
+			strItem, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected string value: %v", item)
+			}
+			newItem, err := f(strItem)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = newItem
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unexpected string value: %v", value)
+	}
+}
@@ -0,0 +1,167 @@
+package mapping
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EpochMillisFormat = "epoch_millis"
+	EpochSecondFormat = "epoch_second"
+)
+
+// DefaultDateTimeFormats are used if the field mapping has no formats.
+var DefaultDateTimeFormats = []string{"RFC3339"}
+
+// Named Go layouts that can be used as formats.
+var dateTimeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+}
+
+// strftime directives and the corresponding Go layout elements.
+var strftimeDirectives = map[byte]string{
+	'a': "Mon",
+	'A': "Monday",
+	'b': "Jan",
+	'B': "January",
+	'd': "02",
+	'D': "01/02/06",
+	'e': "_2",
+	'F': "2006-01-02",
+	'H': "15",
+	'I': "03",
+	'm': "01",
+	'M': "04",
+	'p': "PM",
+	'S': "05",
+	'T': "15:04:05",
+	'y': "06",
+	'Y': "2006",
+	'z': "-0700",
+	'Z': "MST",
+	'%': "%",
+}
+
+// StrftimeToLayout converts a strftime format such as `%Y-%m-%d %H:%M:%S` to a Go layout.
+func StrftimeToLayout(format string) (string, error) {
+	var layout strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			layout.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		if i >= len(format) {
+			return "", fmt.Errorf("unexpected end of strftime format: %s", format)
+		}
+
+		// %f is fractional seconds, which Go layouts only allow after a period or comma.
+		if format[i] == 'f' {
+			if i < 2 || (format[i-2] != '.' && format[i-2] != ',') {
+				return "", fmt.Errorf("%%f must follow a period or comma: %s", format)
+			}
+			layout.WriteString("000000")
+			continue
+		}
+
+		element, ok := strftimeDirectives[format[i]]
+		if !ok {
+			return "", fmt.Errorf("unsupported strftime directive %%%c: %s", format[i], format)
+		}
+		layout.WriteString(element)
+	}
+
+	return layout.String(), nil
+}
+
+// makeDateTimeLayout returns the Go layout of the format.
+// Formats containing `%` are treated as strftime formats.
+func makeDateTimeLayout(format string) (string, error) {
+	if layout, ok := dateTimeLayouts[format]; ok {
+		return layout, nil
+	}
+
+	if strings.Contains(format, "%") {
+		return StrftimeToLayout(format)
+	}
+
+	return format, nil
+}
+
+func ValidateDateTimeFormats(formats []string) error {
+	for _, format := range formats {
+		if format == "" {
+			return fmt.Errorf("datetime format is empty")
+		}
+		if format == EpochMillisFormat || format == EpochSecondFormat {
+			continue
+		}
+		if _, err := makeDateTimeLayout(format); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MakeDateTimeWithFormats parses the value with the first matching format.
+// Formats without a time zone are parsed in UTC.
+func MakeDateTimeWithFormats(value interface{}, formats []string) (time.Time, error) {
+	return MakeDateTimeInLocation(value, formats, time.UTC)
+}
+
+// MakeDateTimeInLocation parses the value with the first matching format.
+// Formats without a time zone are parsed in the given location.
+func MakeDateTimeInLocation(value interface{}, formats []string, location *time.Location) (time.Time, error) {
+	if len(formats) == 0 {
+		formats = DefaultDateTimeFormats
+	}
+
+	for _, format := range formats {
+		switch format {
+		case EpochMillisFormat, EpochSecondFormat:
+			var f64Value float64
+			switch v := value.(type) {
+			case float64:
+				f64Value = v
+			case string:
+				var err error
+				if f64Value, err = strconv.ParseFloat(v, 64); err != nil {
+					continue
+				}
+			default:
+				continue
+			}
+			if format == EpochSecondFormat {
+				f64Value *= 1000
+			}
+			millis := int64(math.Round(f64Value))
+			return time.Unix(millis/1000, (millis%1000)*int64(time.Millisecond)).In(location), nil
+		default:
+			strValue, ok := value.(string)
+			if !ok {
+				continue
+			}
+			layout, err := makeDateTimeLayout(format)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if datetimeValue, err := time.ParseInLocation(layout, strValue, location); err == nil {
+				return datetimeValue, nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("value does not match datetime formats: %v", value)
+}
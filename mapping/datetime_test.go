@@ -0,0 +1,91 @@
+package mapping
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/mosuka/phalanx/proto"
+)
+
+func TestStrftimeToLayout(t *testing.T) {
+	actual, err := StrftimeToLayout("%Y-%m-%dT%H:%M:%S.%f%z")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	expected := "2006-01-02T15:04:05.000000-0700"
+	if actual != expected {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	if _, err := StrftimeToLayout("%Q"); err == nil {
+		t.Fatalf("expected an error for an unsupported directive\n")
+	}
+}
+
+func TestMakeDateTimeWithFormats(t *testing.T) {
+	formats := []string{"%Y-%m-%d %H:%M:%S", "20060102", EpochMillisFormat}
+	expected := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, value := range []interface{}{"2021-08-01 12:00:00", float64(expected.UnixNano() / int64(time.Millisecond))} {
+		actual, err := MakeDateTimeWithFormats(value, formats)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if !actual.Equal(expected) {
+			t.Fatalf("`%v` is not `%v`\n", actual, expected)
+		}
+	}
+
+	actual, err := MakeDateTimeWithFormats("20210801", formats)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !actual.Equal(time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("`%v` is not `%v`\n", actual, time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	actual, err = MakeDateTimeWithFormats("1627819200", []string{EpochSecondFormat})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !actual.Equal(expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	if _, err := MakeDateTimeWithFormats("2021-08-01 12:00:00", nil); err == nil {
+		t.Fatalf("expected an error for a value that is not RFC3339\n")
+	}
+}
+
+func TestMakeDocumentWithDateTimeFormats(t *testing.T) {
+	indexMappingFile := "../testdata/test_mapping.json"
+
+	bytes, _ := ioutil.ReadFile(indexMappingFile)
+
+	mapping, _ := NewMapping(bytes)
+
+	_, err := mapping.MakeDocument(&proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"formatted_datetime_field": "2021-08-01 12:00:00", "datetime_field": "2021-08-01T12:00:00Z"}`),
+	}, DefaultDynamicPolicy, nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = mapping.MakeDocument(&proto.Document{
+		Id:     "2",
+		Fields: []byte(`{"formatted_datetime_field": 1627819200000}`),
+	}, DefaultDynamicPolicy, nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = mapping.MakeDocument(&proto.Document{
+		Id:     "3",
+		Fields: []byte(`{"datetime_field": "2021-08-01 12:00:00"}`),
+	}, DefaultDynamicPolicy, nil)
+	if err == nil {
+		t.Fatalf("expected an error for a datetime without a matching format\n")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/blugelabs/bluge/analysis/analyzer"
 	"github.com/blugelabs/bluge/numeric/geo"
 	phalanxanalyzer "github.com/mosuka/phalanx/analysis/analyzer"
+	phalanxtokenizer "github.com/mosuka/phalanx/analysis/tokenizer"
 	"github.com/mosuka/phalanx/errors"
 	"github.com/mosuka/phalanx/proto"
 )
@@ -20,18 +21,16 @@ const TimestampFieldName = "_timestamp"
 const ScoreFieldName = "_score"
 const AllFieldName = "_all"
 
+// ExistsFieldName is the field that indexes the names of the fields that have values in the document.
+const ExistsFieldName = "_exists"
+
 const DefaultTextFieldOptions = bluge.Index | bluge.Store | bluge.SearchTermPositions | bluge.HighlightMatches
 const DefaultNumericFieldOptions = bluge.Index | bluge.Store | bluge.Sortable | bluge.Aggregatable
 const DefaultDateTimeFieldOptions = bluge.Index | bluge.Store | bluge.Sortable | bluge.Aggregatable
 const DefaultGeoPointFieldOptions = bluge.Index | bluge.Store | bluge.Sortable | bluge.Aggregatable
 
 func IsDateTime(value interface{}) bool {
-	strValue, ok := value.(string)
-	if !ok {
-		return false
-	}
-
-	if _, err := time.Parse(time.RFC3339, strValue); err != nil {
+	if _, err := MakeDateTime(value); err != nil {
 		return false
 	}
 
@@ -39,12 +38,7 @@ func IsDateTime(value interface{}) bool {
 }
 
 func MakeDateTime(value interface{}) (time.Time, error) {
-	strValue, ok := value.(string)
-	if !ok {
-		return time.Time{}, fmt.Errorf("value is not string")
-	}
-
-	return MakeDateTimeWithRfc3339(strValue)
+	return MakeDateTimeWithFormats(value, DefaultDateTimeFormats)
 }
 
 func MakeDateTimeWithRfc3339(value string) (time.Time, error) {
@@ -110,6 +104,22 @@ func MakeTextField(fieldName string, fieldValue string, fieldOptions bluge.Field
 	return field
 }
 
+// MakeExistsField makes the field that records that the document has a value in the field.
+func MakeExistsField(fieldName string) *bluge.TermField {
+	field := bluge.NewKeywordField(ExistsFieldName, fieldName)
+	field.FieldOptions = bluge.Index
+
+	return field
+}
+
+// MakeLanguageField makes the field that records the detected language of a text field.
+func MakeLanguageField(fieldName string, language string) *bluge.TermField {
+	field := bluge.NewKeywordField(fieldName, language)
+	field.FieldOptions = bluge.Index | bluge.Store | bluge.Sortable | bluge.Aggregatable
+
+	return field
+}
+
 func MakeNumericField(fieldName string, fieldValue float64, fieldOptions bluge.FieldOptions) *bluge.TermField {
 	field := bluge.NewNumericField(fieldName, fieldValue)
 	field.FieldOptions = fieldOptions
@@ -131,10 +141,11 @@ func MakeGeoPointField(fieldName string, fieldValue geo.Point, fieldOptions blug
 type FieldType string
 
 const (
-	TextField     FieldType = "text"
-	NumericField  FieldType = "numeric"
-	DatetimeField FieldType = "datetime"
-	GeoPointField FieldType = "geo_point"
+	TextField        FieldType = "text"
+	NumericField     FieldType = "numeric"
+	DatetimeField    FieldType = "datetime"
+	GeoPointField    FieldType = "geo_point"
+	DenseVectorField FieldType = "dense_vector"
 )
 
 type FieldOptions struct {
@@ -150,6 +161,13 @@ type FieldSetting struct {
 	FieldType       FieldType                       `json:"type"`
 	FieldOptions    FieldOptions                    `json:"options"`
 	AnalyzerSetting phalanxanalyzer.AnalyzerSetting `json:"analyzer"`
+	// SearchAnalyzerSetting analyzes query text instead of the analyzer, e.g. to expand synonyms at query time only.
+	SearchAnalyzerSetting *phalanxanalyzer.AnalyzerSetting `json:"search_analyzer,omitempty"`
+	Required              bool                             `json:"required,omitempty"`
+	Dimension             int                              `json:"dimension,omitempty"`
+	Similarity            Similarity                       `json:"similarity,omitempty"`
+	Formats               []string                         `json:"formats,omitempty"`
+	LanguageField         string                           `json:"language_field,omitempty"`
 }
 
 type IndexMapping map[string]FieldSetting
@@ -171,12 +189,50 @@ func (m IndexMapping) Marshal() ([]byte, error) {
 func (m IndexMapping) getFieldSetting(fieldName string) (*FieldSetting, error) {
 	fieldSetting, ok := m[fieldName]
 	if !ok {
+		if m.isLanguageField(fieldName) {
+			return &languageFieldSetting, nil
+		}
 		return nil, errors.ErrFieldSettingDoesNotExist
 	}
 
 	return &fieldSetting, nil
 }
 
+// languageFieldSetting is the setting of the fields that record the detected languages.
+var languageFieldSetting = FieldSetting{
+	FieldType: TextField,
+	FieldOptions: FieldOptions{
+		Index:        true,
+		Store:        true,
+		Sortable:     true,
+		Aggregatable: true,
+	},
+	AnalyzerSetting: phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.SingleTokenTokenizer,
+		},
+	},
+}
+
+// GetLanguageField returns the name of the field that records the detected language of the text field.
+func (m IndexMapping) GetLanguageField(fieldName string) string {
+	if fieldSetting, ok := m[fieldName]; ok && fieldSetting.LanguageField != "" {
+		return fieldSetting.LanguageField
+	}
+
+	return fieldName + "_language"
+}
+
+func (m IndexMapping) isLanguageField(fieldName string) bool {
+	for name, fieldSetting := range m {
+		if fieldSetting.FieldType == TextField && m.GetLanguageField(name) == fieldName {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (m IndexMapping) Exists(fieldName string) bool {
 	_, ok := m[fieldName]
 
@@ -184,7 +240,7 @@ func (m IndexMapping) Exists(fieldName string) bool {
 }
 
 func (m IndexMapping) GetFieldType(fieldName string) (FieldType, error) {
-	if m.Exists(fieldName) {
+	if m.Exists(fieldName) || m.isLanguageField(fieldName) {
 		fieldSetting, err := m.getFieldSetting(fieldName)
 		if err != nil {
 			return "", err
@@ -202,6 +258,8 @@ func (m IndexMapping) GetFieldType(fieldName string) (FieldType, error) {
 			return DatetimeField, nil
 		case GeoPointField:
 			return GeoPointField, nil
+		case "vector":
+			return DenseVectorField, nil
 		default:
 			return "", errors.ErrUnknownFieldType
 		}
@@ -246,7 +304,61 @@ func (m IndexMapping) GetAnalyzer(fieldName string) (*analysis.Analyzer, error)
 	return phalanxanalyzer.NewAnalyzer(fieldSetting.AnalyzerSetting)
 }
 
-func (m IndexMapping) MakeDocument(srcDoc *proto.Document) (*bluge.Document, error) {
+func (m IndexMapping) GetAnalyzerSetting(fieldName string) (phalanxanalyzer.AnalyzerSetting, error) {
+	fieldSetting, err := m.getFieldSetting(fieldName)
+	if err != nil {
+		return phalanxanalyzer.AnalyzerSetting{}, err
+	}
+
+	return fieldSetting.AnalyzerSetting, nil
+}
+
+// GetSearchAnalyzerSetting returns the analyzer setting to analyze query text of the field.
+// It returns the analyzer setting of the field if the field has no search analyzer.
+func (m IndexMapping) GetSearchAnalyzerSetting(fieldName string) (phalanxanalyzer.AnalyzerSetting, error) {
+	fieldSetting, err := m.getFieldSetting(fieldName)
+	if err != nil {
+		return phalanxanalyzer.AnalyzerSetting{}, err
+	}
+
+	if fieldSetting.SearchAnalyzerSetting != nil {
+		return *fieldSetting.SearchAnalyzerSetting, nil
+	}
+
+	return fieldSetting.AnalyzerSetting, nil
+}
+
+func (m IndexMapping) GetDenseVectorSetting(fieldName string) (int, Similarity, error) {
+	fieldSetting, err := m.getFieldSetting(fieldName)
+	if err != nil {
+		return 0, "", err
+	}
+
+	similarity, err := NewSimilarity(string(fieldSetting.Similarity))
+	if err != nil {
+		return 0, "", err
+	}
+
+	return fieldSetting.Dimension, similarity, nil
+}
+
+// GetDateTimeFormats returns the datetime formats of the field.
+// It returns the default formats if the field is not mapped or has no formats.
+func (m IndexMapping) GetDateTimeFormats(fieldName string) []string {
+	fieldSetting, err := m.getFieldSetting(fieldName)
+	if err != nil || len(fieldSetting.Formats) == 0 {
+		return DefaultDateTimeFormats
+	}
+
+	return fieldSetting.Formats
+}
+
+func (m IndexMapping) MakeDocument(srcDoc *proto.Document, dynamic DynamicPolicy, analyzers *phalanxanalyzer.AnalyzerCache) (*bluge.Document, error) {
+	// Analyzers are built once per document at least.
+	if analyzers == nil {
+		analyzers = phalanxanalyzer.NewAnalyzerCache(phalanxanalyzer.AnalysisSetting{})
+	}
+
 	// id, ok := fieldMap[IdFieldName].(string)
 	// if !ok {
 	// 	return nil, errors.ErrDocumentIdDoesNotExist
@@ -265,7 +377,62 @@ func (m IndexMapping) MakeDocument(srcDoc *proto.Document) (*bluge.Document, err
 		return nil, err
 	}
 
+	// Check required fields.
+	for fieldName, fieldSetting := range m {
+		if !fieldSetting.Required {
+			continue
+		}
+		if fieldValue, ok := fieldsMap[fieldName]; !ok || fieldValue == nil {
+			return nil, NewValidationError(srcDoc.Id, fieldName, fieldSetting.FieldType, nil, "required field is missing")
+		}
+	}
+
+	// The language fields are excluded from the _all field as well as the system fields.
+	excludedFields := []string{IdFieldName, TimestampFieldName, ExistsFieldName}
+
 	for fieldName, fieldValueIntr := range fieldsMap {
+		// Skip system reserved field name.
+		switch fieldName {
+		case IdFieldName:
+			continue
+		case TimestampFieldName:
+			continue
+		case AllFieldName:
+			continue
+		case ExistsFieldName:
+			continue
+		}
+
+		// Fields that are not defined in the mapping are handled by the dynamic policy.
+		if !m.Exists(fieldName) {
+			switch dynamic {
+			case DynamicIgnore:
+				continue
+			case DynamicStrict:
+				return nil, NewValidationError(srcDoc.Id, fieldName, "", fieldValueIntr, "field is not defined in the mapping")
+			}
+		}
+
+		fieldType, err := m.GetFieldType(fieldName)
+		if err != nil {
+			return nil, NewValidationError(srcDoc.Id, fieldName, "", fieldValueIntr, err.Error())
+		}
+
+		// A dense vector is a single value even though it is a JSON array.
+		if fieldType == DenseVectorField {
+			vector, err := MakeDenseVector(fieldValueIntr)
+			if err != nil {
+				return nil, NewValidationError(srcDoc.Id, fieldName, fieldType, fieldValueIntr, "unexpected dense vector value")
+			}
+			dimension, _, err := m.GetDenseVectorSetting(fieldName)
+			if err == nil && len(vector) != dimension {
+				return nil, NewValidationError(srcDoc.Id, fieldName, fieldType, fieldValueIntr, fmt.Sprintf("unexpected dense vector dimension: expected %d, got %d", dimension, len(vector)))
+			}
+			doc.AddField(MakeDenseVectorField(fieldName, vector))
+			doc.AddField(MakeExistsField(fieldName))
+			continue
+		}
+
 		fieldValues := make([]interface{}, 0)
 		switch value := fieldValueIntr.(type) {
 		case []interface{}:
@@ -275,40 +442,46 @@ func (m IndexMapping) MakeDocument(srcDoc *proto.Document) (*bluge.Document, err
 		}
 
 		for _, fieldValue := range fieldValues {
-			// Skip system reserved field name.
-			switch fieldName {
-			case IdFieldName:
-				continue
-			case TimestampFieldName:
-				continue
-			case AllFieldName:
-				continue
-			}
-
 			var field *bluge.TermField
-			fieldType, err := m.GetFieldType(fieldName)
-			if err != nil {
-				return nil, err
-			}
 			switch fieldType {
 			case TextField:
 				strValue, ok := fieldValue.(string)
 				if !ok {
-					return nil, fmt.Errorf("unexpected string value")
+					return nil, NewValidationError(srcDoc.Id, fieldName, fieldType, fieldValue, "unexpected string value")
 				}
 				fieldOptions, err := m.GetFieldOptions(fieldName)
 				if err != nil {
 					fieldOptions = DefaultTextFieldOptions
 				}
-				fieldAnalyzer, err := m.GetAnalyzer(fieldName)
-				if err != nil {
+				var fieldAnalyzer *analysis.Analyzer
+				if analyzerSetting, err := m.GetAnalyzerSetting(fieldName); err == nil {
+					if resolved, err := analyzers.Resolve(analyzerSetting); err == nil && resolved.Language == phalanxanalyzer.AutoLanguage {
+						// The language is detected once per value and recorded in the language field.
+						language, langAnalyzer, err := phalanxanalyzer.DetectLanguageAnalyzer(strValue)
+						if err == nil {
+							fieldAnalyzer = langAnalyzer
+							if language != "" {
+								languageField := m.GetLanguageField(fieldName)
+								doc.AddField(MakeLanguageField(languageField, language))
+								doc.AddField(MakeExistsField(languageField))
+								excludedFields = append(excludedFields, languageField)
+							}
+						}
+					} else {
+						fieldAnalyzer, err = analyzers.Get(analyzerSetting)
+						if err != nil {
+							fieldAnalyzer = nil
+						}
+					}
+				}
+				if fieldAnalyzer == nil {
 					fieldAnalyzer = analyzer.NewStandardAnalyzer()
 				}
 				field = MakeTextField(fieldName, strValue, fieldOptions, fieldAnalyzer)
 			case NumericField:
 				f64Value, ok := fieldValue.(float64)
 				if !ok {
-					return nil, fmt.Errorf("unexpected numeric value")
+					return nil, NewValidationError(srcDoc.Id, fieldName, fieldType, fieldValue, "unexpected numeric value")
 				}
 				fieldOptions, err := m.GetFieldOptions(fieldName)
 				if err != nil {
@@ -316,9 +489,9 @@ func (m IndexMapping) MakeDocument(srcDoc *proto.Document) (*bluge.Document, err
 				}
 				field = MakeNumericField(fieldName, f64Value, fieldOptions)
 			case DatetimeField:
-				datetimeValue, err := MakeDateTime(fieldValue)
+				datetimeValue, err := MakeDateTimeWithFormats(fieldValue, m.GetDateTimeFormats(fieldName))
 				if err != nil {
-					return nil, fmt.Errorf("unexpected datetime value")
+					return nil, NewValidationError(srcDoc.Id, fieldName, fieldType, fieldValue, "unexpected datetime value")
 				}
 				fieldOptions, err := m.GetFieldOptions(fieldName)
 				if err != nil {
@@ -328,7 +501,7 @@ func (m IndexMapping) MakeDocument(srcDoc *proto.Document) (*bluge.Document, err
 			case GeoPointField:
 				geoPointValue, err := MakeGeoPoint(fieldValue)
 				if err != nil {
-					return nil, fmt.Errorf("unexpected geo point value")
+					return nil, NewValidationError(srcDoc.Id, fieldName, fieldType, fieldValue, "unexpected geo point value")
 				}
 				fieldOptions, err := m.GetFieldOptions(fieldName)
 				if err != nil {
@@ -338,10 +511,15 @@ func (m IndexMapping) MakeDocument(srcDoc *proto.Document) (*bluge.Document, err
 			}
 			doc.AddField(field)
 		}
+
+		// The exists query finds the documents by the name of the field.
+		if len(fieldValues) > 0 {
+			doc.AddField(MakeExistsField(fieldName))
+		}
 	}
 
 	// add _all field
-	doc.AddField(bluge.NewCompositeFieldExcluding(AllFieldName, []string{IdFieldName, TimestampFieldName}))
+	doc.AddField(bluge.NewCompositeFieldExcluding(AllFieldName, excludedFields))
 
 	return doc, nil
 }
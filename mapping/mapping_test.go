@@ -3,10 +3,16 @@ package mapping
 import (
 	"bytes"
 	"io/ioutil"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/blugelabs/bluge/analysis"
+	phalanxanalyzer "github.com/mosuka/phalanx/analysis/analyzer"
+	phalanxtoken "github.com/mosuka/phalanx/analysis/token"
+	phalanxtokenizer "github.com/mosuka/phalanx/analysis/tokenizer"
+	"github.com/mosuka/phalanx/proto"
 )
 
 func tokenStream(termStrs ...string) analysis.TokenStream {
@@ -76,6 +82,71 @@ func TestGetFieldType(t *testing.T) {
 	if fieldType != TextField {
 		t.Fatalf("%v is not %v\n", fieldType, TextField)
 	}
+
+	fieldType, err = mapping.GetFieldType("dense_vector_field")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if fieldType != DenseVectorField {
+		t.Fatalf("%v is not %v\n", fieldType, DenseVectorField)
+	}
+}
+
+func TestMakeDocumentWithDenseVector(t *testing.T) {
+	indexMappingFile := "../testdata/test_mapping.json"
+
+	bytes, _ := ioutil.ReadFile(indexMappingFile)
+
+	mapping, _ := NewMapping(bytes)
+
+	_, err := mapping.MakeDocument(&proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"dense_vector_field": [0.1, 0.2, 0.3]}`),
+	}, DefaultDynamicPolicy, nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = mapping.MakeDocument(&proto.Document{
+		Id:     "2",
+		Fields: []byte(`{"dense_vector_field": [0.1, 0.2]}`),
+	}, DefaultDynamicPolicy, nil)
+	if err == nil {
+		t.Fatalf("expected an error for a vector with an unexpected dimension\n")
+	}
+
+	expected := []float32{0.1, 0.2, 0.3}
+	actual, err := DecodeDenseVector(EncodeDenseVector(expected))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestValidateDenseVectorSetting(t *testing.T) {
+	if err := ValidateDenseVectorSetting(3, L2Similarity); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if err := ValidateDenseVectorSetting(0, CosineSimilarity); err == nil {
+		t.Fatalf("expected an error for a dense vector without a dimension\n")
+	}
+	if err := ValidateDenseVectorSetting(3, "manhattan"); err == nil {
+		t.Fatalf("expected an error for an unknown similarity\n")
+	}
+
+	// The vectors of a field without a dimension are not accepted.
+	mapping, err := NewMapping([]byte(`{"vector_field": {"type": "dense_vector"}}`))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if _, err := mapping.MakeDocument(&proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"vector_field": [0.1, 0.2, 0.3]}`),
+	}, DefaultDynamicPolicy, nil); err == nil {
+		t.Fatalf("expected an error for a vector with an unexpected dimension\n")
+	}
 }
 
 func TestGetFieldOptions(t *testing.T) {
@@ -94,6 +165,136 @@ func TestGetFieldOptions(t *testing.T) {
 	}
 }
 
+func TestMakeDocumentWithDynamicPolicy(t *testing.T) {
+	indexMappingFile := "../testdata/test_validation_mapping.json"
+
+	bytes, _ := ioutil.ReadFile(indexMappingFile)
+
+	mapping, _ := NewMapping(bytes)
+
+	doc := &proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"title": "hello", "price": 100, "comment_text": "world"}`),
+	}
+
+	if _, err := mapping.MakeDocument(doc, DynamicTrue, nil); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := mapping.MakeDocument(doc, DynamicIgnore, nil); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err := mapping.MakeDocument(doc, DynamicStrict, nil)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("`%v` is not a validation error\n", err)
+	}
+	if validationErr.DocumentId != "1" || validationErr.Field != "comment_text" {
+		t.Fatalf("unexpected validation error: %v\n", validationErr)
+	}
+}
+
+func TestMakeDocumentWithLanguageDetection(t *testing.T) {
+	mapping, err := NewMapping([]byte(`{"title": {"type": "text", "options": {"index": true, "store": true}, "analyzer": {"language": "auto"}}}`))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	doc, err := mapping.MakeDocument(&proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"title": "東京スカイツリーの最寄り駅はとうきょうスカイツリー駅です"}`),
+	}, DefaultDynamicPolicy, nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	actual := ""
+	for _, field := range *doc {
+		if field.Name() == "title_language" {
+			actual = string(field.Value())
+		}
+	}
+	expected := "ja"
+	if actual != expected {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	fieldType, err := mapping.GetFieldType("title_language")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if fieldType != TextField {
+		t.Fatalf("`%v` is not `%v`\n", fieldType, TextField)
+	}
+}
+
+func TestMakeDocumentWithExistsField(t *testing.T) {
+	mapping, err := NewMapping([]byte(`{"title": {"type": "text", "options": {"index": true, "store": true}}, "tags": {"type": "text", "options": {"index": true, "store": true}}, "price": {"type": "numeric", "options": {"index": true, "store": true}}}`))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	doc, err := mapping.MakeDocument(&proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"title": "hello", "tags": [], "price": 100}`),
+	}, DefaultDynamicPolicy, nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	actual := make([]string, 0)
+	for _, field := range *doc {
+		if field.Name() == ExistsFieldName {
+			actual = append(actual, string(field.Value()))
+		}
+	}
+	sort.Strings(actual)
+	expected := []string{"price", "title"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestMakeDocumentWithInvalidValue(t *testing.T) {
+	indexMappingFile := "../testdata/test_validation_mapping.json"
+
+	bytes, _ := ioutil.ReadFile(indexMappingFile)
+
+	mapping, _ := NewMapping(bytes)
+
+	_, err := mapping.MakeDocument(&proto.Document{
+		Id:     "1",
+		Fields: []byte(`{"title": "hello", "price": "expensive"}`),
+	}, DefaultDynamicPolicy, nil)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("`%v` is not a validation error\n", err)
+	}
+	expected := &ValidationError{
+		DocumentId:    "1",
+		Field:         "price",
+		ExpectedType:  NumericField,
+		ReceivedValue: "expensive",
+		Reason:        "unexpected numeric value",
+	}
+	if !reflect.DeepEqual(validationErr, expected) {
+		t.Fatalf("`%v` is not `%v`\n", validationErr, expected)
+	}
+
+	_, err = mapping.MakeDocument(&proto.Document{
+		Id:     "2",
+		Fields: []byte(`{"price": 100}`),
+	}, DefaultDynamicPolicy, nil)
+	validationErr, ok = err.(*ValidationError)
+	if !ok {
+		t.Fatalf("`%v` is not a validation error\n", err)
+	}
+	if validationErr.Field != "title" {
+		t.Fatalf("%v is not %v\n", validationErr.Field, "title")
+	}
+}
+
 func TestAsciiFoldingCharFilter(t *testing.T) {
 	indexMappingFile := "../testdata/test_mapping.json"
 
@@ -327,6 +528,505 @@ func TestJapaneseTokenizer(t *testing.T) {
 	}
 }
 
+func TestJapaneseTokenizerWithUserDictionary(t *testing.T) {
+	userDictionaryFile, err := filepath.Abs("../testdata/test_user_dict.txt")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.KagomeTokenizer,
+			Options: map[string]interface{}{
+				"dictionary":          "IPADIC",
+				"user_dictionary_uri": "file://" + userDictionaryFile,
+				"mode":                "normal",
+				"reading_form":        "romaji",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("朝青龍"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"asashouryuu"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestJapaneseTokenFilters(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.JapaneseKatakanaStemTokenFilter,
+			},
+			{
+				Name: phalanxtoken.JapaneseNumberTokenFilter,
+			},
+			{
+				Name: phalanxtoken.JapaneseReadingFormTokenFilter,
+				Options: map[string]interface{}{
+					"form": "hiragana",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("コンピューター サーバー 三千五百 ２万 007 カタカナ"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"こんぴゅーた", "さーば", "3500", "20000", "007", "かたかな"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestPhoneticTokenFilter(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.PhoneticTokenFilter,
+				Options: map[string]interface{}{
+					"encoder": "double_metaphone",
+					"replace": false,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("Smith Schmidt 007"))
+	actual := make([]string, len(tokens))
+	actualIncrs := make([]int, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+		actualIncrs[i] = token.PositionIncr
+	}
+	expected := []string{"Smith", "SM0", "XMT", "Schmidt", "XMT", "SMT", "007"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+	expectedIncrs := []int{1, 0, 0, 1, 0, 0, 1}
+	if !reflect.DeepEqual(actualIncrs, expectedIncrs) {
+		t.Fatalf("`%v` is not `%v`\n", actualIncrs, expectedIncrs)
+	}
+}
+
+func TestPhoneticTokenFilterEncoders(t *testing.T) {
+	encoders := map[string][]string{
+		"soundex":         {"R163", "R163", "T522"},
+		"refined_soundex": {"R901096", "R901096", "T6083503"},
+		"metaphone":       {"RBRT", "RPRT", "TMKS"},
+		"beider_morse":    {"rubirt", "rupirt", "timtsak"},
+	}
+	for encoder, expected := range encoders {
+		a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+			TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+				Name: phalanxtokenizer.WhitespaceTokenizer,
+			},
+			TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+				{
+					Name: phalanxtoken.PhoneticTokenFilter,
+					Options: map[string]interface{}{
+						"encoder": encoder,
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		tokens := a.Analyze([]byte("Robert Rupert Tymczak"))
+		actual := make([]string, len(tokens))
+		for i, token := range tokens {
+			actual[i] = string(token.Term)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("`%v` is not `%v`\n", actual, expected)
+		}
+	}
+}
+
+func TestWordDelimiterGraphTokenFilter(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.WordDelimiterGraphTokenFilter,
+				Options: map[string]interface{}{
+					"catenate_all":      true,
+					"preserve_original": true,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("XPS-13 9310 iPhone12Pro"))
+	actual := make([]string, len(tokens))
+	actualIncrs := make([]int, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+		actualIncrs[i] = token.PositionIncr
+	}
+	expected := []string{"XPS-13", "XPS13", "XPS", "13", "9310", "iPhone12Pro", "i", "Phone", "12", "Pro"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+	expectedIncrs := []int{1, 0, 0, 1, 1, 1, 0, 1, 1, 1}
+	if !reflect.DeepEqual(actualIncrs, expectedIncrs) {
+		t.Fatalf("`%v` is not `%v`\n", actualIncrs, expectedIncrs)
+	}
+}
+
+func TestPatternReplaceTokenFilter(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.PatternReplaceTokenFilter,
+				Options: map[string]interface{}{
+					"pattern":     "^(\\d+)-(\\d+)$",
+					"replacement": "$1$2",
+				},
+			},
+			{
+				Name: phalanxtoken.PatternReplaceTokenFilter,
+				Options: map[string]interface{}{
+					"pattern": "^-+$",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("tel 03-1234 -- XPS-13"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"tel", "031234", "XPS-13"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+	if tokens[2].PositionIncr != 2 {
+		t.Fatalf("`%v` is not `%v`\n", tokens[2].PositionIncr, 2)
+	}
+}
+
+func TestPatternCaptureTokenFilter(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.PatternCaptureTokenFilter,
+				Options: map[string]interface{}{
+					"patterns": []interface{}{
+						"(\\p{Ll}+|\\p{Lu}\\p{Ll}+|\\p{Lu}+)",
+						"(\\d+)",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("iPhone12Pro 9310"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"iPhone12Pro", "i", "Phone", "Pro", "12", "9310"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestNgramTokenizer(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.NgramTokenizer,
+			Options: map[string]interface{}{
+				"min_gram": float64(2),
+				"max_gram": float64(3),
+				"token_chars": []interface{}{
+					"letter",
+					"digit",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("Quick Fox"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"Qu", "Qui", "ui", "uic", "ic", "ick", "ck", "Fo", "Fox", "ox"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestEdgeNgramTokenizer(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.EdgeNgramTokenizer,
+			Options: map[string]interface{}{
+				"min_gram": float64(1),
+				"max_gram": float64(4),
+				"token_chars": []interface{}{
+					"letter",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("Quick Fox"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"Q", "Qu", "Qui", "Quic", "F", "Fo", "Fox"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestPathHierarchyTokenizer(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.PathHierarchyTokenizer,
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("/usr/local/bin"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"/usr", "/usr/local", "/usr/local/bin"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestPathHierarchyTokenizerWithReverse(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.PathHierarchyTokenizer,
+			Options: map[string]interface{}{
+				"delimiter": ".",
+				"reverse":   true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("www.example.com"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"www.example.com", "example.com", "com"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestCharGroupTokenizer(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.CharGroupTokenizer,
+			Options: map[string]interface{}{
+				"tokenize_on_chars": []interface{}{
+					"whitespace",
+					"-",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("XPS-13 9310"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"XPS", "13", "9310"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestCJKBigramTokenizer(t *testing.T) {
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.CJKBigramTokenizer,
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("我是中国人 한국어 abc"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"我是", "是中", "中国", "国人", "한국", "국어", "abc"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestStemmerTokenFilter(t *testing.T) {
+	languages := map[string][]string{
+		"english":         {"run", "queri", "cherri"},
+		"minimal_english": {"running", "query", "cherry"},
+		"light_german":    {"haus", "haus", "buch"},
+		"czech":           {"hrad", "hrad", "hrad"},
+		"bulgarian":       {"град", "град", "град"},
+		"indonesian":      {"celaka", "main", "lari"},
+	}
+	inputs := map[string]string{
+		"english":         "running queries cherries",
+		"minimal_english": "running queries cherries",
+		"light_german":    "häuser häusern bücher",
+		"czech":           "hrady hradech hradem",
+		"bulgarian":       "градове градът града",
+		"indonesian":      "kecelakaan permainan berlari",
+	}
+	for language, expected := range languages {
+		a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+			TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+				Name: phalanxtokenizer.WhitespaceTokenizer,
+			},
+			TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+				{
+					Name: phalanxtoken.StemmerTokenFilter,
+					Options: map[string]interface{}{
+						"language": language,
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		tokens := a.Analyze([]byte(inputs[language]))
+		actual := make([]string, len(tokens))
+		for i, token := range tokens {
+			actual[i] = string(token.Term)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%v: `%v` is not `%v`\n", language, actual, expected)
+		}
+	}
+
+	if _, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.StemmerTokenFilter,
+				Options: map[string]interface{}{
+					"language": "klingon",
+				},
+			},
+		},
+	}); err == nil {
+		t.Fatalf("unexpected language is accepted\n")
+	}
+}
+
+func TestHunspellTokenFilter(t *testing.T) {
+	affFile, err := filepath.Abs("../testdata/test_hunspell.aff")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	dicFile, err := filepath.Abs("../testdata/test_hunspell.dic")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.HunspellTokenFilter,
+				Options: map[string]interface{}{
+					"aff_uri":     "file://" + affFile,
+					"dic_uri":     "file://" + dicFile,
+					"ignore_case": true,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("Cherries unworked moved drive works xyz"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"cherry", "work", "move", "drive", "work", "xyz"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
 func TestLetterTokenizer(t *testing.T) {
 	indexMappingFile := "../testdata/test_mapping.json"
 
@@ -1349,6 +2049,244 @@ func TestStopTokensTokenFilter(t *testing.T) {
 	}
 }
 
+func TestSynonymTokenFilter(t *testing.T) {
+	indexMappingFile := "../testdata/test_mapping.json"
+
+	b, _ := ioutil.ReadFile(indexMappingFile)
+
+	mapping, _ := NewMapping(b)
+
+	a, err := mapping.GetAnalyzer("synonym_token_filter_test")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	input := analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("ny"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("city"),
+			PositionIncr: 1,
+		},
+	}
+
+	actual := a.TokenFilters[0].Filter(input)
+
+	expected := analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("ny"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("new"),
+			PositionIncr: 0,
+		},
+		&analysis.Token{
+			Term:         []byte("york"),
+			PositionIncr: 0,
+		},
+		&analysis.Token{
+			Term:         []byte("city"),
+			PositionIncr: 1,
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	input = analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("i"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("pod"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("x"),
+			PositionIncr: 1,
+		},
+	}
+
+	actual = a.TokenFilters[0].Filter(input)
+
+	expected = analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("ipod"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("x"),
+			PositionIncr: 2,
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestSynonymGraphTokenFilter(t *testing.T) {
+	indexMappingFile := "../testdata/test_mapping.json"
+
+	b, _ := ioutil.ReadFile(indexMappingFile)
+
+	mapping, _ := NewMapping(b)
+
+	a, err := mapping.GetAnalyzer("synonym_graph_token_filter_test")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	input := analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("ny"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("city"),
+			PositionIncr: 1,
+		},
+	}
+
+	actual := a.TokenFilters[0].Filter(input)
+
+	// The following tokens stay right after the kept input phrase, so both "new york" and "ny city" match as phrases.
+	expected := analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("ny"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("new"),
+			PositionIncr: 0,
+		},
+		&analysis.Token{
+			Term:         []byte("york"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("city"),
+			PositionIncr: 0,
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	input = analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("i"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("pod"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("x"),
+			PositionIncr: 1,
+		},
+	}
+
+	actual = a.TokenFilters[0].Filter(input)
+
+	expected = analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("ipod"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("x"),
+			PositionIncr: 1,
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+
+	input = analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("new"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("york"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("city"),
+			PositionIncr: 1,
+		},
+	}
+
+	actual = a.TokenFilters[0].Filter(input)
+
+	expected = analysis.TokenStream{
+		&analysis.Token{
+			Term:         []byte("new"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("ny"),
+			PositionIncr: 0,
+		},
+		&analysis.Token{
+			Term:         []byte("york"),
+			PositionIncr: 1,
+		},
+		&analysis.Token{
+			Term:         []byte("city"),
+			PositionIncr: 1,
+		},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
+func TestSynonymTokenFilterWithUri(t *testing.T) {
+	synonymsFile, err := filepath.Abs("../testdata/test_synonyms.txt")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	a, err := phalanxanalyzer.NewAnalyzer(phalanxanalyzer.AnalyzerSetting{
+		TokenizerSetting: phalanxtokenizer.TokenizerSetting{
+			Name: phalanxtokenizer.WhitespaceTokenizer,
+		},
+		TokenFilterSettings: []phalanxtoken.TokenFilterSetting{
+			{
+				Name: phalanxtoken.SynonymGraphTokenFilter,
+				Options: map[string]interface{}{
+					"synonyms_uri": "file://" + synonymsFile,
+					"ignore_case":  true,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokens := a.Analyze([]byte("The Cosmos"))
+	actual := make([]string, len(tokens))
+	for i, token := range tokens {
+		actual[i] = string(token.Term)
+	}
+	expected := []string{"The", "Cosmos", "universe"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("`%v` is not `%v`\n", actual, expected)
+	}
+}
+
 func TestTruncateTokenFilter(t *testing.T) {
 	indexMappingFile := "../testdata/test_mapping.json"
 
@@ -0,0 +1,59 @@
+package mapping
+
+import (
+	"fmt"
+
+	"github.com/mosuka/phalanx/errors"
+)
+
+// DynamicPolicy defines how fields that are not defined in the index mapping are handled.
+type DynamicPolicy string
+
+const (
+	// Undefined fields are typed by their name suffix, such as `title_text`.
+	DynamicTrue DynamicPolicy = "true"
+	// Undefined fields are silently dropped.
+	DynamicIgnore DynamicPolicy = "ignore"
+	// Undefined fields make the document invalid.
+	DynamicStrict DynamicPolicy = "strict"
+)
+
+const DefaultDynamicPolicy = DynamicTrue
+
+func NewDynamicPolicy(name string) (DynamicPolicy, error) {
+	switch DynamicPolicy(name) {
+	case "":
+		return DefaultDynamicPolicy, nil
+	case DynamicTrue, DynamicIgnore, DynamicStrict:
+		return DynamicPolicy(name), nil
+	default:
+		return "", fmt.Errorf("unknown dynamic policy: %s", name)
+	}
+}
+
+// ValidationError describes why a document does not match the index mapping.
+type ValidationError struct {
+	DocumentId    string
+	Field         string
+	ExpectedType  FieldType
+	ReceivedValue interface{}
+	Reason        string
+}
+
+func NewValidationError(documentId string, field string, expectedType FieldType, receivedValue interface{}, reason string) *ValidationError {
+	return &ValidationError{
+		DocumentId:    documentId,
+		Field:         field,
+		ExpectedType:  expectedType,
+		ReceivedValue: receivedValue,
+		Reason:        reason,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: id=%s, field=%s, expected_type=%s, received_value=%v: %s", errors.ErrInvalidDocument, e.DocumentId, e.Field, e.ExpectedType, e.ReceivedValue, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return errors.ErrInvalidDocument
+}
@@ -0,0 +1,126 @@
+package mapping
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/blugelabs/bluge"
+)
+
+type Similarity string
+
+const (
+	CosineSimilarity     Similarity = "cosine"
+	DotProductSimilarity Similarity = "dot_product"
+	L2Similarity         Similarity = "l2"
+)
+
+const DefaultSimilarity = CosineSimilarity
+
+func NewSimilarity(name string) (Similarity, error) {
+	switch Similarity(name) {
+	case "":
+		return DefaultSimilarity, nil
+	case CosineSimilarity, DotProductSimilarity, L2Similarity:
+		return Similarity(name), nil
+	default:
+		return "", fmt.Errorf("unknown similarity: %s", name)
+	}
+}
+
+// ValidateDenseVectorSetting checks the setting of a dense_vector field.
+// The dimension is required, so that all the vectors of the field can be compared.
+func ValidateDenseVectorSetting(dimension int, similarity Similarity) error {
+	if dimension <= 0 {
+		return fmt.Errorf("dense vector dimension is unexpected: %d", dimension)
+	}
+	if _, err := NewSimilarity(string(similarity)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Score returns the similarity between two vectors as a positive score.
+// Larger scores mean more similar vectors.
+func (s Similarity) Score(a []float32, b []float32) float64 {
+	switch s {
+	case DotProductSimilarity:
+		dot := dotProduct(a, b)
+		if dot < 0 {
+			return 1 / (1 - dot)
+		}
+		return dot + 1
+	case L2Similarity:
+		var sum float64
+		for i := range a {
+			d := float64(a[i]) - float64(b[i])
+			sum += d * d
+		}
+		return 1 / (1 + sum)
+	default:
+		normA := math.Sqrt(dotProduct(a, a))
+		normB := math.Sqrt(dotProduct(b, b))
+		if normA == 0 || normB == 0 {
+			return 0
+		}
+		return (1 + dotProduct(a, b)/(normA*normB)) / 2
+	}
+}
+
+func dotProduct(a []float32, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func IsDenseVector(value interface{}) bool {
+	_, err := MakeDenseVector(value)
+	return err == nil
+}
+
+func MakeDenseVector(value interface{}) ([]float32, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("value is not []interface{}")
+	}
+
+	vector := make([]float32, len(values))
+	for i, v := range values {
+		f64Value, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected vector element: %v", v)
+		}
+		vector[i] = float32(f64Value)
+	}
+
+	return vector, nil
+}
+
+// EncodeDenseVector encodes the vector as little-endian float32 values.
+func EncodeDenseVector(vector []float32) []byte {
+	buf := make([]byte, len(vector)*4)
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+func DecodeDenseVector(value []byte) ([]float32, error) {
+	if len(value)%4 != 0 {
+		return nil, fmt.Errorf("unexpected dense vector length: %d", len(value))
+	}
+
+	vector := make([]float32, len(value)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(value[i*4:]))
+	}
+	return vector, nil
+}
+
+func MakeDenseVectorField(fieldName string, fieldValue []float32) *bluge.TermField {
+	return bluge.NewStoredOnlyField(fieldName, EncodeDenseVector(fieldValue))
+}
@@ -2,6 +2,7 @@ package metastore
 
 import (
 	"encoding/json"
+	"sync"
 
 	"github.com/mosuka/phalanx/analysis/analyzer"
 	"github.com/mosuka/phalanx/mapping"
@@ -37,7 +38,14 @@ type IndexMetadata struct {
 	IndexMappingVersion int64                    `json:"index_mapping_version"`
 	DefaultSearchField  string                   `json:"default_search_field"`
 	DefaultAnalyzer     analyzer.AnalyzerSetting `json:"default_analyzer"`
+	Dynamic             mapping.DynamicPolicy    `json:"dynamic"`
+	Analysis            analyzer.AnalysisSetting `json:"analysis"`
+	DefaultPipeline     string                   `json:"default_pipeline"`
 	shardMetadataMap    cmap.ConcurrentMap       `json:"-"`
+
+	analyzerCache        *analyzer.AnalyzerCache
+	analyzerCacheVersion int64
+	analyzerCacheMutex   sync.Mutex
 }
 
 func NewIndexMetadata() *IndexMetadata {
@@ -61,6 +69,21 @@ func (m *IndexMetadata) Marshal() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// AnalyzerCache returns the analyzers of the index.
+// The cache is rebuilt when the index mapping version changes.
+// The analyzers using reloaded resources, such as word lists, are rebuilt by the cache itself.
+func (m *IndexMetadata) AnalyzerCache() *analyzer.AnalyzerCache {
+	m.analyzerCacheMutex.Lock()
+	defer m.analyzerCacheMutex.Unlock()
+
+	if m.analyzerCache == nil || m.analyzerCacheVersion != m.IndexMappingVersion {
+		m.analyzerCache = analyzer.NewAnalyzerCache(m.Analysis)
+		m.analyzerCacheVersion = m.IndexMappingVersion
+	}
+
+	return m.analyzerCache
+}
+
 func (m *IndexMetadata) ShardMetadataIter() <-chan cmap.Tuple {
 	return m.shardMetadataMap.IterBuffered()
 }
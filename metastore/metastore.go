@@ -8,7 +8,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/mosuka/phalanx/analysis/analyzer"
 	"github.com/mosuka/phalanx/errors"
+	"github.com/mosuka/phalanx/ingest"
 	"github.com/mosuka/phalanx/mapping"
 	"github.com/mosuka/rendezvous"
 	cmap "github.com/orcaman/concurrent-map"
@@ -18,6 +20,10 @@ import (
 const (
 	shardNamePrefix = "shard-"
 
+	// Directory of the ingest pipelines.
+	// It cannot be used as an index name.
+	PipelinesDirName = "_pipelines"
+
 	// Evvent size.
 	// Cluster events can occur in large numbers at once,
 	// so make sure they are large enough.
@@ -70,9 +76,20 @@ func makeShardMetadataPath(indexName string, shardName string) string {
 	return filepath.Join(indexName, fmt.Sprintf("%s.json", shardName))
 }
 
+// Make pipeline path
+// e.g. nginx_logs -> _pipelines/nginx_logs.json
+func makePipelinePath(pipelineName string) string {
+	return filepath.Join(PipelinesDirName, fmt.Sprintf("%s.json", pipelineName))
+}
+
+func isPipelinePath(path string) bool {
+	return filepath.Base(filepath.Dir(path)) == PipelinesDirName && strings.HasSuffix(path, ".json")
+}
+
 type Metastore struct {
 	storage          Storage
 	indexMetadataMap cmap.ConcurrentMap
+	pipelineMap      cmap.ConcurrentMap
 	ringMap          map[string]*rendezvous.Ring
 	events           chan MetastoreEvent
 	stopWatching     chan bool
@@ -101,11 +118,27 @@ func NewMetastore(storage Storage, logger *zap.Logger) (*Metastore, error) {
 	}
 
 	indexMetadataMap := cmap.New()
+	pipelineMap := cmap.New()
 	ringMap := make(map[string]*rendezvous.Ring)
 
 	for _, path := range paths {
 		fileName := filepath.Base(path)
-		if fileName == "index.json" {
+		if isPipelinePath(path) {
+			value, err := storage.Get(ctx, path)
+			if err != nil {
+				logger.Error(err.Error(), zap.String("path", path))
+				return nil, err
+			}
+
+			pipelineSetting, err := ingest.NewPipelineSetting(value)
+			if err != nil {
+				logger.Error(err.Error(), zap.String("path", path))
+				return nil, err
+			}
+
+			pipelineName := strings.TrimSuffix(fileName, ".json")
+			pipelineMap.Set(pipelineName, pipelineSetting)
+		} else if fileName == "index.json" {
 			value, err := storage.Get(ctx, path)
 			if err != nil {
 				logger.Error(err.Error(), zap.String("path", path))
@@ -127,6 +160,9 @@ func NewMetastore(storage Storage, logger *zap.Logger) (*Metastore, error) {
 
 	for _, path := range paths {
 		fileName := filepath.Base(path)
+		if isPipelinePath(path) {
+			continue
+		}
 		if strings.HasPrefix(fileName, shardNamePrefix) && strings.HasSuffix(fileName, ".json") {
 			value, err := storage.Get(ctx, path)
 			if err != nil {
@@ -166,6 +202,7 @@ func NewMetastore(storage Storage, logger *zap.Logger) (*Metastore, error) {
 	metastore := &Metastore{
 		storage:          storage,
 		indexMetadataMap: indexMetadataMap,
+		pipelineMap:      pipelineMap,
 		ringMap:          ringMap,
 		stopWatching:     make(chan bool),
 		events:           make(chan MetastoreEvent, metastoreEventSize),
@@ -303,7 +340,18 @@ func (m *Metastore) handleStorageEvent(event StorageEvent) error {
 
 	switch event.Type {
 	case StorageEventTypePut:
-		if fileName == "index.json" {
+		if isPipelinePath(event.Path) {
+			pipelineName := strings.TrimSuffix(fileName, ".json")
+
+			pipelineSetting, err := ingest.NewPipelineSetting(event.Value)
+			if err != nil {
+				m.logger.Warn("failed to make pipeline", zap.Error(err), zap.String("path", event.Path))
+				return err
+			}
+
+			m.pipelineMap.Set(pipelineName, pipelineSetting)
+			m.logger.Info("put pipeline", zap.String("pipeline_name", pipelineName))
+		} else if fileName == "index.json" {
 			indexName := filepath.Base(filepath.Dir(event.Path))
 
 			indexMetadata, err := NewIndexMetadataWithBytes(event.Value)
@@ -373,7 +421,12 @@ func (m *Metastore) handleStorageEvent(event StorageEvent) error {
 			m.logger.Info("sent metastore shard put event", zap.String("index_name", indexName), zap.String("shard_name", shardName))
 		}
 	case StorageEventTypeDelete:
-		if fileName == "index.json" {
+		if isPipelinePath(event.Path) {
+			pipelineName := strings.TrimSuffix(fileName, ".json")
+
+			m.pipelineMap.Remove(pipelineName)
+			m.logger.Info("delete pipeline", zap.String("pipeline_name", pipelineName))
+		} else if fileName == "index.json" {
 			indexName := filepath.Base(filepath.Dir(event.Path))
 
 			delete(m.ringMap, indexName)
@@ -533,3 +586,105 @@ func (m *Metastore) GetMapping(indexName string) (mapping.IndexMapping, error) {
 
 	return indexMetadata.IndexMapping, nil
 }
+
+func (m *Metastore) GetAnalysis(indexName string) (analyzer.AnalysisSetting, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	indexMetadata := m.getIndexMetadata(indexName)
+	if indexMetadata == nil {
+		err := errors.ErrIndexMetadataDoesNotExist
+		m.logger.Error(err.Error(), zap.String("index_name", indexName))
+		return analyzer.AnalysisSetting{}, err
+	}
+
+	return indexMetadata.Analysis, nil
+}
+
+func (m *Metastore) GetDynamicPolicy(indexName string) (mapping.DynamicPolicy, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	indexMetadata := m.getIndexMetadata(indexName)
+	if indexMetadata == nil {
+		err := errors.ErrIndexMetadataDoesNotExist
+		m.logger.Error(err.Error(), zap.String("index_name", indexName))
+		return "", err
+	}
+
+	return mapping.NewDynamicPolicy(string(indexMetadata.Dynamic))
+}
+
+func (m *Metastore) GetAnalyzerCache(indexName string) (*analyzer.AnalyzerCache, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	indexMetadata := m.getIndexMetadata(indexName)
+	if indexMetadata == nil {
+		err := errors.ErrIndexMetadataDoesNotExist
+		m.logger.Error(err.Error(), zap.String("index_name", indexName))
+		return nil, err
+	}
+
+	return indexMetadata.AnalyzerCache(), nil
+}
+
+func (m *Metastore) PipelineExists(pipelineName string) bool {
+	_, ok := m.pipelineMap.Get(pipelineName)
+	return ok
+}
+
+func (m *Metastore) GetPipeline(pipelineName string) (*ingest.PipelineSetting, error) {
+	if tmpPipelineSetting, ok := m.pipelineMap.Get(pipelineName); ok {
+		if pipelineSetting, ok := tmpPipelineSetting.(*ingest.PipelineSetting); ok {
+			return pipelineSetting, nil
+		}
+	}
+
+	return nil, errors.ErrPipelineDoesNotExist
+}
+
+func (m *Metastore) SetPipeline(pipelineName string, pipelineSetting *ingest.PipelineSetting) error {
+	if !ingest.IsValidPipelineName(pipelineName) {
+		err := fmt.Errorf("%w: pipeline name is unexpected: %s", errors.ErrInvalidPipeline, pipelineName)
+		m.logger.Error(err.Error(), zap.String("pipeline_name", pipelineName))
+		return err
+	}
+
+	value, err := pipelineSetting.Marshal()
+	if err != nil {
+		m.logger.Error(err.Error())
+		return err
+	}
+
+	pipelinePath := makePipelinePath(pipelineName)
+	m.logger.Info("put pipeline", zap.String("path", pipelinePath))
+	if err := m.storage.Put(m.ctx, pipelinePath, value); err != nil {
+		m.logger.Error(err.Error(), zap.String("path", pipelinePath))
+		return err
+	}
+
+	// Storage events are delivered asynchronously, so the pipeline is also set here
+	// to make it available immediately on this node.
+	m.pipelineMap.Set(pipelineName, pipelineSetting)
+
+	return nil
+}
+
+func (m *Metastore) DeletePipeline(pipelineName string) error {
+	if !m.PipelineExists(pipelineName) {
+		err := errors.ErrPipelineDoesNotExist
+		m.logger.Error(err.Error(), zap.String("pipeline_name", pipelineName))
+		return err
+	}
+
+	pipelinePath := makePipelinePath(pipelineName)
+	if err := m.storage.Delete(m.ctx, pipelinePath); err != nil {
+		m.logger.Error(err.Error(), zap.String("path", pipelinePath))
+		return err
+	}
+
+	m.pipelineMap.Remove(pipelineName)
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/mapping"
 	"github.com/mosuka/phalanx/proto"
 )
 
@@ -45,7 +46,9 @@ var (
 	}
 )
 
-func NewAggregations(requests map[string]*proto.AggregationRequest) (map[string]search.Aggregation, error) {
+// NewAggregations creates aggregations from the requests.
+// Options that are omitted in the requests are filled from the index mapping.
+func NewAggregations(requests map[string]*proto.AggregationRequest, indexMapping mapping.IndexMapping) (map[string]search.Aggregation, error) {
 	aggs := make(map[string]search.Aggregation)
 	for name, request := range requests {
 		switch request.Type {
@@ -76,6 +79,15 @@ func NewAggregations(requests map[string]*proto.AggregationRequest) (map[string]
 			if err := json.Unmarshal(request.Options, &opts); err != nil {
 				return nil, err
 			}
+			if _, ok := opts["formats"]; !ok {
+				if field, ok := opts["field"].(string); ok {
+					formats := make([]interface{}, 0)
+					for _, format := range indexMapping.GetDateTimeFormats(field) {
+						formats = append(formats, format)
+					}
+					opts["formats"] = formats
+				}
+			}
 			agg, err := NewDateRangeAggregationWithOptions(opts)
 			if err != nil {
 				return nil, err
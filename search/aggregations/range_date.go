@@ -2,6 +2,7 @@ package aggregations
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/blugelabs/bluge/search"
 	"github.com/blugelabs/bluge/search/aggregations"
@@ -15,6 +16,7 @@ import (
 // Options example:
 // {
 //   "field": "timestamp",
+//   "formats": ["2006-01-02", "epoch_millis"],
 //   "ranges": {
 //     "year_before_last": {
 //       "start": "2020-01-01T00:00:00Z",
@@ -30,6 +32,8 @@ import (
 //     }
 //   }
 // }
+// If formats are omitted, the formats of the field mapping are used.
+// RFC 3339 is always accepted.
 func NewDateRangeAggregationWithOptions(opts map[string]interface{}) (*aggregations.DateRangeAggregation, error) {
 	fieldValue, ok := opts["field"]
 	if !ok {
@@ -43,6 +47,25 @@ func NewDateRangeAggregationWithOptions(opts map[string]interface{}) (*aggregati
 		return nil, fmt.Errorf("field option is empty")
 	}
 
+	formats := make([]string, 0)
+	if formatsValue, ok := opts["formats"]; ok && formatsValue != nil {
+		formatValues, ok := formatsValue.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("formats option is unexpected: %v", formatsValue)
+		}
+		for _, formatValue := range formatValues {
+			format, ok := formatValue.(string)
+			if !ok {
+				return nil, fmt.Errorf("formats option is unexpected: %v", formatsValue)
+			}
+			formats = append(formats, format)
+		}
+	}
+	if err := mapping.ValidateDateTimeFormats(formats); err != nil {
+		return nil, fmt.Errorf("formats option is unexpected: %v", err)
+	}
+	formats = append(formats, time.RFC3339)
+
 	dateRangesAgg := aggregations.DateRanges(search.Field(field))
 
 	ranges, ok := opts["ranges"].(map[string]interface{})
@@ -59,7 +82,7 @@ func NewDateRangeAggregationWithOptions(opts map[string]interface{}) (*aggregati
 		if !ok {
 			return nil, fmt.Errorf("range %v start option is unexpected: %v", name, rangeMap["start"])
 		}
-		start, err := mapping.MakeDateTimeWithRfc3339(startStr)
+		start, err := mapping.MakeDateTimeWithFormats(startStr, formats)
 		if err != nil {
 			return nil, fmt.Errorf("range %v start option is unexpected: %v", name, startStr)
 		}
@@ -68,7 +91,7 @@ func NewDateRangeAggregationWithOptions(opts map[string]interface{}) (*aggregati
 		if !ok {
 			return nil, fmt.Errorf("range %v end option is unexpected: %v", name, rangeMap["high"])
 		}
-		end, err := mapping.MakeDateTimeWithRfc3339(endStr)
+		end, err := mapping.MakeDateTimeWithFormats(endStr, formats)
 		if err != nil {
 			return nil, fmt.Errorf("range %v start option is unexpected: %v", name, endStr)
 		}
@@ -0,0 +1,187 @@
+package collapse
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/analysis/tokenizer"
+	"github.com/mosuka/phalanx/mapping"
+	"github.com/mosuka/phalanx/proto"
+)
+
+// MaxWindowSize is the maximum number of the top documents searched to find the groups.
+const MaxWindowSize = 10000
+
+// Collapser keeps only the best document per value of a field.
+// The values are read from the stored values of the field, so the field must be stored.
+// Only the first value of a multi-valued field is used, and the documents without a value are not collapsed.
+// The field must be a numeric field or a keyword field, a text field analyzed into the whole value as is,
+// so that the documents of a group are found by the exact term of the value.
+type Collapser struct {
+	field     string
+	fieldType mapping.FieldType
+}
+
+func NewCollapser(field string, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) (*Collapser, error) {
+	if field == "" {
+		return nil, fmt.Errorf("collapse field does not exist")
+	}
+
+	fieldType, err := indexMapping.GetFieldType(field)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fieldType {
+	case mapping.TextField:
+		analyzerSetting, err := indexMapping.GetAnalyzerSetting(field)
+		if err != nil {
+			return nil, err
+		}
+		// The named analyzers are resolved with the analysis settings of the index.
+		resolved, err := analysisSetting.Resolve(analyzerSetting)
+		if err != nil {
+			return nil, err
+		}
+		if !isKeyword(resolved) {
+			return nil, fmt.Errorf("collapse field is not a keyword field: %s", field)
+		}
+	case mapping.NumericField:
+	default:
+		return nil, fmt.Errorf("collapse field type is unexpected: %s", fieldType)
+	}
+
+	// The fields not defined in the mapping are stored by default.
+	if fieldOptions, err := indexMapping.GetFieldOptions(field); err == nil && fieldOptions&bluge.Store == 0 {
+		return nil, fmt.Errorf("collapse field is not stored: %s", field)
+	}
+
+	return &Collapser{
+		field:     field,
+		fieldType: fieldType,
+	}, nil
+}
+
+// isKeyword returns whether the resolved analyzer indexes the whole text as a single term without modifying it.
+func isKeyword(analyzerSetting analyzer.AnalyzerSetting) bool {
+	return analyzerSetting.Language == "" &&
+		len(analyzerSetting.CharFilterSettings) == 0 &&
+		analyzerSetting.TokenizerSetting.Name == tokenizer.SingleTokenTokenizer &&
+		len(analyzerSetting.TokenFilterSettings) == 0
+}
+
+func (c *Collapser) Field() string {
+	return c.field
+}
+
+func (c *Collapser) FieldType() mapping.FieldType {
+	return c.fieldType
+}
+
+// Key returns the collapse key of the stored value of the field.
+// The numeric values are formatted in the shortest representation, so that the same values have the same key.
+func (c *Collapser) Key(value []byte) (string, error) {
+	switch c.fieldType {
+	case mapping.NumericField:
+		f64Value, err := bluge.DecodeNumericFloat64(value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(f64Value, 'f', -1, 64), nil
+	default:
+		return string(value), nil
+	}
+}
+
+// DocumentMatchKey returns the collapse key of the document, or an empty string if the document has no value.
+func (c *Collapser) DocumentMatchKey(docMatch *search.DocumentMatch) (string, error) {
+	key := ""
+	var keyErr error
+	err := docMatch.VisitStoredFields(func(field string, value []byte) bool {
+		if field != c.field {
+			return true
+		}
+		key, keyErr = c.Key(value)
+		return false
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return key, keyErr
+}
+
+// Size returns the number of the top documents of the search that contain the top num groups.
+// The top documents are searched with doubling sizes until num groups are found or all the documents are searched.
+// The sizes are doubled up to MaxWindowSize, and the groups found in the window are returned at that point.
+func (c *Collapser) Size(ctx context.Context, readers []*bluge.Reader, query bluge.Query, sortBy string, num int) (int, error) {
+	if num <= 0 {
+		return 0, nil
+	}
+
+	size := num
+	for {
+		request := bluge.NewTopNSearch(size, query).SortBy([]string{sortBy})
+		docMatchIter, err := bluge.MultiSearch(ctx, request, readers...)
+		if err != nil {
+			return 0, err
+		}
+
+		keys := make(map[string]bool)
+		groups := 0
+		count := 0
+		docMatch, err := docMatchIter.Next()
+		for err == nil && docMatch != nil {
+			count++
+
+			key, keyErr := c.DocumentMatchKey(docMatch)
+			if keyErr != nil {
+				return 0, keyErr
+			}
+			if key == "" || !keys[key] {
+				keys[key] = true
+				groups++
+			}
+			if groups >= num {
+				return count, nil
+			}
+
+			docMatch, err = docMatchIter.Next()
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		// All the documents or the maximum window are searched.
+		if count < size || size >= MaxWindowSize {
+			return count, nil
+		}
+
+		size *= 2
+		if size > MaxWindowSize {
+			size = MaxWindowSize
+		}
+	}
+}
+
+// Collapse keeps the first document per collapse key of the sorted documents.
+// The documents without a collapse key are all kept.
+func Collapse(docs []*proto.Document) []*proto.Document {
+	keys := make(map[string]bool)
+	collapsedDocs := make([]*proto.Document, 0, len(docs))
+	for _, doc := range docs {
+		if doc.CollapseKey != "" {
+			if keys[doc.CollapseKey] {
+				continue
+			}
+			keys[doc.CollapseKey] = true
+		}
+		collapsedDocs = append(collapsedDocs, doc)
+	}
+
+	return collapsedDocs
+}
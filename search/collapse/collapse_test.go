@@ -0,0 +1,237 @@
+package collapse
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/analysis/tokenizer"
+	"github.com/mosuka/phalanx/mapping"
+	"github.com/mosuka/phalanx/proto"
+)
+
+var testMapping = []byte(`{
+	"brand": {
+		"type": "text",
+		"options": {"index": true, "store": true},
+		"analyzer": {"tokenizer": {"name": "single_token"}}
+	},
+	"lower_brand": {
+		"type": "text",
+		"options": {"index": true, "store": true},
+		"analyzer": {"tokenizer": {"name": "single_token"}, "token_filters": [{"name": "lower_case"}]}
+	},
+	"named_brand": {
+		"type": "text",
+		"options": {"index": true, "store": true},
+		"analyzer": "keyword"
+	},
+	"unstored_brand": {
+		"type": "text",
+		"options": {"index": true, "store": false},
+		"analyzer": {"tokenizer": {"name": "single_token"}}
+	},
+	"unstored_price": {
+		"type": "numeric",
+		"options": {"index": true, "store": false}
+	},
+	"title": {
+		"type": "text",
+		"options": {"index": true, "store": true},
+		"analyzer": {"tokenizer": {"name": "unicode"}}
+	},
+	"price": {
+		"type": "numeric",
+		"options": {"index": true, "store": true}
+	},
+	"location": {
+		"type": "geo_point",
+		"options": {"index": true, "store": true}
+	}
+}`)
+
+var testAnalysis = analyzer.AnalysisSetting{
+	Analyzers: map[string]analyzer.AnalyzerSetting{
+		"keyword": {
+			TokenizerSetting: tokenizer.TokenizerSetting{
+				Name: tokenizer.SingleTokenTokenizer,
+			},
+		},
+	},
+}
+
+func newTestMapping(t *testing.T) mapping.IndexMapping {
+	indexMapping, err := mapping.NewMapping(testMapping)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return indexMapping
+}
+
+// openShard indexes the documents with the brands in order of decreasing price.
+func openShard(t *testing.T, brands []string) *bluge.Reader {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	batch := bluge.NewBatch()
+	for i, brand := range brands {
+		doc := bluge.NewDocument(fmt.Sprintf("%d", i+1))
+		if brand != "" {
+			doc.AddField(bluge.NewKeywordField("brand", brand).StoreValue())
+		}
+		doc.AddField(bluge.NewNumericField("price", float64(len(brands)-i)).StoreValue().Sortable())
+		batch.Update(doc.ID(), doc)
+	}
+	if err := writer.Batch(batch); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	return reader
+}
+
+func TestNewCollapser(t *testing.T) {
+	indexMapping := newTestMapping(t)
+
+	// The named analyzers are resolved with the analysis settings.
+	for _, field := range []string{"brand", "named_brand", "price"} {
+		if _, err := NewCollapser(field, indexMapping, testAnalysis); err != nil {
+			t.Fatalf("unexpected error with %s: %v\n", field, err)
+		}
+	}
+
+	// The groups of the analyzed values are not found by the exact terms of the stored values.
+	for _, field := range []string{"lower_brand", "title", "location", "unknown_text", ""} {
+		if _, err := NewCollapser(field, indexMapping, testAnalysis); err == nil {
+			t.Fatalf("expected error with %s\n", field)
+		}
+	}
+
+	// The values of the groups are read from the stored values.
+	for _, field := range []string{"unstored_brand", "unstored_price"} {
+		if _, err := NewCollapser(field, indexMapping, testAnalysis); err == nil {
+			t.Fatalf("expected error with %s\n", field)
+		}
+	}
+}
+
+func TestCollapserKey(t *testing.T) {
+	indexMapping := newTestMapping(t)
+
+	collapser, err := NewCollapser("price", indexMapping, testAnalysis)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	key, err := collapser.Key(bluge.NewNumericField("price", 1.5).Value())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if key != "1.5" {
+		t.Fatalf("unexpected key: %v\n", key)
+	}
+
+	collapser, err = NewCollapser("brand", indexMapping, testAnalysis)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	key, err = collapser.Key([]byte("Acme Wear"))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if key != "Acme Wear" {
+		t.Fatalf("unexpected key: %v\n", key)
+	}
+}
+
+func TestCollapserSize(t *testing.T) {
+	indexMapping := newTestMapping(t)
+
+	collapser, err := NewCollapser("brand", indexMapping, testAnalysis)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reader := openShard(t, []string{"a", "a", "a", "b", "", "a", "c", "c", "d"})
+	defer reader.Close()
+
+	query := bluge.NewMatchAllQuery()
+	cases := []struct {
+		num  int
+		size int
+	}{
+		// The documents without a value are groups of their own.
+		{num: 1, size: 1},
+		{num: 2, size: 4},
+		{num: 3, size: 5},
+		{num: 4, size: 7},
+		// All the documents are searched.
+		{num: 10, size: 9},
+	}
+	for _, c := range cases {
+		size, err := collapser.Size(context.Background(), []*bluge.Reader{reader}, query, "-price", c.num)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if size != c.size {
+			t.Fatalf("unexpected size for %d groups: %v\n", c.num, size)
+		}
+	}
+}
+
+func TestCollapserSizeWithMaxWindowSize(t *testing.T) {
+	indexMapping := newTestMapping(t)
+
+	collapser, err := NewCollapser("brand", indexMapping, testAnalysis)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// The second group is beyond the maximum window.
+	brands := make([]string, MaxWindowSize+1)
+	for i := range brands {
+		brands[i] = "a"
+	}
+	brands[MaxWindowSize] = "b"
+	reader := openShard(t, brands)
+	defer reader.Close()
+
+	size, err := collapser.Size(context.Background(), []*bluge.Reader{reader}, bluge.NewMatchAllQuery(), "-price", 2)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if size != MaxWindowSize {
+		t.Fatalf("unexpected size: %v\n", size)
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	docs := []*proto.Document{
+		{Id: "1", CollapseKey: "a"},
+		{Id: "2", CollapseKey: "a"},
+		{Id: "3", CollapseKey: ""},
+		{Id: "4", CollapseKey: "b"},
+		{Id: "5", CollapseKey: ""},
+		{Id: "6", CollapseKey: "b"},
+	}
+
+	ids := []string{}
+	for _, doc := range Collapse(docs) {
+		ids = append(ids, doc.Id)
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "3", "4", "5"}) {
+		t.Fatalf("unexpected documents: %v\n", ids)
+	}
+}
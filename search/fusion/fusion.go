@@ -0,0 +1,41 @@
+package fusion
+
+import (
+	"github.com/mosuka/phalanx/errors"
+	"github.com/mosuka/phalanx/proto"
+)
+
+type FusionType int
+
+const (
+	FusionTypeUnknown FusionType = iota
+	FusionTypeRrf
+)
+
+// Maps for FusionType.
+var (
+	FusionType_name = map[FusionType]string{
+		FusionTypeUnknown: "unknown",
+		FusionTypeRrf:     "rrf",
+	}
+	FusionType_value = map[string]FusionType{
+		"unknown": FusionTypeUnknown,
+		"rrf":     FusionTypeRrf,
+	}
+)
+
+// Fusion combines several ranked lists of documents into a single ranking.
+type Fusion interface {
+	// WindowSize returns the number of top documents taken from each ranking.
+	WindowSize() int
+	Fuse(rankings ...[]*proto.Document) []*proto.Document
+}
+
+func NewFusion(fusionType string, fusionOpts map[string]interface{}) (Fusion, error) {
+	switch FusionType_value[fusionType] {
+	case FusionTypeRrf:
+		return NewRrfFusionWithMap(fusionOpts)
+	default:
+		return nil, errors.ErrUnknownFusionType
+	}
+}
@@ -0,0 +1,104 @@
+package fusion
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mosuka/phalanx/proto"
+)
+
+const (
+	DefaultRankConstant = 60
+	DefaultWindowSize   = 100
+)
+
+type RrfFusionOptions struct {
+	RankConstant int `json:"rank_constant"`
+	WindowSize   int `json:"window_size"`
+}
+
+func NewRrfFusionOptions() RrfFusionOptions {
+	return RrfFusionOptions{
+		RankConstant: DefaultRankConstant,
+		WindowSize:   DefaultWindowSize,
+	}
+}
+
+// Create new RrfFusion with given options.
+// Options example:
+// {
+//   "rank_constant": 60,
+//   "window_size": 100
+// }
+func NewRrfFusionWithMap(opts map[string]interface{}) (*RrfFusion, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewRrfFusionOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewRrfFusionWithOptions(options)
+}
+
+func NewRrfFusionWithOptions(opts RrfFusionOptions) (*RrfFusion, error) {
+	if opts.RankConstant < 1 {
+		return nil, fmt.Errorf("rank_constant option is unexpected: %v", opts.RankConstant)
+	}
+
+	if opts.WindowSize < 1 {
+		return nil, fmt.Errorf("window_size option is unexpected: %v", opts.WindowSize)
+	}
+
+	return &RrfFusion{
+		rankConstant: opts.RankConstant,
+		windowSize:   opts.WindowSize,
+	}, nil
+}
+
+// RrfFusion implements Reciprocal Rank Fusion.
+// Each document scores the sum of 1 / (rank_constant + rank) over the rankings it appears in.
+type RrfFusion struct {
+	rankConstant int
+	windowSize   int
+}
+
+func (f *RrfFusion) WindowSize() int {
+	return f.windowSize
+}
+
+func (f *RrfFusion) Fuse(rankings ...[]*proto.Document) []*proto.Document {
+	docs := make(map[string]*proto.Document)
+	scores := make(map[string]float64)
+	ids := make([]string, 0)
+
+	for _, ranking := range rankings {
+		if len(ranking) > f.windowSize {
+			ranking = ranking[:f.windowSize]
+		}
+		for i, doc := range ranking {
+			if _, ok := docs[doc.Id]; !ok {
+				docs[doc.Id] = doc
+				ids = append(ids, doc.Id)
+			}
+			scores[doc.Id] += 1.0 / float64(f.rankConstant+i+1)
+		}
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		return scores[ids[i]] > scores[ids[j]]
+	})
+
+	fusedDocs := make([]*proto.Document, len(ids))
+	for i, id := range ids {
+		doc := docs[id]
+		doc.Score = scores[id]
+		fusedDocs[i] = doc
+	}
+
+	return fusedDocs
+}
@@ -0,0 +1,100 @@
+package fusion
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mosuka/phalanx/proto"
+)
+
+func newDocs(ids ...string) []*proto.Document {
+	docs := make([]*proto.Document, 0, len(ids))
+	for _, id := range ids {
+		docs = append(docs, &proto.Document{Id: id})
+	}
+	return docs
+}
+
+func docIds(docs []*proto.Document) []string {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.Id)
+	}
+	return ids
+}
+
+// rrfScore sums up the reciprocal ranks in order, as the fusion does.
+func rrfScore(rankConstant int, ranks ...int) float64 {
+	score := 0.0
+	for _, rank := range ranks {
+		score += 1.0 / float64(rankConstant+rank)
+	}
+	return score
+}
+
+func TestRrfFusion(t *testing.T) {
+	fusion, err := NewFusion("rrf", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	docs := fusion.Fuse(newDocs("a", "b", "c"), newDocs("c", "a", "d"))
+
+	if !reflect.DeepEqual(docIds(docs), []string{"a", "c", "b", "d"}) {
+		t.Fatalf("unexpected documents: %v\n", docIds(docs))
+	}
+	scores := []float64{
+		rrfScore(60, 1, 2),
+		rrfScore(60, 3, 1),
+		rrfScore(60, 2),
+		rrfScore(60, 3),
+	}
+	for i, doc := range docs {
+		if doc.Score != scores[i] {
+			t.Fatalf("unexpected score of %s: %v\n", doc.Id, doc.Score)
+		}
+	}
+}
+
+func TestRrfFusionTies(t *testing.T) {
+	fusion, err := NewFusion("rrf", map[string]interface{}{
+		"rank_constant": 1,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// The documents with the same score are ranked in order of their first appearance.
+	docs := fusion.Fuse(newDocs("a", "b"), newDocs("b", "a"))
+	if !reflect.DeepEqual(docIds(docs), []string{"a", "b"}) {
+		t.Fatalf("unexpected documents: %v\n", docIds(docs))
+	}
+	if docs[0].Score != rrfScore(1, 1, 2) || docs[1].Score != docs[0].Score {
+		t.Fatalf("unexpected scores: %v, %v\n", docs[0].Score, docs[1].Score)
+	}
+}
+
+func TestRrfFusionWindowSize(t *testing.T) {
+	fusion, err := NewFusion("rrf", map[string]interface{}{
+		"window_size": 1,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// Only the top documents of each ranking are fused.
+	docs := fusion.Fuse(newDocs("a", "b"), newDocs("c", "a"))
+	if !reflect.DeepEqual(docIds(docs), []string{"a", "c"}) {
+		t.Fatalf("unexpected documents: %v\n", docIds(docs))
+	}
+}
+
+func TestNewFusion(t *testing.T) {
+	if _, err := NewFusion("unknown", map[string]interface{}{}); err == nil {
+		t.Fatalf("expected error with unknown fusion type\n")
+	}
+
+	if _, err := NewFusion("rrf", map[string]interface{}{"rank_constant": 0}); err == nil {
+		t.Fatalf("expected error with rank_constant 0\n")
+	}
+}
@@ -15,6 +15,7 @@ type BooleanQueryOptions struct {
 	Must      []QuerySetting `json:"must"`
 	MustNot   []QuerySetting `json:"must_not"`
 	Should    []QuerySetting `json:"should"`
+	Filter    []QuerySetting `json:"filter"`
 	MinShould int            `json:"min_should"`
 	Boost     float64        `json:"boost"`
 }
@@ -80,6 +81,15 @@ func NewBooleanQueryOptions() BooleanQueryOptions {
 //       }
 //     }
 //   ],
+//   "filter": [
+//     {
+//       "type": "term",
+//       "options": {
+//         "term": "greeting",
+//         "field": "category"
+//       }
+//     }
+//   ],
 //   "min_should": 1,
 //   "boost": 1.0
 // }
@@ -118,6 +128,13 @@ func NewBooleanQueryWithOptions(opts BooleanQueryOptions) (*bluge.BooleanQuery,
 		}
 	}
 
+	// Filter queries must match, but do not contribute to the score.
+	for _, filterQuery := range opts.Filter {
+		if query, err := NewQuery(filterQuery.Type, filterQuery.Options); err == nil {
+			booleanQuery.AddMust(NewConstantScoreQuery(query).SetBoost(0.0))
+		}
+	}
+
 	// min_should is optional.
 	if opts.MinShould > 0 {
 		booleanQuery.SetMinShould(opts.MinShould)
@@ -0,0 +1,155 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+)
+
+type ConstantScoreQueryOptions struct {
+	Filter *QuerySetting `json:"filter"`
+	Boost  float64       `json:"boost"`
+}
+
+func NewConstantScoreQueryOptions() ConstantScoreQueryOptions {
+	return ConstantScoreQueryOptions{
+		Boost: 1.0,
+	}
+}
+
+// Create new ConstantScoreQuery with given options.
+// Options example:
+// {
+//   "filter": {
+//     "type": "term",
+//     "options": {
+//       "term": "search",
+//       "field": "category"
+//     }
+//   },
+//   "boost": 1.2
+// }
+func NewConstantScoreQueryWithMap(opts map[string]interface{}) (*ConstantScoreQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewConstantScoreQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewConstantScoreQueryWithOptions(options)
+}
+
+func NewConstantScoreQueryWithOptions(opts ConstantScoreQueryOptions) (*ConstantScoreQuery, error) {
+	if opts.Filter == nil {
+		return nil, fmt.Errorf("filter option does not exist")
+	}
+
+	filter, err := NewQuery(opts.Filter.Type, opts.Filter.Options)
+	if err != nil {
+		return nil, fmt.Errorf("filter option is unexpected: %v", err)
+	}
+
+	constantScoreQuery := NewConstantScoreQuery(filter)
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		constantScoreQuery.SetBoost(opts.Boost)
+	}
+
+	return constantScoreQuery, nil
+}
+
+// ConstantScoreQuery matches the documents matching the filter,
+// and scores all of them with the boost.
+// The filter is not scored, so it is cheaper than scoring the query.
+type ConstantScoreQuery struct {
+	filter bluge.Query
+	boost  float64
+}
+
+func NewConstantScoreQuery(filter bluge.Query) *ConstantScoreQuery {
+	return &ConstantScoreQuery{
+		filter: filter,
+		boost:  1.0,
+	}
+}
+
+func (q *ConstantScoreQuery) SetBoost(b float64) *ConstantScoreQuery {
+	q.boost = b
+	return q
+}
+
+func (q *ConstantScoreQuery) Filter() bluge.Query {
+	return q.filter
+}
+
+func (q *ConstantScoreQuery) Boost() float64 {
+	return q.boost
+}
+
+func (q *ConstantScoreQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	filterSearcher, err := q.filter.Searcher(i, filterSearcherOptions(options))
+	if err != nil {
+		return nil, err
+	}
+
+	return newConstantScoreSearcher(filterSearcher, q.boost, options), nil
+}
+
+// filterSearcherOptions returns the options to search documents without scoring them.
+func filterSearcherOptions(options search.SearcherOptions) search.SearcherOptions {
+	filterOptions := options
+	filterOptions.Score = "none"
+	filterOptions.Explain = false
+	filterOptions.IncludeTermVectors = false
+
+	return filterOptions
+}
+
+// newConstantScoreSearcher scores the documents matched by the searcher with the boost.
+func newConstantScoreSearcher(s search.Searcher, boost float64, options search.SearcherOptions) search.Searcher {
+	return &constantScoreSearcher{
+		Searcher: s,
+		boost:    boost,
+		explain:  options.Explain,
+	}
+}
+
+type constantScoreSearcher struct {
+	search.Searcher
+	boost   float64
+	explain bool
+}
+
+func (s *constantScoreSearcher) Next(ctx *search.Context) (*search.DocumentMatch, error) {
+	dm, err := s.Searcher.Next(ctx)
+	if err != nil || dm == nil {
+		return dm, err
+	}
+
+	s.score(dm)
+	return dm, nil
+}
+
+func (s *constantScoreSearcher) Advance(ctx *search.Context, number uint64) (*search.DocumentMatch, error) {
+	dm, err := s.Searcher.Advance(ctx, number)
+	if err != nil || dm == nil {
+		return dm, err
+	}
+
+	s.score(dm)
+	return dm, nil
+}
+
+func (s *constantScoreSearcher) score(dm *search.DocumentMatch) {
+	dm.Score = s.boost
+	if s.explain {
+		dm.Explanation = search.NewExplanation(s.boost, "constant score, boost")
+	}
+}
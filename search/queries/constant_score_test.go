@@ -0,0 +1,116 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+)
+
+func TestNewConstantScoreQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_constant_score_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewConstantScoreQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if query.Boost() != 1.2 {
+		t.Fatalf("expected 1.2, but %v\n", query.Boost())
+	}
+
+	if _, err := NewConstantScoreQueryWithMap(map[string]interface{}{}); err == nil {
+		t.Fatalf("expected error without filter\n")
+	}
+}
+
+func TestConstantScoreQuery(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	for id, category := range map[string]string{"1": "search", "2": "search search", "3": "database"} {
+		doc := bluge.NewDocument(id)
+		doc.AddField(bluge.NewTextField("category", category))
+		doc.AddField(bluge.NewTextField("title", "hello"))
+		if err := writer.Update(doc.ID(), doc); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	filter := bluge.NewTermQuery("search").SetField("category")
+
+	// All the matching documents have the same score.
+	docMatchIter, err := reader.Search(context.Background(), bluge.NewTopNSearch(10, NewConstantScoreQuery(filter).SetBoost(1.2)))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	count := 0
+	docMatch, err := docMatchIter.Next()
+	for err == nil && docMatch != nil {
+		if docMatch.Score != 1.2 {
+			t.Fatalf("expected 1.2, but %v\n", docMatch.Score)
+		}
+		count++
+		docMatch, err = docMatchIter.Next()
+	}
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 documents, but %v\n", count)
+	}
+
+	// Filter clauses narrow the documents without changing the scores.
+	scores := make([]float64, 0)
+	for _, opts := range []BooleanQueryOptions{
+		{
+			Must:  []QuerySetting{{Type: "term", Options: map[string]interface{}{"term": "hello", "field": "title"}}},
+			Boost: 1.0,
+		},
+		{
+			Must:   []QuerySetting{{Type: "term", Options: map[string]interface{}{"term": "hello", "field": "title"}}},
+			Filter: []QuerySetting{{Type: "term", Options: map[string]interface{}{"term": "search", "field": "category"}}},
+			Boost:  1.0,
+		},
+	} {
+		query, err := NewBooleanQueryWithOptions(opts)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		docMatchIter, err := reader.Search(context.Background(), bluge.NewTopNSearch(10, query).WithStandardAggregations())
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		docMatch, err := docMatchIter.Next()
+		if err != nil || docMatch == nil {
+			t.Fatalf("no documents match: %v\n", err)
+		}
+		scores = append(scores, docMatch.Score)
+		if len(scores) == 2 && docMatchIter.Aggregations().Count() != 2 {
+			t.Fatalf("expected 2 documents, but %v\n", docMatchIter.Aggregations().Count())
+		}
+	}
+	if math.Abs(scores[0]-scores[1]) > 1e-9 {
+		t.Fatalf("expected %v, but %v\n", scores[0], scores[1])
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/blugelabs/bluge"
+	"github.com/mosuka/phalanx/mapping"
 )
 
 var (
@@ -14,12 +15,13 @@ var (
 )
 
 type DateRangeQueryOptions struct {
-	Start          string  `json:"start"`
-	End            string  `json:"end"`
-	InclusiveStart bool    `json:"inclusive_start"`
-	InclusiveEnd   bool    `json:"inclusive_end"`
-	Field          string  `json:"field"`
-	Boost          float64 `json:"boost"`
+	Start          string   `json:"start"`
+	End            string   `json:"end"`
+	InclusiveStart bool     `json:"inclusive_start"`
+	InclusiveEnd   bool     `json:"inclusive_end"`
+	Field          string   `json:"field"`
+	Formats        []string `json:"formats"`
+	Boost          float64  `json:"boost"`
 }
 
 func NewDateRangeQueryOptions() DateRangeQueryOptions {
@@ -39,8 +41,11 @@ func NewDateRangeQueryOptions() DateRangeQueryOptions {
 //   "inclusive_start": true,
 //   "inclusive_end": false,
 //   "field": "description",
+//   "formats": ["2006-01-02 15:04:05", "epoch_millis"],
 //   "boost": 1.0
 // }
+// If formats are omitted, the formats of the field mapping are used.
+// RFC 3339 is always accepted.
 func NewDateRangeQueryWithMap(opts map[string]interface{}) (*bluge.DateRangeQuery, error) {
 	bytes, err := json.Marshal(opts)
 	if err != nil {
@@ -57,10 +62,15 @@ func NewDateRangeQueryWithMap(opts map[string]interface{}) (*bluge.DateRangeQuer
 }
 
 func NewDateRangeQueryWithOptions(opts DateRangeQueryOptions) (*bluge.DateRangeQuery, error) {
+	if err := mapping.ValidateDateTimeFormats(opts.Formats); err != nil {
+		return nil, fmt.Errorf("formats option is unexpected: %v", err)
+	}
+	formats := append(append([]string{}, opts.Formats...), time.RFC3339)
+
 	var start time.Time
 	if opts.Start != "" {
 		var err error
-		start, err = time.Parse(time.RFC3339, opts.Start)
+		start, err = mapping.MakeDateTimeWithFormats(opts.Start, formats)
 		if err != nil {
 			return nil, fmt.Errorf("start option is unexpected: %v", opts.Start)
 		}
@@ -69,7 +79,7 @@ func NewDateRangeQueryWithOptions(opts DateRangeQueryOptions) (*bluge.DateRangeQ
 	var end time.Time
 	if opts.End != "" {
 		var err error
-		end, err = time.Parse(time.RFC3339, opts.End)
+		end, err = mapping.MakeDateTimeWithFormats(opts.End, formats)
 		if err != nil {
 			return nil, fmt.Errorf("end option is unexpected: %v", opts.End)
 		}
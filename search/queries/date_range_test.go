@@ -23,3 +23,34 @@ func TestNewDateRangeQueryWithMap(t *testing.T) {
 		t.Fatalf("%v\n", err)
 	}
 }
+
+func TestNewDateRangeQueryWithFormats(t *testing.T) {
+	queryFile := "../../testdata/test_date_range_query_with_formats.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = NewDateRangeQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// RFC3339 is always accepted.
+	opts["start"] = "2022-01-01T00:00:00Z"
+	_, err = NewDateRangeQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	opts["start"] = "2022/01/01"
+	_, err = NewDateRangeQueryWithMap(opts)
+	if err == nil {
+		t.Fatalf("expected an error for a start without a matching format\n")
+	}
+}
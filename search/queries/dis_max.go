@@ -0,0 +1,175 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+	"github.com/blugelabs/bluge/search/searcher"
+)
+
+type DisMaxQueryOptions struct {
+	Queries    []QuerySetting `json:"queries"`
+	TieBreaker float64        `json:"tie_breaker"`
+	Boost      float64        `json:"boost"`
+}
+
+func NewDisMaxQueryOptions() DisMaxQueryOptions {
+	return DisMaxQueryOptions{
+		Boost: 1.0,
+	}
+}
+
+// Create new DisMaxQuery with given options.
+// Options example:
+// {
+//   "queries": [
+//     {
+//       "type": "match",
+//       "options": {
+//         "match": "search engine",
+//         "field": "name"
+//       }
+//     },
+//     {
+//       "type": "match",
+//       "options": {
+//         "match": "search engine",
+//         "field": "description"
+//       }
+//     }
+//   ],
+//   "tie_breaker": 0.3,
+//   "boost": 1.0
+// }
+func NewDisMaxQueryWithMap(opts map[string]interface{}) (*DisMaxQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewDisMaxQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewDisMaxQueryWithOptions(options)
+}
+
+func NewDisMaxQueryWithOptions(opts DisMaxQueryOptions) (*DisMaxQuery, error) {
+	if opts.TieBreaker < 0.0 || opts.TieBreaker > 1.0 {
+		return nil, fmt.Errorf("tie_breaker option is unexpected: %v", opts.TieBreaker)
+	}
+
+	disMaxQuery := NewDisMaxQuery().SetTieBreaker(opts.TieBreaker)
+
+	for i, querySetting := range opts.Queries {
+		query, err := NewQuery(querySetting.Type, querySetting.Options)
+		if err != nil {
+			return nil, fmt.Errorf("queries[%d] option is unexpected: %v", i, err)
+		}
+		disMaxQuery.AddQuery(query)
+	}
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		disMaxQuery.SetBoost(opts.Boost)
+	}
+
+	return disMaxQuery, nil
+}
+
+// DisMaxQuery matches the documents matching any of the queries.
+// The score is the maximum score of the matching queries,
+// plus the tie breaker multiplied by the scores of the other matching queries.
+type DisMaxQuery struct {
+	queries    []bluge.Query
+	tieBreaker float64
+	boost      float64
+}
+
+func NewDisMaxQuery(queries ...bluge.Query) *DisMaxQuery {
+	return &DisMaxQuery{
+		queries: queries,
+		boost:   1.0,
+	}
+}
+
+func (q *DisMaxQuery) AddQuery(query bluge.Query) *DisMaxQuery {
+	q.queries = append(q.queries, query)
+	return q
+}
+
+func (q *DisMaxQuery) SetTieBreaker(tieBreaker float64) *DisMaxQuery {
+	q.tieBreaker = tieBreaker
+	return q
+}
+
+func (q *DisMaxQuery) SetBoost(b float64) *DisMaxQuery {
+	q.boost = b
+	return q
+}
+
+func (q *DisMaxQuery) Queries() []bluge.Query {
+	return q.queries
+}
+
+func (q *DisMaxQuery) TieBreaker() float64 {
+	return q.tieBreaker
+}
+
+func (q *DisMaxQuery) Boost() float64 {
+	return q.boost
+}
+
+func (q *DisMaxQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	searchers := make([]search.Searcher, 0, len(q.queries))
+	for _, query := range q.queries {
+		s, err := query.Searcher(i, options)
+		if err != nil {
+			for _, searcher := range searchers {
+				_ = searcher.Close()
+			}
+			return nil, err
+		}
+		searchers = append(searchers, s)
+	}
+
+	if len(searchers) == 0 {
+		return searcher.NewMatchNoneSearcher(i, options)
+	}
+
+	return searcher.NewDisjunctionSearcher(i, searchers, 0, &disMaxScorer{
+		tieBreaker: q.tieBreaker,
+		boost:      q.boost,
+	}, options)
+}
+
+type disMaxScorer struct {
+	tieBreaker float64
+	boost      float64
+}
+
+func (s *disMaxScorer) ScoreComposite(constituents []*search.DocumentMatch) float64 {
+	var max, sum float64
+	for _, constituent := range constituents {
+		if constituent.Score > max {
+			max = constituent.Score
+		}
+		sum += constituent.Score
+	}
+
+	return (max + s.tieBreaker*(sum-max)) * s.boost
+}
+
+func (s *disMaxScorer) ExplainComposite(constituents []*search.DocumentMatch) *search.Explanation {
+	children := make([]*search.Explanation, 0, len(constituents))
+	for _, constituent := range constituents {
+		children = append(children, constituent.Explanation)
+	}
+
+	return search.NewExplanation(s.ScoreComposite(constituents),
+		fmt.Sprintf("max plus %v times others, times boost %v, of:", s.tieBreaker, s.boost),
+		children...)
+}
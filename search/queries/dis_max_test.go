@@ -0,0 +1,75 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+)
+
+func TestNewDisMaxQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_dis_max_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewDisMaxQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(query.Queries()) != 2 {
+		t.Fatalf("expected 2 queries, but %v\n", len(query.Queries()))
+	}
+}
+
+func TestDisMaxQuery(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	doc := bluge.NewDocument("1")
+	doc.AddField(bluge.NewTextField("title", "hello"))
+	doc.AddField(bluge.NewTextField("body", "hello"))
+	if err := writer.Update(doc.ID(), doc); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	titleQuery := bluge.NewTermQuery("hello").SetField("title").SetBoost(2.0)
+	bodyQuery := bluge.NewTermQuery("hello").SetField("body")
+
+	scores := make([]float64, 0)
+	for _, query := range []bluge.Query{titleQuery, bodyQuery, NewDisMaxQuery(titleQuery, bodyQuery).SetTieBreaker(0.5)} {
+		docMatchIter, err := reader.Search(context.Background(), bluge.NewTopNSearch(1, query))
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		docMatch, err := docMatchIter.Next()
+		if err != nil || docMatch == nil {
+			t.Fatalf("document 1 does not match: %v\n", err)
+		}
+		scores = append(scores, docMatch.Score)
+	}
+
+	// The maximum score plus the tie breaker multiplied by the other score.
+	expected := scores[0] + 0.5*scores[1]
+	if math.Abs(scores[2]-expected) > 1e-9 {
+		t.Fatalf("expected %v, but %v\n", expected, scores[2])
+	}
+}
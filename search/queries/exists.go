@@ -0,0 +1,89 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+type ExistsQueryOptions struct {
+	Field string  `json:"field"`
+	Boost float64 `json:"boost"`
+}
+
+func NewExistsQueryOptions() ExistsQueryOptions {
+	return ExistsQueryOptions{
+		Boost: 1.0,
+	}
+}
+
+// Create new ExistsQuery with given options.
+// Options example:
+// {
+//   "field": "thumbnail",
+//   "boost": 1.0
+// }
+func NewExistsQueryWithMap(opts map[string]interface{}) (*ExistsQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewExistsQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewExistsQueryWithOptions(options)
+}
+
+func NewExistsQueryWithOptions(opts ExistsQueryOptions) (*ExistsQuery, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	existsQuery := NewExistsQuery(opts.Field)
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		existsQuery.SetBoost(opts.Boost)
+	}
+
+	return existsQuery, nil
+}
+
+// ExistsQuery matches the documents that have a value in the field.
+// All the matching documents have the same score, the boost.
+// The documents are found by a single term of the exists field, which is written when the documents are indexed.
+type ExistsQuery struct {
+	field string
+	boost float64
+}
+
+func NewExistsQuery(field string) *ExistsQuery {
+	return &ExistsQuery{
+		field: field,
+		boost: 1.0,
+	}
+}
+
+func (q *ExistsQuery) SetBoost(b float64) *ExistsQuery {
+	q.boost = b
+	return q
+}
+
+func (q *ExistsQuery) Field() string {
+	return q.field
+}
+
+func (q *ExistsQuery) Boost() float64 {
+	return q.boost
+}
+
+func (q *ExistsQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	// The names of the fields that have values are indexed in the exists field of each document.
+	return NewConstantScoreQuery(bluge.NewTermQuery(q.field).SetField(mapping.ExistsFieldName)).SetBoost(q.boost).Searcher(i, options)
+}
@@ -0,0 +1,83 @@
+package queries
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+func TestNewExistsQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_exists_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewExistsQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if query.Field() != "thumbnail" {
+		t.Fatalf("expected thumbnail, but %v\n", query.Field())
+	}
+
+	if _, err := NewExistsQueryWithMap(map[string]interface{}{}); err == nil {
+		t.Fatalf("expected error without field\n")
+	}
+}
+
+func TestExistsQuery(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	doc := bluge.NewDocument("1")
+	doc.AddField(bluge.NewTextField("thumbnail", "thumbnail.png"))
+	doc.AddField(mapping.MakeExistsField("thumbnail"))
+	doc.AddField(bluge.NewNumericField("price", 100))
+	doc.AddField(mapping.MakeExistsField("price"))
+	if err := writer.Update(doc.ID(), doc); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	doc = bluge.NewDocument("2")
+	doc.AddField(mapping.MakeDenseVectorField("vector", []float32{0.1, 0.2}))
+	doc.AddField(mapping.MakeExistsField("vector"))
+	if err := writer.Update(doc.ID(), doc); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	doc = bluge.NewDocument("3")
+	doc.AddField(bluge.NewNumericField("price", 200))
+	doc.AddField(mapping.MakeExistsField("price"))
+	if err := writer.Update(doc.ID(), doc); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	for field, expected := range map[string][]string{
+		"thumbnail": {"1"},
+		"price":     {"1", "3"},
+		"vector":    {"2"},
+		"unknown":   {},
+	} {
+		ids := searchIds(t, reader, NewExistsQuery(field))
+		if !reflect.DeepEqual(ids, expected) {
+			t.Fatalf("%s: expected %v, but %v\n", field, expected, ids)
+		}
+	}
+}
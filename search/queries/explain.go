@@ -0,0 +1,157 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/blugelabs/bluge/search"
+	"github.com/blugelabs/bluge/search/searcher"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+// ExplainQuery matches the document with the given ID only if it matches the query.
+// The document is scored by the query as it is in the search results, so that the score can be explained.
+type ExplainQuery struct {
+	query bluge.Query
+	id    string
+}
+
+func NewExplainQuery(query bluge.Query, id string) *ExplainQuery {
+	return &ExplainQuery{
+		query: query,
+		id:    id,
+	}
+}
+
+func (q *ExplainQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	// Find the document numbers of the ID.
+	numbers := make(map[uint64]bool)
+	if err := visitMatchingNumbers(i, bluge.NewTermQuery(q.id).SetField(mapping.IdFieldName), options, func(number uint64) error {
+		numbers[number] = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	querySearcher, err := q.query.Searcher(i, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return searcher.NewFilteringSearcher(querySearcher, func(dm *search.DocumentMatch) bool {
+		return numbers[dm.Number]
+	}), nil
+}
+
+// ExplainNoMatch explains why the document with the given ID does not match the query.
+// The explanation is the tree of the query scored 0, in which the clauses of the boolean queries
+// that reject the document are explained down to the queries that do not match it.
+// The queries are described in the form of RewriteQuery.
+func ExplainNoMatch(ctx context.Context, reader *bluge.Reader, query bluge.Query, id string, defaultField string, defaultAnalyzer *analysis.Analyzer) (*search.Explanation, error) {
+	e := &noMatchExplainer{
+		ctx:    ctx,
+		reader: reader,
+		id:     id,
+		rewriter: &queryRewriter{
+			defaultField:    defaultField,
+			defaultAnalyzer: defaultAnalyzer,
+		},
+	}
+
+	return e.explain(query)
+}
+
+type noMatchExplainer struct {
+	ctx      context.Context
+	reader   *bluge.Reader
+	id       string
+	rewriter *queryRewriter
+}
+
+// matches reports whether the document matches the query.
+func (e *noMatchExplainer) matches(query bluge.Query) (bool, error) {
+	docMatchIter, err := e.reader.Search(e.ctx, bluge.NewTopNSearch(1, NewExplainQuery(query, e.id)))
+	if err != nil {
+		return false, err
+	}
+	docMatch, err := docMatchIter.Next()
+	if err != nil {
+		return false, err
+	}
+
+	return docMatch != nil, nil
+}
+
+// explain explains the query that does not match the document.
+func (e *noMatchExplainer) explain(query bluge.Query) (*search.Explanation, error) {
+	message := fmt.Sprintf("%s does not match", e.rewriter.rewrite(query))
+
+	switch q := query.(type) {
+	case *bluge.BooleanQuery:
+		children := make([]*search.Explanation, 0)
+		for _, subQuery := range q.Musts() {
+			matched, err := e.matches(subQuery)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				continue
+			}
+			child, err := e.explain(subQuery)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, search.NewExplanation(0.0, "must clause does not match", child))
+		}
+		for _, subQuery := range q.MustNots() {
+			matched, err := e.matches(subQuery)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+			children = append(children, search.NewExplanation(0.0, fmt.Sprintf("must_not clause matches: %s", e.rewriter.rewrite(subQuery))))
+		}
+
+		// The should clauses are required if there are no must clauses, or the minimum is specified.
+		minShould := q.MinShould()
+		if minShould == 0 && len(q.Musts()) == 0 && len(q.Shoulds()) > 0 {
+			minShould = 1
+		}
+		if minShould > 0 {
+			matchedCount := 0
+			shouldChildren := make([]*search.Explanation, 0)
+			for _, subQuery := range q.Shoulds() {
+				matched, err := e.matches(subQuery)
+				if err != nil {
+					return nil, err
+				}
+				if matched {
+					matchedCount++
+					continue
+				}
+				child, err := e.explain(subQuery)
+				if err != nil {
+					return nil, err
+				}
+				shouldChildren = append(shouldChildren, child)
+			}
+			if matchedCount < minShould {
+				children = append(children, search.NewExplanation(0.0, fmt.Sprintf("%d of the should clauses match, but %d are required", matchedCount, minShould), shouldChildren...))
+			}
+		}
+
+		return search.NewExplanation(0.0, message, children...), nil
+	case *ConstantScoreQuery:
+		child, err := e.explain(q.Filter())
+		if err != nil {
+			return nil, err
+		}
+		return search.NewExplanation(0.0, message, child), nil
+	default:
+		return search.NewExplanation(0.0, message), nil
+	}
+}
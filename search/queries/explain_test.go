@@ -0,0 +1,131 @@
+package queries
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+)
+
+func TestExplainQuery(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	texts := map[string]string{
+		"1": "hello world",
+		"2": "hello phalanx",
+	}
+	batch := bluge.NewBatch()
+	for id, text := range texts {
+		doc := bluge.NewDocument(id)
+		doc.AddField(bluge.NewTextField("text", text))
+		batch.Update(doc.ID(), doc)
+	}
+	if err := writer.Batch(batch); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	query := bluge.NewTermQuery("world").SetField("text")
+
+	request := bluge.NewTopNSearch(1, NewExplainQuery(query, "1")).ExplainScores()
+	docMatchIter, err := reader.Search(context.Background(), request)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	docMatch, err := docMatchIter.Next()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if docMatch == nil {
+		t.Fatalf("document 1 does not match\n")
+	}
+	if docMatch.Explanation == nil || docMatch.Explanation.Value != docMatch.Score {
+		t.Fatalf("`%v` is not explained\n", docMatch.Score)
+	}
+
+	request = bluge.NewTopNSearch(1, NewExplainQuery(query, "2")).ExplainScores()
+	docMatchIter, err = reader.Search(context.Background(), request)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	docMatch, err = docMatchIter.Next()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if docMatch != nil {
+		t.Fatalf("document 2 matches\n")
+	}
+}
+
+func TestExplainNoMatch(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	doc := bluge.NewDocument("1")
+	doc.AddField(bluge.NewTextField("text", "hello world"))
+	doc.AddField(bluge.NewKeywordField("tag", "greeting"))
+	if err := writer.Update(doc.ID(), doc); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	query := bluge.NewBooleanQuery().
+		AddMust(bluge.NewTermQuery("hello").SetField("text")).
+		AddMust(bluge.NewTermQuery("phalanx").SetField("text")).
+		AddMustNot(bluge.NewTermQuery("greeting").SetField("tag"))
+
+	explanation, err := ExplainNoMatch(context.Background(), reader, query, "1", "_all", nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if explanation.Value != 0.0 {
+		t.Fatalf("expected 0.0, but %v\n", explanation.Value)
+	}
+	expected := "(+text:hello +text:phalanx -tag:greeting) does not match"
+	if explanation.Message != expected {
+		t.Fatalf("`%v` is not `%v`\n", explanation.Message, expected)
+	}
+
+	// Only the clauses that reject the document are explained.
+	messages := make([]string, 0)
+	for _, child := range explanation.Children {
+		messages = append(messages, child.Message)
+	}
+	expectedMessages := []string{"must clause does not match", "must_not clause matches: tag:greeting"}
+	if !reflect.DeepEqual(messages, expectedMessages) {
+		t.Fatalf("`%v` is not `%v`\n", messages, expectedMessages)
+	}
+	if explanation.Children[0].Children[0].Message != "text:phalanx does not match" {
+		t.Fatalf("unexpected explanation: %v\n", explanation.Children[0].Children[0].Message)
+	}
+
+	// The should clauses are required without must clauses.
+	query = bluge.NewBooleanQuery().
+		AddShould(bluge.NewTermQuery("phalanx").SetField("text")).
+		AddShould(bluge.NewTermQuery("bluge").SetField("text"))
+	explanation, err = ExplainNoMatch(context.Background(), reader, query, "1", "_all", nil)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(explanation.Children) != 1 || explanation.Children[0].Message != "0 of the should clauses match, but 1 are required" || len(explanation.Children[0].Children) != 2 {
+		t.Fatalf("unexpected explanation: %v\n", explanation)
+	}
+}
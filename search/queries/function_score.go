@@ -0,0 +1,918 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/numeric/geo"
+	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+type ScoreMode int
+
+const (
+	ScoreModeUnknown ScoreMode = iota
+	ScoreModeMultiply
+	ScoreModeSum
+	ScoreModeAvg
+	ScoreModeFirst
+	ScoreModeMax
+	ScoreModeMin
+)
+
+// Maps for ScoreMode.
+var (
+	ScoreMode_name = map[ScoreMode]string{
+		ScoreModeUnknown:  "unknown",
+		ScoreModeMultiply: "multiply",
+		ScoreModeSum:      "sum",
+		ScoreModeAvg:      "avg",
+		ScoreModeFirst:    "first",
+		ScoreModeMax:      "max",
+		ScoreModeMin:      "min",
+	}
+	ScoreMode_value = map[string]ScoreMode{
+		"unknown":  ScoreModeUnknown,
+		"multiply": ScoreModeMultiply,
+		"sum":      ScoreModeSum,
+		"avg":      ScoreModeAvg,
+		"first":    ScoreModeFirst,
+		"max":      ScoreModeMax,
+		"min":      ScoreModeMin,
+	}
+)
+
+type BoostMode int
+
+const (
+	BoostModeUnknown BoostMode = iota
+	BoostModeMultiply
+	BoostModeReplace
+	BoostModeSum
+	BoostModeAvg
+	BoostModeMax
+	BoostModeMin
+)
+
+// Maps for BoostMode.
+var (
+	BoostMode_name = map[BoostMode]string{
+		BoostModeUnknown:  "unknown",
+		BoostModeMultiply: "multiply",
+		BoostModeReplace:  "replace",
+		BoostModeSum:      "sum",
+		BoostModeAvg:      "avg",
+		BoostModeMax:      "max",
+		BoostModeMin:      "min",
+	}
+	BoostMode_value = map[string]BoostMode{
+		"unknown":  BoostModeUnknown,
+		"multiply": BoostModeMultiply,
+		"replace":  BoostModeReplace,
+		"sum":      BoostModeSum,
+		"avg":      BoostModeAvg,
+		"max":      BoostModeMax,
+		"min":      BoostModeMin,
+	}
+)
+
+type FieldValueFactorOptions struct {
+	Field    string   `json:"field"`
+	Factor   float64  `json:"factor"`
+	Modifier string   `json:"modifier"`
+	Missing  *float64 `json:"missing"`
+}
+
+type DecayFunctionOptions struct {
+	Field   string      `json:"field"`
+	Origin  interface{} `json:"origin"`
+	Scale   interface{} `json:"scale"`
+	Offset  interface{} `json:"offset"`
+	Decay   float64     `json:"decay"`
+	Formats []string    `json:"formats"`
+}
+
+type ScoreFunctionOptions struct {
+	Filter           *QuerySetting            `json:"filter"`
+	Weight           *float64                 `json:"weight"`
+	FieldValueFactor *FieldValueFactorOptions `json:"field_value_factor"`
+	Gauss            *DecayFunctionOptions    `json:"gauss"`
+	Exp              *DecayFunctionOptions    `json:"exp"`
+	Linear           *DecayFunctionOptions    `json:"linear"`
+}
+
+type FunctionScoreQueryOptions struct {
+	Query     *QuerySetting          `json:"query"`
+	Functions []ScoreFunctionOptions `json:"functions"`
+	ScoreMode string                 `json:"score_mode"`
+	BoostMode string                 `json:"boost_mode"`
+	MaxBoost  float64                `json:"max_boost"`
+	Boost     float64                `json:"boost"`
+}
+
+func NewFunctionScoreQueryOptions() FunctionScoreQueryOptions {
+	return FunctionScoreQueryOptions{
+		ScoreMode: ScoreMode_name[ScoreModeMultiply],
+		BoostMode: BoostMode_name[BoostModeMultiply],
+		MaxBoost:  math.MaxFloat64,
+		Boost:     1.0,
+	}
+}
+
+// Create new FunctionScoreQuery with given options.
+// Options example:
+// {
+//   "query": {
+//     "type": "match",
+//     "options": {
+//       "match": "search engine",
+//       "field": "description"
+//     }
+//   },
+//   "functions": [
+//     {
+//       "filter": {
+//         "type": "term",
+//         "options": {
+//           "term": "search",
+//           "field": "category"
+//         }
+//       },
+//       "weight": 2.0
+//     },
+//     {
+//       "field_value_factor": {
+//         "field": "popularity",
+//         "factor": 1.2,
+//         "modifier": "log1p",
+//         "missing": 1.0
+//       }
+//     },
+//     {
+//       "gauss": {
+//         "field": "publish_date",
+//         "origin": "2021-06-01T00:00:00Z",
+//         "scale": "30d",
+//         "offset": "7d",
+//         "decay": 0.5
+//       }
+//     },
+//     {
+//       "exp": {
+//         "field": "location",
+//         "origin": {
+//           "lon": -122.107799,
+//           "lat": 37.399285
+//         },
+//         "scale": "10km"
+//       }
+//     }
+//   ],
+//   "score_mode": "multiply",
+//   "boost_mode": "multiply",
+//   "max_boost": 10.0,
+//   "boost": 1.0
+// }
+func NewFunctionScoreQueryWithMap(opts map[string]interface{}) (*FunctionScoreQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewFunctionScoreQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewFunctionScoreQueryWithOptions(options)
+}
+
+func NewFunctionScoreQueryWithOptions(opts FunctionScoreQueryOptions) (*FunctionScoreQuery, error) {
+	// query is optional.
+	var query bluge.Query = bluge.NewMatchAllQuery()
+	if opts.Query != nil {
+		var err error
+		query, err = NewQuery(opts.Query.Type, opts.Query.Options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scoreMode, ok := ScoreMode_value[opts.ScoreMode]
+	if !ok || scoreMode == ScoreModeUnknown {
+		return nil, fmt.Errorf("score_mode option is unexpected: %v", opts.ScoreMode)
+	}
+
+	boostMode, ok := BoostMode_value[opts.BoostMode]
+	if !ok || boostMode == BoostModeUnknown {
+		return nil, fmt.Errorf("boost_mode option is unexpected: %v", opts.BoostMode)
+	}
+
+	functionScoreQuery := NewFunctionScoreQuery(query).SetScoreMode(scoreMode).SetBoostMode(boostMode).SetMaxBoost(opts.MaxBoost)
+
+	for i, functionOpts := range opts.Functions {
+		function, err := newScoreFunction(functionOpts)
+		if err != nil {
+			return nil, fmt.Errorf("functions[%d] option is unexpected: %v", i, err)
+		}
+		functionScoreQuery.AddFunction(function)
+	}
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		functionScoreQuery.SetBoost(opts.Boost)
+	}
+
+	return functionScoreQuery, nil
+}
+
+func newScoreFunction(opts ScoreFunctionOptions) (*ScoreFunction, error) {
+	function := &ScoreFunction{
+		weight: 1.0,
+	}
+
+	// filter is optional.
+	if opts.Filter != nil {
+		filter, err := NewQuery(opts.Filter.Type, opts.Filter.Options)
+		if err != nil {
+			return nil, err
+		}
+		function.filter = filter
+	}
+
+	// weight is optional.
+	if opts.Weight != nil {
+		function.weight = *opts.Weight
+	}
+
+	numFunctions := 0
+	if opts.FieldValueFactor != nil {
+		valueFunction, err := newFieldValueFactorFunction(*opts.FieldValueFactor)
+		if err != nil {
+			return nil, err
+		}
+		function.valueFunction = valueFunction
+		numFunctions++
+	}
+	for curve, decayOpts := range []*DecayFunctionOptions{
+		DecayCurveGauss:  opts.Gauss,
+		DecayCurveExp:    opts.Exp,
+		DecayCurveLinear: opts.Linear,
+	} {
+		if decayOpts == nil {
+			continue
+		}
+		valueFunction, err := newDecayFunction(DecayCurve(curve), *decayOpts)
+		if err != nil {
+			return nil, err
+		}
+		function.valueFunction = valueFunction
+		numFunctions++
+	}
+
+	if numFunctions > 1 {
+		return nil, fmt.Errorf("only one of field_value_factor, gauss, exp and linear can be specified")
+	}
+	if numFunctions == 0 && opts.Weight == nil {
+		return nil, fmt.Errorf("function does not exist")
+	}
+
+	return function, nil
+}
+
+// valueFunction computes a score from the stored value of a field.
+// The value is nil if the document does not have the field.
+type valueFunction interface {
+	Field() string
+	FieldType() mapping.FieldType
+	Score(value []byte) (float64, error)
+	String() string
+}
+
+// ScoreFunction computes a score for the documents matching the filter.
+// The score is the weight multiplied by the value function, or the weight alone without a value function.
+type ScoreFunction struct {
+	filter        bluge.Query
+	weight        float64
+	valueFunction valueFunction
+}
+
+func (f *ScoreFunction) Filter() bluge.Query {
+	return f.filter
+}
+
+func (f *ScoreFunction) Weight() float64 {
+	return f.weight
+}
+
+// Field returns the field that the function reads, and its expected type.
+// The field is empty if the function does not read any field.
+func (f *ScoreFunction) Field() (string, mapping.FieldType) {
+	if f.valueFunction == nil {
+		return "", ""
+	}
+	return f.valueFunction.Field(), f.valueFunction.FieldType()
+}
+
+func (f *ScoreFunction) String() string {
+	if f.valueFunction == nil {
+		return fmt.Sprintf("weight(%v)", f.weight)
+	}
+	if f.weight == 1.0 {
+		return f.valueFunction.String()
+	}
+	return fmt.Sprintf("%s * weight(%v)", f.valueFunction.String(), f.weight)
+}
+
+func (f *ScoreFunction) score(values map[string][]byte) (float64, *search.Explanation, error) {
+	if f.valueFunction == nil {
+		return f.weight, search.NewExplanation(f.weight, "weight"), nil
+	}
+
+	value, err := f.valueFunction.Score(values[f.valueFunction.Field()])
+	if err != nil {
+		return 0, nil, err
+	}
+	score := value * f.weight
+
+	return score, search.NewExplanation(score, fmt.Sprintf("%s, product of:", f.String()),
+		search.NewExplanation(value, f.valueFunction.String()),
+		search.NewExplanation(f.weight, "weight"),
+	), nil
+}
+
+// FieldValueFactorModifier is applied to the field value multiplied by the factor.
+type FieldValueFactorModifier func(float64) float64
+
+var FieldValueFactorModifiers = map[string]FieldValueFactorModifier{
+	"none":       func(v float64) float64 { return v },
+	"log":        func(v float64) float64 { return math.Log10(v) },
+	"log1p":      func(v float64) float64 { return math.Log10(v + 1) },
+	"log2p":      func(v float64) float64 { return math.Log10(v + 2) },
+	"ln":         func(v float64) float64 { return math.Log(v) },
+	"ln1p":       func(v float64) float64 { return math.Log1p(v) },
+	"ln2p":       func(v float64) float64 { return math.Log(v + 2) },
+	"square":     func(v float64) float64 { return v * v },
+	"sqrt":       func(v float64) float64 { return math.Sqrt(v) },
+	"reciprocal": func(v float64) float64 { return 1 / v },
+}
+
+type fieldValueFactorFunction struct {
+	field        string
+	factor       float64
+	modifierName string
+	modifier     FieldValueFactorModifier
+	missing      *float64
+}
+
+func newFieldValueFactorFunction(opts FieldValueFactorOptions) (*fieldValueFactorFunction, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	factor := opts.Factor
+	if factor == 0.0 {
+		factor = 1.0
+	}
+
+	modifierName := opts.Modifier
+	if modifierName == "" {
+		modifierName = "none"
+	}
+	modifier, ok := FieldValueFactorModifiers[modifierName]
+	if !ok {
+		return nil, fmt.Errorf("modifier option is unexpected: %v", opts.Modifier)
+	}
+
+	return &fieldValueFactorFunction{
+		field:        opts.Field,
+		factor:       factor,
+		modifierName: modifierName,
+		modifier:     modifier,
+		missing:      opts.Missing,
+	}, nil
+}
+
+func (f *fieldValueFactorFunction) Field() string {
+	return f.field
+}
+
+func (f *fieldValueFactorFunction) FieldType() mapping.FieldType {
+	return mapping.NumericField
+}
+
+func (f *fieldValueFactorFunction) Score(value []byte) (float64, error) {
+	var fieldValue float64
+	switch {
+	case value != nil:
+		var err error
+		fieldValue, err = bluge.DecodeNumericFloat64(value)
+		if err != nil {
+			return 0, err
+		}
+	case f.missing != nil:
+		fieldValue = *f.missing
+	default:
+		return 0, fmt.Errorf("document does not have the %s field, and the missing value is not specified", f.field)
+	}
+
+	score := f.modifier(fieldValue * f.factor)
+	// Scores must be finite and non-negative.
+	if math.IsNaN(score) || math.IsInf(score, 0) || score < 0 {
+		return 0, fmt.Errorf("field_value_factor of the %s field is not a non-negative finite number: %v", f.field, score)
+	}
+
+	return score, nil
+}
+
+func (f *fieldValueFactorFunction) String() string {
+	return fmt.Sprintf("field_value_factor(%s(%s * %v))", f.modifierName, f.field, f.factor)
+}
+
+type DecayCurve int
+
+const (
+	DecayCurveUnknown DecayCurve = iota
+	DecayCurveGauss
+	DecayCurveExp
+	DecayCurveLinear
+)
+
+// Maps for DecayCurve.
+var (
+	DecayCurve_name = map[DecayCurve]string{
+		DecayCurveUnknown: "unknown",
+		DecayCurveGauss:   "gauss",
+		DecayCurveExp:     "exp",
+		DecayCurveLinear:  "linear",
+	}
+	DecayCurve_value = map[string]DecayCurve{
+		"unknown": DecayCurveUnknown,
+		"gauss":   DecayCurveGauss,
+		"exp":     DecayCurveExp,
+		"linear":  DecayCurveLinear,
+	}
+)
+
+const DefaultDecay = 0.5
+
+// decayFunction scores the documents by the distance of the field value from the origin.
+// Documents within the offset from the origin get the full score 1.0,
+// and documents at the scale beyond the offset get the decay.
+// Documents without the field get the full score.
+// Distances are numbers for numeric fields, seconds for datetime fields and meters for geo_point fields.
+type decayFunction struct {
+	curve     DecayCurve
+	field     string
+	fieldType mapping.FieldType
+	origin    interface{}
+	scale     float64
+	offset    float64
+	decay     float64
+}
+
+func newDecayFunction(curve DecayCurve, opts DecayFunctionOptions) (*decayFunction, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	decay := opts.Decay
+	if decay == 0.0 {
+		decay = DefaultDecay
+	}
+	if decay <= 0.0 || decay >= 1.0 {
+		return nil, fmt.Errorf("decay option is unexpected: %v", opts.Decay)
+	}
+
+	function := &decayFunction{
+		curve: curve,
+		field: opts.Field,
+		decay: decay,
+	}
+
+	// The field type is inferred from the origin.
+	var err error
+	switch origin := opts.Origin.(type) {
+	case float64:
+		function.fieldType = mapping.NumericField
+		function.origin = origin
+		if function.scale, err = parseNumericDistance(opts.Scale); err != nil {
+			return nil, fmt.Errorf("scale option is unexpected: %v", opts.Scale)
+		}
+		if function.offset, err = parseNumericDistance(opts.Offset); err != nil {
+			return nil, fmt.Errorf("offset option is unexpected: %v", opts.Offset)
+		}
+	case string:
+		function.fieldType = mapping.DatetimeField
+		if err := mapping.ValidateDateTimeFormats(opts.Formats); err != nil {
+			return nil, fmt.Errorf("formats option is unexpected: %v", err)
+		}
+		formats := append(append([]string{}, opts.Formats...), time.RFC3339)
+		if function.origin, err = mapping.MakeDateTimeWithFormats(origin, formats); err != nil {
+			return nil, fmt.Errorf("origin option is unexpected: %v", opts.Origin)
+		}
+		if function.scale, err = parseDurationDistance(opts.Scale); err != nil {
+			return nil, fmt.Errorf("scale option is unexpected: %v", opts.Scale)
+		}
+		if function.offset, err = parseDurationDistance(opts.Offset); err != nil {
+			return nil, fmt.Errorf("offset option is unexpected: %v", opts.Offset)
+		}
+	case map[string]interface{}:
+		function.fieldType = mapping.GeoPointField
+		bytes, err := json.Marshal(origin)
+		if err != nil {
+			return nil, err
+		}
+		var point geo.Point
+		if err := json.Unmarshal(bytes, &point); err != nil {
+			return nil, fmt.Errorf("origin option is unexpected: %v", opts.Origin)
+		}
+		function.origin = point
+		if function.scale, err = parseGeoDistance(opts.Scale); err != nil {
+			return nil, fmt.Errorf("scale option is unexpected: %v", opts.Scale)
+		}
+		if function.offset, err = parseGeoDistance(opts.Offset); err != nil {
+			return nil, fmt.Errorf("offset option is unexpected: %v", opts.Offset)
+		}
+	default:
+		return nil, fmt.Errorf("origin option is unexpected: %v", opts.Origin)
+	}
+
+	if function.scale <= 0.0 {
+		return nil, fmt.Errorf("scale option is unexpected: %v", opts.Scale)
+	}
+	if function.offset < 0.0 {
+		return nil, fmt.Errorf("offset option is unexpected: %v", opts.Offset)
+	}
+
+	return function, nil
+}
+
+func parseNumericDistance(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("distance is not a number: %v", value)
+	}
+}
+
+// parseDurationDistance parses a duration like "1h30m" into seconds.
+// Days are also available, such as "7d".
+func parseDurationDistance(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		if strings.HasSuffix(v, "d") {
+			days, err := strconv.ParseFloat(strings.TrimSuffix(v, "d"), 64)
+			if err != nil {
+				return 0, err
+			}
+			return days * 24 * time.Hour.Seconds(), nil
+		}
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, err
+		}
+		return duration.Seconds(), nil
+	default:
+		return 0, fmt.Errorf("distance is not a duration: %v", value)
+	}
+}
+
+// parseGeoDistance parses a distance like "10km" into meters.
+func parseGeoDistance(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case string:
+		return geo.ParseDistance(v)
+	default:
+		return 0, fmt.Errorf("distance is not a string: %v", value)
+	}
+}
+
+func (f *decayFunction) Field() string {
+	return f.field
+}
+
+func (f *decayFunction) FieldType() mapping.FieldType {
+	return f.fieldType
+}
+
+func (f *decayFunction) distance(value []byte) (float64, error) {
+	switch origin := f.origin.(type) {
+	case float64:
+		fieldValue, err := bluge.DecodeNumericFloat64(value)
+		if err != nil {
+			return 0, err
+		}
+		return math.Abs(fieldValue - origin), nil
+	case time.Time:
+		fieldValue, err := bluge.DecodeDateTime(value)
+		if err != nil {
+			return 0, err
+		}
+		return math.Abs(fieldValue.Sub(origin).Seconds()), nil
+	case geo.Point:
+		lon, lat, err := bluge.DecodeGeoLonLat(value)
+		if err != nil {
+			return 0, err
+		}
+		// Haversin returns kilometers.
+		return geo.Haversin(origin.Lon, origin.Lat, lon, lat) * 1000, nil
+	default:
+		return 0, fmt.Errorf("origin is unexpected: %v", f.origin)
+	}
+}
+
+func (f *decayFunction) Score(value []byte) (float64, error) {
+	if value == nil {
+		return 1.0, nil
+	}
+
+	distance, err := f.distance(value)
+	if err != nil {
+		return 0, err
+	}
+	distance = math.Max(0, distance-f.offset)
+
+	switch f.curve {
+	case DecayCurveGauss:
+		sigmaSquared := -f.scale * f.scale / (2 * math.Log(f.decay))
+		return math.Exp(-distance * distance / (2 * sigmaSquared)), nil
+	case DecayCurveExp:
+		lambda := math.Log(f.decay) / f.scale
+		return math.Exp(lambda * distance), nil
+	case DecayCurveLinear:
+		s := f.scale / (1 - f.decay)
+		return math.Max(0, (s-distance)/s), nil
+	default:
+		return 0, fmt.Errorf("decay curve is unexpected: %v", f.curve)
+	}
+}
+
+func (f *decayFunction) String() string {
+	origin := fmt.Sprintf("%v", f.origin)
+	if t, ok := f.origin.(time.Time); ok {
+		origin = t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%s(%s, origin=%s, scale=%v, offset=%v, decay=%v)", DecayCurve_name[f.curve], f.field, origin, f.scale, f.offset, f.decay)
+}
+
+// FunctionScoreQuery modifies the scores of the documents matching the query with score functions.
+// The scores of the functions are combined by the score mode and capped by the max boost,
+// and then combined with the query score by the boost mode.
+// Documents that no function applies to keep the function score 1.0.
+type FunctionScoreQuery struct {
+	query     bluge.Query
+	functions []*ScoreFunction
+	scoreMode ScoreMode
+	boostMode BoostMode
+	maxBoost  float64
+	boost     float64
+}
+
+func NewFunctionScoreQuery(query bluge.Query) *FunctionScoreQuery {
+	return &FunctionScoreQuery{
+		query:     query,
+		functions: make([]*ScoreFunction, 0),
+		scoreMode: ScoreModeMultiply,
+		boostMode: BoostModeMultiply,
+		maxBoost:  math.MaxFloat64,
+		boost:     1.0,
+	}
+}
+
+func (q *FunctionScoreQuery) AddFunction(function *ScoreFunction) *FunctionScoreQuery {
+	q.functions = append(q.functions, function)
+	return q
+}
+
+func (q *FunctionScoreQuery) SetScoreMode(scoreMode ScoreMode) *FunctionScoreQuery {
+	q.scoreMode = scoreMode
+	return q
+}
+
+func (q *FunctionScoreQuery) SetBoostMode(boostMode BoostMode) *FunctionScoreQuery {
+	q.boostMode = boostMode
+	return q
+}
+
+func (q *FunctionScoreQuery) SetMaxBoost(maxBoost float64) *FunctionScoreQuery {
+	q.maxBoost = maxBoost
+	return q
+}
+
+func (q *FunctionScoreQuery) SetBoost(b float64) *FunctionScoreQuery {
+	q.boost = b
+	return q
+}
+
+func (q *FunctionScoreQuery) Query() bluge.Query {
+	return q.query
+}
+
+func (q *FunctionScoreQuery) Functions() []*ScoreFunction {
+	return q.functions
+}
+
+func (q *FunctionScoreQuery) ScoreMode() ScoreMode {
+	return q.scoreMode
+}
+
+func (q *FunctionScoreQuery) BoostMode() BoostMode {
+	return q.boostMode
+}
+
+func (q *FunctionScoreQuery) Boost() float64 {
+	return q.boost
+}
+
+func (q *FunctionScoreQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	// Find the document numbers matching the filters, which do not need scores.
+	filterNumbers := make([]map[uint64]bool, len(q.functions))
+	for n, function := range q.functions {
+		if function.filter == nil {
+			continue
+		}
+		numbers := make(map[uint64]bool)
+		if err := visitMatchingNumbers(i, function.filter, options, func(number uint64) error {
+			numbers[number] = true
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		filterNumbers[n] = numbers
+	}
+
+	fields := make(map[string]bool)
+	for _, function := range q.functions {
+		if field, _ := function.Field(); field != "" {
+			fields[field] = true
+		}
+	}
+
+	querySearcher, err := q.query.Searcher(i, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &functionScoreSearcher{
+		Searcher:      querySearcher,
+		reader:        i,
+		query:         q,
+		filterNumbers: filterNumbers,
+		fields:        fields,
+		options:       options,
+	}, nil
+}
+
+type functionScoreSearcher struct {
+	search.Searcher
+	reader        search.Reader
+	query         *FunctionScoreQuery
+	filterNumbers []map[uint64]bool
+	fields        map[string]bool
+	options       search.SearcherOptions
+}
+
+func (s *functionScoreSearcher) Next(ctx *search.Context) (*search.DocumentMatch, error) {
+	dm, err := s.Searcher.Next(ctx)
+	if err != nil || dm == nil {
+		return dm, err
+	}
+
+	return dm, s.score(dm)
+}
+
+func (s *functionScoreSearcher) Advance(ctx *search.Context, number uint64) (*search.DocumentMatch, error) {
+	dm, err := s.Searcher.Advance(ctx, number)
+	if err != nil || dm == nil {
+		return dm, err
+	}
+
+	return dm, s.score(dm)
+}
+
+func (s *functionScoreSearcher) score(dm *search.DocumentMatch) error {
+	values := make(map[string][]byte)
+	if len(s.fields) > 0 {
+		// Multi-valued fields are scored by their first value.
+		err := s.reader.VisitStoredFields(dm.Number, func(field string, value []byte) bool {
+			if s.fields[field] {
+				if _, ok := values[field]; !ok {
+					values[field] = append([]byte{}, value...)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	scores := make([]float64, 0, len(s.query.functions))
+	weights := make([]float64, 0, len(s.query.functions))
+	explanations := make([]*search.Explanation, 0, len(s.query.functions))
+	for n, function := range s.query.functions {
+		if s.filterNumbers[n] != nil && !s.filterNumbers[n][dm.Number] {
+			continue
+		}
+		score, explanation, err := function.score(values)
+		if err != nil {
+			return err
+		}
+		scores = append(scores, score)
+		weights = append(weights, function.weight)
+		explanations = append(explanations, explanation)
+		if s.query.scoreMode == ScoreModeFirst {
+			break
+		}
+	}
+
+	functionScore := 1.0
+	if len(scores) > 0 {
+		switch s.query.scoreMode {
+		case ScoreModeMultiply:
+			for _, score := range scores {
+				functionScore *= score
+			}
+		case ScoreModeSum:
+			functionScore = 0.0
+			for _, score := range scores {
+				functionScore += score
+			}
+		case ScoreModeAvg:
+			// The scores are averaged by the weights, which are already multiplied.
+			sum, weightSum := 0.0, 0.0
+			for n, score := range scores {
+				sum += score
+				weightSum += weights[n]
+			}
+			if weightSum > 0 {
+				functionScore = sum / weightSum
+			} else {
+				functionScore = 0.0
+			}
+		case ScoreModeFirst:
+			functionScore = scores[0]
+		case ScoreModeMax:
+			functionScore = math.Inf(-1)
+			for _, score := range scores {
+				functionScore = math.Max(functionScore, score)
+			}
+		case ScoreModeMin:
+			functionScore = math.Inf(1)
+			for _, score := range scores {
+				functionScore = math.Min(functionScore, score)
+			}
+		}
+	}
+	functionScore = math.Min(functionScore, s.query.maxBoost)
+
+	queryScore := dm.Score
+	var score float64
+	switch s.query.boostMode {
+	case BoostModeMultiply:
+		score = queryScore * functionScore
+	case BoostModeReplace:
+		score = functionScore
+	case BoostModeSum:
+		score = queryScore + functionScore
+	case BoostModeAvg:
+		score = (queryScore + functionScore) / 2
+	case BoostModeMax:
+		score = math.Max(queryScore, functionScore)
+	case BoostModeMin:
+		score = math.Min(queryScore, functionScore)
+	}
+	dm.Score = score * s.query.boost
+
+	if s.options.Explain {
+		functionExplanation := search.NewExplanation(functionScore,
+			fmt.Sprintf("function score, score mode %s, max boost %v, of:", ScoreMode_name[s.query.scoreMode], s.query.maxBoost),
+			explanations...,
+		)
+		queryExplanation := dm.Explanation
+		if queryExplanation == nil {
+			queryExplanation = search.NewExplanation(queryScore, "query score")
+		}
+		dm.Explanation = search.NewExplanation(dm.Score,
+			fmt.Sprintf("function_score, boost mode %s, boost %v, of:", BoostMode_name[s.query.boostMode], s.query.boost),
+			queryExplanation,
+			functionExplanation,
+		)
+	}
+
+	return nil
+}
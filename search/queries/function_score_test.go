@@ -0,0 +1,119 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+)
+
+func TestNewFunctionScoreQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_function_score_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewFunctionScoreQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(query.Functions()) != 5 {
+		t.Fatalf("expected 5 functions, but %v\n", len(query.Functions()))
+	}
+}
+
+func TestFunctionScoreQuery(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	popularities := map[string]float64{
+		"1": 10,
+		"2": 20,
+		"3": 50,
+	}
+	batch := bluge.NewBatch()
+	for id, popularity := range popularities {
+		doc := bluge.NewDocument(id)
+		doc.AddField(bluge.NewTextField("text", "hello"))
+		doc.AddField(bluge.NewNumericField("popularity", popularity).StoreValue())
+		batch.Update(doc.ID(), doc)
+	}
+	if err := writer.Batch(batch); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	var opts map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+	  "functions": [
+	    {"field_value_factor": {"field": "popularity", "factor": 0.1}},
+	    {"linear": {"field": "popularity", "origin": 10, "scale": 40, "decay": 0.5}},
+	    {"filter": {"type": "term", "options": {"term": "3", "field": "_id"}}, "weight": 3.0}
+	  ],
+	  "score_mode": "multiply",
+	  "boost_mode": "replace"
+	}`), &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	query, err := NewFunctionScoreQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// popularity * 0.1 * max(0, (80 - |popularity - 10|) / 80), and 3 times for the document 3.
+	expected := map[string]float64{
+		"1": 1.0,
+		"2": 1.75,
+		"3": 7.5,
+	}
+
+	docMatchIter, err := reader.Search(context.Background(), bluge.NewTopNSearch(10, query).ExplainScores())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	numDocs := 0
+	docMatch, err := docMatchIter.Next()
+	for err == nil && docMatch != nil {
+		var id string
+		if err := docMatch.VisitStoredFields(func(field string, value []byte) bool {
+			if field == "_id" {
+				id = string(value)
+				return false
+			}
+			return true
+		}); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if math.Abs(docMatch.Score-expected[id]) > 1e-9 {
+			t.Fatalf("expected %v for document %v, but %v\n", expected[id], id, docMatch.Score)
+		}
+		if docMatch.Explanation == nil || math.Abs(docMatch.Explanation.Value-docMatch.Score) > 1e-9 {
+			t.Fatalf("`%v` is not explained\n", docMatch.Score)
+		}
+		numDocs++
+		docMatch, err = docMatchIter.Next()
+	}
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if numDocs != 3 {
+		t.Fatalf("expected 3 documents, but %v\n", numDocs)
+	}
+}
@@ -0,0 +1,50 @@
+package queries
+
+import (
+	"encoding/json"
+
+	"github.com/mosuka/phalanx/mapping"
+)
+
+type IdsQueryOptions struct {
+	Ids   []string `json:"ids"`
+	Boost float64  `json:"boost"`
+}
+
+func NewIdsQueryOptions() IdsQueryOptions {
+	return IdsQueryOptions{
+		Boost: 1.0,
+	}
+}
+
+// Create new IdsQuery with given options.
+// Options example:
+// {
+//   "ids": ["1", "2", "3"],
+//   "boost": 1.0
+// }
+func NewIdsQueryWithMap(opts map[string]interface{}) (*TermsQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewIdsQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewIdsQueryWithOptions(options)
+}
+
+// The ids query is a terms query on the _id field.
+func NewIdsQueryWithOptions(opts IdsQueryOptions) (*TermsQuery, error) {
+	idsQuery := NewTermsQuery(opts.Ids...).SetField(mapping.IdFieldName)
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		idsQuery.SetBoost(opts.Boost)
+	}
+
+	return idsQuery, nil
+}
@@ -0,0 +1,34 @@
+package queries
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/mosuka/phalanx/mapping"
+)
+
+func TestNewIdsQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_ids_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewIdsQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if query.Field() != mapping.IdFieldName {
+		t.Fatalf("expected %v, but %v\n", mapping.IdFieldName, query.Field())
+	}
+	if !reflect.DeepEqual(query.Terms(), []string{"1", "2", "3"}) {
+		t.Fatalf("unexpected ids: %v\n", query.Terms())
+	}
+}
@@ -0,0 +1,287 @@
+package queries
+
+import (
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+const DefaultKNNQueryK = 10
+
+type KNNQueryOptions struct {
+	Field      string        `json:"field"`
+	Vector     []float32     `json:"vector"`
+	K          int           `json:"k"`
+	Similarity string        `json:"similarity"`
+	Filter     *QuerySetting `json:"filter"`
+	Boost      float64       `json:"boost"`
+}
+
+func NewKNNQueryOptions() KNNQueryOptions {
+	return KNNQueryOptions{
+		K:     DefaultKNNQueryK,
+		Boost: 1.0,
+	}
+}
+
+// Create new KNNQuery with given options.
+// Options example:
+// {
+//   "field": "embedding",
+//   "vector": [0.1, 0.2, 0.3],
+//   "k": 10,
+//   "similarity": "cosine",
+//   "filter": {
+//     "type": "term",
+//     "options": {
+//       "term": "hello",
+//       "field": "description"
+//     }
+//   },
+//   "boost": 1.0
+// }
+func NewKNNQueryWithMap(opts map[string]interface{}) (*KNNQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewKNNQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewKNNQueryWithOptions(options)
+}
+
+func NewKNNQueryWithOptions(opts KNNQueryOptions) (*KNNQuery, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if len(opts.Vector) == 0 {
+		return nil, fmt.Errorf("vector option does not exist")
+	}
+
+	if opts.K <= 0 {
+		return nil, fmt.Errorf("k option is unexpected: %v", opts.K)
+	}
+
+	similarity, err := mapping.NewSimilarity(opts.Similarity)
+	if err != nil {
+		return nil, err
+	}
+
+	knnQuery := NewKNNQuery(opts.Field, opts.Vector, opts.K).SetSimilarity(similarity)
+
+	// filter is optional.
+	if opts.Filter != nil {
+		filter, err := NewQuery(opts.Filter.Type, opts.Filter.Options)
+		if err != nil {
+			return nil, err
+		}
+		knnQuery.SetFilter(filter)
+	}
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		knnQuery.SetBoost(opts.Boost)
+	}
+
+	return knnQuery, nil
+}
+
+// KNNQuery finds the k nearest neighbors of a vector in a dense vector field.
+// Each shard is scanned exhaustively, so the result is exact.
+type KNNQuery struct {
+	field      string
+	vector     []float32
+	k          int
+	similarity mapping.Similarity
+	filter     bluge.Query
+	boost      float64
+}
+
+func NewKNNQuery(field string, vector []float32, k int) *KNNQuery {
+	return &KNNQuery{
+		field:      field,
+		vector:     vector,
+		k:          k,
+		similarity: mapping.DefaultSimilarity,
+		boost:      1.0,
+	}
+}
+
+func (q *KNNQuery) SetSimilarity(similarity mapping.Similarity) *KNNQuery {
+	q.similarity = similarity
+	return q
+}
+
+func (q *KNNQuery) SetFilter(filter bluge.Query) *KNNQuery {
+	q.filter = filter
+	return q
+}
+
+func (q *KNNQuery) SetBoost(b float64) *KNNQuery {
+	q.boost = b
+	return q
+}
+
+func (q *KNNQuery) Boost() float64 {
+	return q.boost
+}
+
+func (q *KNNQuery) Field() string {
+	return q.field
+}
+
+func (q *KNNQuery) K() int {
+	return q.k
+}
+
+func (q *KNNQuery) Filter() bluge.Query {
+	return q.filter
+}
+
+func (q *KNNQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	var candidateQuery bluge.Query = bluge.NewMatchAllQuery()
+	if q.filter != nil {
+		candidateQuery = q.filter
+	}
+
+	hits := make(knnHitHeap, 0, q.k)
+	if err := visitMatchingNumbers(i, candidateQuery, options, func(number uint64) error {
+		var vector []float32
+		var decodeErr error
+		if err := i.VisitStoredFields(number, func(field string, value []byte) bool {
+			if field == q.field {
+				vector, decodeErr = mapping.DecodeDenseVector(value)
+				return false
+			}
+			return true
+		}); err != nil {
+			return err
+		}
+		if decodeErr != nil {
+			return decodeErr
+		}
+
+		// Documents without the field or with another dimension never match.
+		if len(vector) == len(q.vector) {
+			hit := knnHit{
+				number: number,
+				score:  q.similarity.Score(q.vector, vector),
+			}
+			if len(hits) < q.k {
+				heap.Push(&hits, hit)
+			} else if hit.score > hits[0].score {
+				hits[0] = hit
+				heap.Fix(&hits, 0)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Searchers must return documents in ascending document number order.
+	sort.Slice(hits, func(a, b int) bool {
+		return hits[a].number < hits[b].number
+	})
+
+	return &knnSearcher{
+		reader:     i,
+		field:      q.field,
+		similarity: q.similarity,
+		hits:       hits,
+		boost:      q.boost,
+		options:    options,
+	}, nil
+}
+
+type knnHit struct {
+	number uint64
+	score  float64
+}
+
+// knnHitHeap is a min-heap of hits ordered by score.
+type knnHitHeap []knnHit
+
+func (h knnHitHeap) Len() int            { return len(h) }
+func (h knnHitHeap) Less(i, j int) bool  { return h[i].score < h[j].score }
+func (h knnHitHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *knnHitHeap) Push(x interface{}) { *h = append(*h, x.(knnHit)) }
+func (h *knnHitHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+type knnSearcher struct {
+	reader     search.Reader
+	field      string
+	similarity mapping.Similarity
+	hits       []knnHit
+	pos        int
+	boost      float64
+	options    search.SearcherOptions
+}
+
+func (s *knnSearcher) Next(ctx *search.Context) (*search.DocumentMatch, error) {
+	if s.pos >= len(s.hits) {
+		return nil, nil
+	}
+
+	hit := s.hits[s.pos]
+	s.pos++
+
+	rv := ctx.DocumentMatchPool.Get()
+	rv.SetReader(s.reader)
+	rv.Number = hit.number
+	rv.Score = hit.score * s.boost
+	if s.options.Explain {
+		rv.Explanation = search.NewExplanation(rv.Score,
+			fmt.Sprintf("knn(field=%s, similarity=%s), product of:", s.field, s.similarity),
+			search.NewExplanation(hit.score, "similarity"),
+			search.NewExplanation(s.boost, "boost"),
+		)
+	}
+
+	return rv, nil
+}
+
+func (s *knnSearcher) Advance(ctx *search.Context, number uint64) (*search.DocumentMatch, error) {
+	for s.pos < len(s.hits) && s.hits[s.pos].number < number {
+		s.pos++
+	}
+
+	return s.Next(ctx)
+}
+
+func (s *knnSearcher) Close() error {
+	return nil
+}
+
+func (s *knnSearcher) Count() uint64 {
+	return uint64(len(s.hits))
+}
+
+func (s *knnSearcher) Min() int {
+	return 0
+}
+
+func (s *knnSearcher) Size() int {
+	return len(s.hits) * 16
+}
+
+func (s *knnSearcher) DocumentMatchPoolSize() int {
+	return 1
+}
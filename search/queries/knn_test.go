@@ -0,0 +1,118 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/mapping"
+	"github.com/mosuka/phalanx/proto"
+)
+
+func TestNewKNNQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_knn_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = NewKNNQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+}
+
+func TestKNNQueryWithIndexMapping(t *testing.T) {
+	indexMapping, err := mapping.NewMapping([]byte(`{
+		"dot_product_vector": {"type": "dense_vector", "dimension": 2, "similarity": "dot_product"},
+		"l2_vector": {"type": "dense_vector", "dimension": 2, "similarity": "l2"}
+	}`))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	// The vectors have the same direction, so the cosine similarity cannot rank them.
+	for id, fields := range map[string]string{
+		"1": `{"dot_product_vector": [2.0, 0.0], "l2_vector": [2.0, 0.0]}`,
+		"2": `{"dot_product_vector": [1.0, 0.0], "l2_vector": [1.0, 0.0]}`,
+	} {
+		doc, err := indexMapping.MakeDocument(&proto.Document{Id: id, Fields: []byte(fields)}, mapping.DefaultDynamicPolicy, nil)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if err := writer.Update(doc.ID(), doc); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+	if err := writer.Close(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	cases := []struct {
+		field    string
+		expected map[string]float64
+	}{
+		// The dot product is 2 and 1.
+		{field: "dot_product_vector", expected: map[string]float64{"1": 3.0, "2": 2.0}},
+		// The squared distances are 1 and 0.
+		{field: "l2_vector", expected: map[string]float64{"1": 0.5, "2": 1.0}},
+	}
+	for _, c := range cases {
+		// The similarity is filled from the index mapping.
+		queryOpts := map[string]interface{}{
+			"field":  c.field,
+			"vector": []interface{}{1.0, 0.0},
+			"k":      2,
+		}
+		ApplyIndexMapping("knn", queryOpts, indexMapping, analyzer.AnalysisSetting{})
+		query, err := NewQuery("knn", queryOpts)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		docMatchIter, err := reader.Search(context.Background(), bluge.NewTopNSearch(10, query))
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		actual := make(map[string]float64)
+		docMatch, err := docMatchIter.Next()
+		for err == nil && docMatch != nil {
+			var id string
+			if err := docMatch.VisitStoredFields(func(field string, value []byte) bool {
+				if field == mapping.IdFieldName {
+					id = string(value)
+					return false
+				}
+				return true
+			}); err != nil {
+				t.Fatalf("%v\n", err)
+			}
+			actual[id] = docMatch.Score
+			docMatch, err = docMatchIter.Next()
+		}
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("unexpected scores of %s: %v\n", c.field, actual)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package queries
+
+// DocumentLookupFunc fetches the values of the fields of the document in the index.
+// The values are returned as strings by field name.
+// No values are returned if the document does not exist.
+type DocumentLookupFunc func(indexName string, id string, fields []string) (map[string][]string, error)
+
+// ResolveLookups replaces the options that refer to other documents, such as terms_lookup of terms queries
+// and ids of more_like_this queries, with the values fetched by the lookup.
+// The documents of more_like_this queries are fetched from the index.
+// Nested queries are also resolved.
+func ResolveLookups(indexName string, queryType string, queryOpts map[string]interface{}, lookup DocumentLookupFunc) error {
+	if queryOpts == nil {
+		return nil
+	}
+
+	for _, querySetting := range nestedQuerySettings(queryType, queryOpts) {
+		querySettingMap, ok := querySetting.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		nestedQueryType, ok := querySettingMap["type"].(string)
+		if !ok {
+			continue
+		}
+		nestedQueryOpts, ok := querySettingMap["options"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := ResolveLookups(indexName, nestedQueryType, nestedQueryOpts, lookup); err != nil {
+			return err
+		}
+	}
+
+	switch QueryType_value[queryType] {
+	case QueryTypeTerms:
+		return resolveTermsLookup(queryOpts, lookup)
+	case QueryTypeMoreLikeThis:
+		return resolveMoreLikeThisDocuments(indexName, queryOpts, lookup)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/blugelabs/bluge"
 	"github.com/mosuka/phalanx/analysis/analyzer"
@@ -26,6 +27,7 @@ type MatchQueryOptions struct {
 	Fuzziness int                      `json:"fuzziness"`
 	Operator  string                   `json:"operator"`
 	Analyzer  analyzer.AnalyzerSetting `json:"analyzer"`
+	Language  string                   `json:"language"`
 }
 
 func NewMatchQueryOptions() MatchQueryOptions {
@@ -62,6 +64,14 @@ func NewMatchQueryOptions() MatchQueryOptions {
 //     ]
 //   }
 // }
+//
+// The text can also be analyzed with the analyzer of a language (ISO 639-1),
+// or of the detected language with "auto". The language takes precedence over the analyzer.
+// {
+//   "match": "hello",
+//   "field": "description",
+//   "language": "auto"
+// }
 func NewMatchQueryWithMap(opts map[string]interface{}) (*bluge.MatchQuery, error) {
 	bytes, err := json.Marshal(opts)
 	if err != nil {
@@ -104,10 +114,27 @@ func NewMatchQueryWithOptions(opts MatchQueryOptions) (*bluge.MatchQuery, error)
 		matchQuery.SetOperator(MatchQueryOperator_value[opts.Operator])
 	}
 
-	// analyzer is optional.
-	if analyzer, err := analyzer.NewAnalyzer(opts.Analyzer); err == nil {
-		matchQuery.SetAnalyzer(analyzer)
+	// language and analyzer are optional.
+	if opts.Language != "" {
+		languageAnalyzer, err := analyzer.NewAnalyzer(analyzer.AnalyzerSetting{Language: opts.Language})
+		if err != nil {
+			return nil, err
+		}
+		matchQuery.SetAnalyzer(languageAnalyzer)
+	} else if hasAnalyzerSetting(opts.Analyzer) {
+		queryAnalyzer, err := analyzer.NewAnalyzer(opts.Analyzer)
+		if err != nil {
+			return nil, fmt.Errorf("analyzer option is unexpected: %v", err)
+		}
+		matchQuery.SetAnalyzer(queryAnalyzer)
 	}
 
 	return matchQuery, nil
 }
+
+// hasAnalyzerSetting reports whether the analyzer is specified.
+// Queries without an analyzer are analyzed with the default analyzer of the index.
+func hasAnalyzerSetting(setting analyzer.AnalyzerSetting) bool {
+	return setting.Name != "" || setting.Language != "" || setting.TokenizerSetting.Name != "" ||
+		len(setting.CharFilterSettings) > 0 || len(setting.TokenFilterSettings) > 0
+}
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/blugelabs/bluge"
 	"github.com/mosuka/phalanx/analysis/analyzer"
@@ -13,6 +14,7 @@ type MatchPhraseQueryOptions struct {
 	Boost    float64                  `json:"boost"`
 	Slop     int                      `json:"slop"`
 	Analyzer analyzer.AnalyzerSetting `json:"analyzer"`
+	Language string                   `json:"language"`
 }
 
 func NewMatchPhraseQueryOptions() MatchPhraseQueryOptions {
@@ -45,6 +47,14 @@ func NewMatchPhraseQueryOptions() MatchPhraseQueryOptions {
 //     ]
 //   }
 // }
+//
+// The text can also be analyzed with the analyzer of a language (ISO 639-1),
+// or of the detected language with "auto". The language takes precedence over the analyzer.
+// {
+//   "phrase": "hello",
+//   "field": "description",
+//   "language": "auto"
+// }
 func NewMatchPhraseQueryWithMap(opts map[string]interface{}) (*bluge.MatchPhraseQuery, error) {
 	bytes, err := json.Marshal(opts)
 	if err != nil {
@@ -77,9 +87,19 @@ func NewMatchPhraseQueryWithOptions(opts MatchPhraseQueryOptions) (*bluge.MatchP
 		matchPhraseQuery.SetSlop(opts.Slop)
 	}
 
-	// analyzer is optional.
-	if analyzer, err := analyzer.NewAnalyzer(opts.Analyzer); err == nil {
-		matchPhraseQuery.SetAnalyzer(analyzer)
+	// language and analyzer are optional.
+	if opts.Language != "" {
+		languageAnalyzer, err := analyzer.NewAnalyzer(analyzer.AnalyzerSetting{Language: opts.Language})
+		if err != nil {
+			return nil, err
+		}
+		matchPhraseQuery.SetAnalyzer(languageAnalyzer)
+	} else if hasAnalyzerSetting(opts.Analyzer) {
+		queryAnalyzer, err := analyzer.NewAnalyzer(opts.Analyzer)
+		if err != nil {
+			return nil, fmt.Errorf("analyzer option is unexpected: %v", err)
+		}
+		matchPhraseQuery.SetAnalyzer(queryAnalyzer)
 	}
 
 	return matchPhraseQuery, nil
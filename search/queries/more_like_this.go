@@ -0,0 +1,341 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+	blugeanalyzer "github.com/blugelabs/bluge/analysis/analyzer"
+	"github.com/blugelabs/bluge/search"
+	"github.com/blugelabs/bluge/search/searcher"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+type MoreLikeThisQueryOptions struct {
+	Fields        []string                            `json:"fields"`
+	Ids           []string                            `json:"ids"`
+	Text          string                              `json:"text"`
+	Documents     []map[string][]string               `json:"documents"`
+	MinTermFreq   int                                 `json:"min_term_freq"`
+	MaxQueryTerms int                                 `json:"max_query_terms"`
+	MinDocFreq    int                                 `json:"min_doc_freq"`
+	Include       bool                                `json:"include"`
+	Analyzer      analyzer.AnalyzerSetting            `json:"analyzer"`
+	Analyzers     map[string]analyzer.AnalyzerSetting `json:"analyzers"`
+	Boost         float64                             `json:"boost"`
+}
+
+func NewMoreLikeThisQueryOptions() MoreLikeThisQueryOptions {
+	return MoreLikeThisQueryOptions{
+		MinTermFreq:   2,
+		MaxQueryTerms: 25,
+		MinDocFreq:    5,
+		Boost:         1.0,
+	}
+}
+
+// Create new MoreLikeThisQuery with given options.
+// Options example:
+// {
+//   "fields": ["title", "description"],
+//   "ids": ["1"],
+//   "text": "search engine written in Go",
+//   "min_term_freq": 2,
+//   "max_query_terms": 25,
+//   "min_doc_freq": 5,
+//   "include": false,
+//   "boost": 1.0
+// }
+//
+// The documents of the ids must be resolved with ResolveLookups before the query is created.
+// The field values of the documents are set to the documents option.
+func NewMoreLikeThisQueryWithMap(opts map[string]interface{}) (*MoreLikeThisQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewMoreLikeThisQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewMoreLikeThisQueryWithOptions(options)
+}
+
+func NewMoreLikeThisQueryWithOptions(opts MoreLikeThisQueryOptions) (*MoreLikeThisQuery, error) {
+	if len(opts.Fields) == 0 {
+		return nil, fmt.Errorf("fields option does not exist")
+	}
+
+	if len(opts.Ids) == 0 && opts.Text == "" {
+		return nil, fmt.Errorf("ids or text option does not exist")
+	}
+
+	if len(opts.Ids) > 0 && opts.Documents == nil {
+		return nil, fmt.Errorf("ids option is not resolved: %v", opts.Ids)
+	}
+
+	if opts.MaxQueryTerms <= 0 {
+		return nil, fmt.Errorf("max_query_terms option is unexpected: %v", opts.MaxQueryTerms)
+	}
+
+	// The analyzer of the query takes precedence over the analyzers of the fields.
+	analyzers := make(map[string]*analysis.Analyzer)
+	for _, field := range opts.Fields {
+		analyzerSetting := opts.Analyzers[field]
+		if hasAnalyzerSetting(opts.Analyzer) {
+			analyzerSetting = opts.Analyzer
+		}
+		if !hasAnalyzerSetting(analyzerSetting) {
+			continue
+		}
+		fieldAnalyzer, err := analyzer.NewAnalyzer(analyzerSetting)
+		if err != nil {
+			return nil, fmt.Errorf("analyzer of the %s field is unexpected: %v", field, err)
+		}
+		analyzers[field] = fieldAnalyzer
+	}
+
+	// The texts of the fields to extract the terms from.
+	texts := make(map[string][]string)
+	for _, field := range opts.Fields {
+		if opts.Text != "" {
+			texts[field] = append(texts[field], opts.Text)
+		}
+		for _, document := range opts.Documents {
+			texts[field] = append(texts[field], document[field]...)
+		}
+	}
+
+	moreLikeThisQuery := NewMoreLikeThisQuery(opts.Fields, texts).
+		SetAnalyzers(analyzers).
+		SetMinTermFreq(opts.MinTermFreq).
+		SetMaxQueryTerms(opts.MaxQueryTerms).
+		SetMinDocFreq(opts.MinDocFreq)
+
+	// The documents that the query is made from do not match unless they are included.
+	if !opts.Include {
+		moreLikeThisQuery.SetExcludeIds(opts.Ids)
+	}
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		moreLikeThisQuery.SetBoost(opts.Boost)
+	}
+
+	return moreLikeThisQuery, nil
+}
+
+// resolveMoreLikeThisDocuments fetches the field values of the documents of the ids from the index,
+// and sets them to the documents option.
+func resolveMoreLikeThisDocuments(indexName string, queryOpts map[string]interface{}, lookup DocumentLookupFunc) error {
+	if _, ok := queryOpts["documents"]; ok {
+		return nil
+	}
+
+	idValues, ok := queryOpts["ids"].([]interface{})
+	if !ok || len(idValues) == 0 {
+		return nil
+	}
+
+	fieldValues, ok := queryOpts["fields"].([]interface{})
+	if !ok {
+		return fmt.Errorf("fields option is unexpected: %v", queryOpts["fields"])
+	}
+	fields := make([]string, 0, len(fieldValues))
+	for _, fieldValue := range fieldValues {
+		field, ok := fieldValue.(string)
+		if !ok {
+			return fmt.Errorf("fields option is unexpected: %v", queryOpts["fields"])
+		}
+		fields = append(fields, field)
+	}
+
+	documents := make([]interface{}, 0, len(idValues))
+	for _, idValue := range idValues {
+		id, ok := idValue.(string)
+		if !ok {
+			return fmt.Errorf("ids option is unexpected: %v", queryOpts["ids"])
+		}
+		document, err := lookup(indexName, id, fields)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, document)
+	}
+	queryOpts["documents"] = documents
+
+	return nil
+}
+
+// MoreLikeThisQuery matches the documents similar to the texts.
+// The terms of the texts are weighted with the term frequencies in the texts
+// and the document frequencies in the index, and the top terms are searched
+// as a disjunction boosted with the weights.
+type MoreLikeThisQuery struct {
+	fields        []string
+	texts         map[string][]string
+	analyzers     map[string]*analysis.Analyzer
+	minTermFreq   int
+	maxQueryTerms int
+	minDocFreq    int
+	excludeIds    []string
+	boost         float64
+}
+
+func NewMoreLikeThisQuery(fields []string, texts map[string][]string) *MoreLikeThisQuery {
+	return &MoreLikeThisQuery{
+		fields:        fields,
+		texts:         texts,
+		analyzers:     make(map[string]*analysis.Analyzer),
+		minTermFreq:   2,
+		maxQueryTerms: 25,
+		minDocFreq:    5,
+		boost:         1.0,
+	}
+}
+
+func (q *MoreLikeThisQuery) SetAnalyzers(analyzers map[string]*analysis.Analyzer) *MoreLikeThisQuery {
+	q.analyzers = analyzers
+	return q
+}
+
+func (q *MoreLikeThisQuery) SetMinTermFreq(minTermFreq int) *MoreLikeThisQuery {
+	q.minTermFreq = minTermFreq
+	return q
+}
+
+func (q *MoreLikeThisQuery) SetMaxQueryTerms(maxQueryTerms int) *MoreLikeThisQuery {
+	q.maxQueryTerms = maxQueryTerms
+	return q
+}
+
+func (q *MoreLikeThisQuery) SetMinDocFreq(minDocFreq int) *MoreLikeThisQuery {
+	q.minDocFreq = minDocFreq
+	return q
+}
+
+func (q *MoreLikeThisQuery) SetExcludeIds(ids []string) *MoreLikeThisQuery {
+	q.excludeIds = ids
+	return q
+}
+
+func (q *MoreLikeThisQuery) SetBoost(b float64) *MoreLikeThisQuery {
+	q.boost = b
+	return q
+}
+
+func (q *MoreLikeThisQuery) Fields() []string {
+	return q.fields
+}
+
+func (q *MoreLikeThisQuery) ExcludeIds() []string {
+	return q.excludeIds
+}
+
+func (q *MoreLikeThisQuery) Boost() float64 {
+	return q.boost
+}
+
+type moreLikeThisTerm struct {
+	field string
+	term  string
+	score float64
+}
+
+// terms returns the top terms of the texts and their weights, with the document frequencies of the reader.
+// A term is weighted with tf * idf, where idf is 1 + ln(N / (df + 1)).
+func (q *MoreLikeThisQuery) terms(i search.Reader) ([]moreLikeThisTerm, error) {
+	terms := make([]moreLikeThisTerm, 0)
+
+	for _, field := range q.fields {
+		fieldAnalyzer, ok := q.analyzers[field]
+		if !ok {
+			// Fields without an analyzer are analyzed with the standard analyzer, the same as unmapped fields when indexing.
+			fieldAnalyzer = blugeanalyzer.NewStandardAnalyzer()
+		}
+
+		termFreqs := make(map[string]int)
+		for _, text := range q.texts[field] {
+			for _, token := range fieldAnalyzer.Analyze([]byte(text)) {
+				termFreqs[string(token.Term)]++
+			}
+		}
+		if len(termFreqs) == 0 {
+			continue
+		}
+
+		collectionStats, err := i.CollectionStats(field)
+		if err != nil {
+			return nil, err
+		}
+		numDocs := float64(collectionStats.TotalDocumentCount())
+
+		for term, termFreq := range termFreqs {
+			if termFreq < q.minTermFreq {
+				continue
+			}
+
+			postings, err := i.PostingsIterator([]byte(term), field, false, false, false)
+			if err != nil {
+				return nil, err
+			}
+			docFreq := postings.Count()
+			if err := postings.Close(); err != nil {
+				return nil, err
+			}
+			if docFreq == 0 || int(docFreq) < q.minDocFreq {
+				continue
+			}
+
+			idf := 1.0 + math.Log(numDocs/float64(docFreq+1))
+			terms = append(terms, moreLikeThisTerm{
+				field: field,
+				term:  term,
+				score: float64(termFreq) * idf,
+			})
+		}
+	}
+
+	sort.Slice(terms, func(a, b int) bool {
+		if terms[a].score != terms[b].score {
+			return terms[a].score > terms[b].score
+		}
+		if terms[a].field != terms[b].field {
+			return terms[a].field < terms[b].field
+		}
+		return terms[a].term < terms[b].term
+	})
+	if len(terms) > q.maxQueryTerms {
+		terms = terms[:q.maxQueryTerms]
+	}
+
+	return terms, nil
+}
+
+func (q *MoreLikeThisQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	terms, err := q.terms(i)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(terms) == 0 {
+		return searcher.NewMatchNoneSearcher(i, options)
+	}
+
+	// The terms are boosted relative to the top term.
+	booleanQuery := bluge.NewBooleanQuery().SetBoost(q.boost)
+	for _, term := range terms {
+		booleanQuery.AddShould(bluge.NewTermQuery(term.term).SetField(term.field).SetBoost(term.score / terms[0].score))
+	}
+	if len(q.excludeIds) > 0 {
+		booleanQuery.AddMustNot(NewTermsQuery(q.excludeIds...).SetField(mapping.IdFieldName))
+	}
+
+	return booleanQuery.Searcher(i, options)
+}
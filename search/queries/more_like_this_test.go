@@ -0,0 +1,155 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+)
+
+func TestNewMoreLikeThisQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_more_like_this_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewMoreLikeThisQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(query.Fields(), []string{"title", "description"}) {
+		t.Fatalf("unexpected fields: %v\n", query.Fields())
+	}
+
+	// The ids must be resolved before the query is created.
+	opts["ids"] = []interface{}{"1"}
+	if _, err := NewMoreLikeThisQueryWithMap(opts); err == nil {
+		t.Fatalf("expected error with unresolved ids\n")
+	}
+
+	lookup := func(indexName string, id string, fields []string) (map[string][]string, error) {
+		return map[string][]string{"title": {"Phalanx"}}, nil
+	}
+	if err := ResolveLookups("articles", "more_like_this", opts, lookup); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	query, err = NewMoreLikeThisQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(query.ExcludeIds(), []string{"1"}) {
+		t.Fatalf("unexpected exclude ids: %v\n", query.ExcludeIds())
+	}
+}
+
+func TestMoreLikeThisQuery(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	for id, title := range map[string]string{
+		"1": "search engine search library",
+		"2": "distributed search engine",
+		"3": "relational database",
+		"4": "key value database",
+	} {
+		doc := bluge.NewDocument(id)
+		doc.AddField(bluge.NewTextField("title", title))
+		if err := writer.Update(doc.ID(), doc); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	search := func(query bluge.Query) []string {
+		docMatchIter, err := reader.Search(context.Background(), bluge.NewTopNSearch(10, query))
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		ids := make([]string, 0)
+		docMatch, err := docMatchIter.Next()
+		for err == nil && docMatch != nil {
+			err = docMatch.VisitStoredFields(func(field string, value []byte) bool {
+				if field == "_id" {
+					ids = append(ids, string(value))
+				}
+				return true
+			})
+			if err != nil {
+				t.Fatalf("%v\n", err)
+			}
+			docMatch, err = docMatchIter.Next()
+		}
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	// The document 1 is excluded, and the terms of the document 1 match the document 2.
+	query, err := NewMoreLikeThisQueryWithOptions(MoreLikeThisQueryOptions{
+		Fields:        []string{"title"},
+		Ids:           []string{"1"},
+		Documents:     []map[string][]string{{"title": {"search engine search library"}}},
+		MinTermFreq:   1,
+		MaxQueryTerms: 25,
+		MinDocFreq:    1,
+		Boost:         1.0,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if ids := search(query); !reflect.DeepEqual(ids, []string{"2"}) {
+		t.Fatalf("expected [2], but %v\n", ids)
+	}
+
+	// The terms less frequent than min_term_freq in the text are not searched.
+	query, err = NewMoreLikeThisQueryWithOptions(MoreLikeThisQueryOptions{
+		Fields:        []string{"title"},
+		Text:          "database database search",
+		MinTermFreq:   2,
+		MaxQueryTerms: 25,
+		MinDocFreq:    1,
+		Boost:         1.0,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if ids := search(query); !reflect.DeepEqual(ids, []string{"3", "4"}) {
+		t.Fatalf("expected [3 4], but %v\n", ids)
+	}
+
+	// The terms in fewer documents than min_doc_freq are not searched.
+	query, err = NewMoreLikeThisQueryWithOptions(MoreLikeThisQueryOptions{
+		Fields:        []string{"title"},
+		Text:          "search relational",
+		MinTermFreq:   1,
+		MaxQueryTerms: 25,
+		MinDocFreq:    2,
+		Boost:         1.0,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if ids := search(query); !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Fatalf("expected [1 2], but %v\n", ids)
+	}
+}
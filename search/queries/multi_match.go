@@ -0,0 +1,314 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+	blugeanalyzer "github.com/blugelabs/bluge/analysis/analyzer"
+	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+)
+
+type MultiMatchType int
+
+const (
+	MultiMatchTypeUnknown MultiMatchType = iota
+	MultiMatchTypeBestFields
+	MultiMatchTypeMostFields
+	MultiMatchTypeCrossFields
+	MultiMatchTypePhrase
+)
+
+// Maps for MultiMatchType.
+var (
+	MultiMatchType_name = map[MultiMatchType]string{
+		MultiMatchTypeUnknown:     "unknown",
+		MultiMatchTypeBestFields:  "best_fields",
+		MultiMatchTypeMostFields:  "most_fields",
+		MultiMatchTypeCrossFields: "cross_fields",
+		MultiMatchTypePhrase:      "phrase",
+	}
+	MultiMatchType_value = map[string]MultiMatchType{
+		"unknown":      MultiMatchTypeUnknown,
+		"best_fields":  MultiMatchTypeBestFields,
+		"most_fields":  MultiMatchTypeMostFields,
+		"cross_fields": MultiMatchTypeCrossFields,
+		"phrase":       MultiMatchTypePhrase,
+	}
+)
+
+type MultiMatchQueryOptions struct {
+	Query      string                              `json:"query"`
+	Fields     []string                            `json:"fields"`
+	Type       string                              `json:"type"`
+	TieBreaker float64                             `json:"tie_breaker"`
+	Operator   string                              `json:"operator"`
+	Fuzziness  int                                 `json:"fuzziness"`
+	Prefix     int                                 `json:"prefix"`
+	Slop       int                                 `json:"slop"`
+	Analyzer   analyzer.AnalyzerSetting            `json:"analyzer"`
+	Analyzers  map[string]analyzer.AnalyzerSetting `json:"analyzers"`
+	Boost      float64                             `json:"boost"`
+}
+
+func NewMultiMatchQueryOptions() MultiMatchQueryOptions {
+	return MultiMatchQueryOptions{
+		Type:     MultiMatchType_name[MultiMatchTypeBestFields],
+		Operator: "OR",
+		Boost:    1.0,
+	}
+}
+
+// Create new MultiMatchQuery with given options.
+// Options example:
+// {
+//   "query": "search engine",
+//   "fields": ["name^3", "description"],
+//   "type": "best_fields",
+//   "tie_breaker": 0.3,
+//   "operator": "OR",
+//   "boost": 1.0
+// }
+//
+// The text of each field is analyzed with the analyzer, the analyzer of the field in the analyzers,
+// or the default analyzer of the index, in that order.
+// The analyzers are filled with the search analyzers of the fields in the index mapping.
+func NewMultiMatchQueryWithMap(opts map[string]interface{}) (bluge.Query, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewMultiMatchQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewMultiMatchQueryWithOptions(options)
+}
+
+type boostedField struct {
+	field string
+	boost float64
+}
+
+// parseBoostedField parses a field name with an optional boost, such as "title^3".
+func parseBoostedField(value string) (boostedField, error) {
+	field := boostedField{
+		field: value,
+		boost: 1.0,
+	}
+
+	if i := strings.LastIndex(value, "^"); i >= 0 {
+		boost, err := strconv.ParseFloat(value[i+1:], 64)
+		if err != nil || boost < 0.0 {
+			return boostedField{}, fmt.Errorf("boost of the field is unexpected: %v", value)
+		}
+		field.field = value[:i]
+		field.boost = boost
+	}
+
+	if field.field == "" {
+		return boostedField{}, fmt.Errorf("field name is empty: %v", value)
+	}
+
+	return field, nil
+}
+
+func NewMultiMatchQueryWithOptions(opts MultiMatchQueryOptions) (bluge.Query, error) {
+	if len(opts.Fields) == 0 {
+		return nil, fmt.Errorf("fields option does not exist")
+	}
+
+	fields := make([]boostedField, 0, len(opts.Fields))
+	for _, value := range opts.Fields {
+		field, err := parseBoostedField(value)
+		if err != nil {
+			return nil, fmt.Errorf("fields option is unexpected: %v", err)
+		}
+		fields = append(fields, field)
+	}
+
+	multiMatchType, ok := MultiMatchType_value[opts.Type]
+	if !ok || multiMatchType == MultiMatchTypeUnknown {
+		return nil, fmt.Errorf("type option is unexpected: %v", opts.Type)
+	}
+
+	operator, ok := MatchQueryOperator_value[opts.Operator]
+	if !ok {
+		return nil, fmt.Errorf("operator option is unexpected: %v", opts.Operator)
+	}
+
+	if opts.TieBreaker < 0.0 || opts.TieBreaker > 1.0 {
+		return nil, fmt.Errorf("tie_breaker option is unexpected: %v", opts.TieBreaker)
+	}
+
+	// The analyzer of the query takes precedence over the analyzers of the fields.
+	analyzerSettings := make(map[string]analyzer.AnalyzerSetting)
+	for _, field := range fields {
+		if hasAnalyzerSetting(opts.Analyzer) {
+			analyzerSettings[field.field] = opts.Analyzer
+		} else if analyzerSetting, ok := opts.Analyzers[field.field]; ok && hasAnalyzerSetting(analyzerSetting) {
+			analyzerSettings[field.field] = analyzerSetting
+		}
+	}
+	analyzers := make(map[string]*analysis.Analyzer)
+	for field, analyzerSetting := range analyzerSettings {
+		fieldAnalyzer, err := analyzer.NewAnalyzer(analyzerSetting)
+		if err != nil {
+			return nil, fmt.Errorf("analyzer of the %s field is unexpected: %v", field, err)
+		}
+		analyzers[field] = fieldAnalyzer
+	}
+
+	switch multiMatchType {
+	case MultiMatchTypeBestFields, MultiMatchTypeMostFields:
+		matchQueries := make([]bluge.Query, 0, len(fields))
+		for _, field := range fields {
+			matchQuery := bluge.NewMatchQuery(opts.Query).SetField(field.field).SetBoost(field.boost).SetOperator(operator)
+			if opts.Prefix > 0 {
+				matchQuery.SetPrefix(opts.Prefix)
+			}
+			if opts.Fuzziness > 0 {
+				matchQuery.SetFuzziness(opts.Fuzziness)
+			}
+			if fieldAnalyzer, ok := analyzers[field.field]; ok {
+				matchQuery.SetAnalyzer(fieldAnalyzer)
+			}
+			matchQueries = append(matchQueries, matchQuery)
+		}
+		if multiMatchType == MultiMatchTypeMostFields {
+			// The scores of the matching fields are summed.
+			booleanQuery := bluge.NewBooleanQuery().AddShould(matchQueries...)
+			if opts.Boost >= 0.0 {
+				booleanQuery.SetBoost(opts.Boost)
+			}
+			return booleanQuery, nil
+		}
+		disMaxQuery := NewDisMaxQuery(matchQueries...).SetTieBreaker(opts.TieBreaker)
+		if opts.Boost >= 0.0 {
+			disMaxQuery.SetBoost(opts.Boost)
+		}
+		return disMaxQuery, nil
+	case MultiMatchTypePhrase:
+		disMaxQuery := NewDisMaxQuery().SetTieBreaker(opts.TieBreaker)
+		for _, field := range fields {
+			matchPhraseQuery := bluge.NewMatchPhraseQuery(opts.Query).SetField(field.field).SetBoost(field.boost)
+			if opts.Slop > 0 {
+				matchPhraseQuery.SetSlop(opts.Slop)
+			}
+			if fieldAnalyzer, ok := analyzers[field.field]; ok {
+				matchPhraseQuery.SetAnalyzer(fieldAnalyzer)
+			}
+			disMaxQuery.AddQuery(matchPhraseQuery)
+		}
+		if opts.Boost >= 0.0 {
+			disMaxQuery.SetBoost(opts.Boost)
+		}
+		return disMaxQuery, nil
+	default:
+		return &crossFieldsQuery{
+			opts:             opts,
+			fields:           fields,
+			analyzerSettings: analyzerSettings,
+			analyzers:        analyzers,
+			operator:         operator,
+		}, nil
+	}
+}
+
+// crossFieldsQuery searches the fields as if they were one field.
+// The fields are grouped by their analyzers, and each term of a group must match,
+// or should match for the OR operator, in any field of the group.
+// Fields without an analyzer are analyzed with the default analyzer of the index, the same as the other types.
+// The query text is analyzed when the searcher is built, since the default analyzer is given by the searcher options.
+type crossFieldsQuery struct {
+	opts             MultiMatchQueryOptions
+	fields           []boostedField
+	analyzerSettings map[string]analyzer.AnalyzerSetting
+	analyzers        map[string]*analysis.Analyzer
+	operator         bluge.MatchQueryOperator
+}
+
+func (q *crossFieldsQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	query, err := q.query(options.DefaultAnalyzer)
+	if err != nil {
+		return nil, err
+	}
+
+	return query.Searcher(i, options)
+}
+
+// query builds the term queries of the fields from the query text.
+func (q *crossFieldsQuery) query(defaultAnalyzer *analysis.Analyzer) (bluge.Query, error) {
+	if defaultAnalyzer == nil {
+		defaultAnalyzer = blugeanalyzer.NewStandardAnalyzer()
+	}
+
+	opts := q.opts
+	groupKeys := make([]string, 0)
+	groupFields := make(map[string][]boostedField)
+	groupAnalyzers := make(map[string]*analysis.Analyzer)
+	for _, field := range q.fields {
+		key := ""
+		fieldAnalyzer := defaultAnalyzer
+		if analyzerSetting, ok := q.analyzerSettings[field.field]; ok {
+			keyBytes, err := json.Marshal(analyzerSetting)
+			if err != nil {
+				return nil, err
+			}
+			key = string(keyBytes)
+			fieldAnalyzer = q.analyzers[field.field]
+		}
+		if _, ok := groupFields[key]; !ok {
+			groupKeys = append(groupKeys, key)
+			groupAnalyzers[key] = fieldAnalyzer
+		}
+		groupFields[key] = append(groupFields[key], field)
+	}
+
+	groupQueries := make([]bluge.Query, 0, len(groupKeys))
+	for _, key := range groupKeys {
+		tokens := groupAnalyzers[key].Analyze([]byte(opts.Query))
+		if len(tokens) == 0 {
+			continue
+		}
+
+		groupQuery := bluge.NewBooleanQuery()
+		for _, token := range tokens {
+			termQuery := NewDisMaxQuery().SetTieBreaker(opts.TieBreaker)
+			for _, field := range groupFields[key] {
+				if opts.Fuzziness > 0 {
+					termQuery.AddQuery(bluge.NewFuzzyQuery(string(token.Term)).SetField(field.field).SetFuzziness(opts.Fuzziness).SetPrefix(opts.Prefix).SetBoost(field.boost))
+					continue
+				}
+				termQuery.AddQuery(bluge.NewTermQuery(string(token.Term)).SetField(field.field).SetBoost(field.boost))
+			}
+			if q.operator == bluge.MatchQueryOperatorAnd {
+				groupQuery.AddMust(termQuery)
+			} else {
+				groupQuery.AddShould(termQuery)
+			}
+		}
+		groupQueries = append(groupQueries, groupQuery)
+	}
+
+	if len(groupQueries) == 1 {
+		groupQuery := groupQueries[0].(*bluge.BooleanQuery)
+		if opts.Boost >= 0.0 {
+			groupQuery.SetBoost(opts.Boost)
+		}
+		return groupQuery, nil
+	}
+
+	disMaxQuery := NewDisMaxQuery(groupQueries...).SetTieBreaker(opts.TieBreaker)
+	if opts.Boost >= 0.0 {
+		disMaxQuery.SetBoost(opts.Boost)
+	}
+
+	return disMaxQuery, nil
+}
@@ -0,0 +1,92 @@
+package queries
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/blugelabs/bluge/analysis/analyzer"
+)
+
+func TestNewMultiMatchQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_multi_match_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewMultiMatchQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if _, ok := query.(*DisMaxQuery); !ok {
+		t.Fatalf("expected a dis max query, but %T\n", query)
+	}
+}
+
+func TestMultiMatchQueryTypes(t *testing.T) {
+	cases := []struct {
+		multiMatchType string
+		expected       string
+	}{
+		{multiMatchType: "best_fields", expected: `((+title:search +title:engine)^3 | (+body:search +body:engine))~0.3`},
+		{multiMatchType: "most_fields", expected: `((+title:search +title:engine)^3 (+body:search +body:engine))`},
+		{multiMatchType: "phrase", expected: `(title:"search engine"^3 | body:"search engine")~0.3`},
+		{multiMatchType: "cross_fields", expected: `(+(title:search^3 | body:search)~0.3 +(title:engine^3 | body:engine)~0.3)`},
+	}
+
+	for _, c := range cases {
+		opts := map[string]interface{}{
+			"query":       "search engine",
+			"fields":      []interface{}{"title^3", "body"},
+			"type":        c.multiMatchType,
+			"tie_breaker": 0.3,
+			"operator":    "AND",
+		}
+		query, err := NewMultiMatchQueryWithMap(opts)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if rewritten := RewriteQuery(query, "_all", analyzer.NewStandardAnalyzer()); rewritten != c.expected {
+			t.Fatalf("expected %v, but %v\n", c.expected, rewritten)
+		}
+	}
+
+	if _, err := NewMultiMatchQueryWithMap(map[string]interface{}{"query": "hello", "fields": []interface{}{"title^x"}}); err == nil {
+		t.Fatalf("expected an error for the invalid boost\n")
+	}
+	if _, err := NewMultiMatchQueryWithMap(map[string]interface{}{"query": "hello", "fields": []interface{}{"title"}, "type": "unknown"}); err == nil {
+		t.Fatalf("expected an error for the unknown type\n")
+	}
+}
+
+func TestMultiMatchQueryCrossFieldsDefaultAnalyzer(t *testing.T) {
+	opts := map[string]interface{}{
+		"query":    "Search Engine",
+		"fields":   []interface{}{"title", "body"},
+		"type":     "cross_fields",
+		"operator": "AND",
+		"analyzers": map[string]interface{}{
+			"title": map[string]interface{}{
+				"tokenizer": map[string]interface{}{
+					"name": "whitespace",
+				},
+			},
+		},
+	}
+	query, err := NewMultiMatchQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// The fields without an analyzer are analyzed with the default analyzer of the index.
+	expected := `((+(title:Search) +(title:Engine)) | (+(body:Search Engine)))`
+	if rewritten := RewriteQuery(query, "_all", analyzer.NewKeywordAnalyzer()); rewritten != expected {
+		t.Fatalf("expected %v, but %v\n", expected, rewritten)
+	}
+}
@@ -1,8 +1,13 @@
 package queries
 
 import (
+	"encoding/json"
+
 	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/analysis/analyzer"
 	"github.com/mosuka/phalanx/errors"
+	"github.com/mosuka/phalanx/mapping"
 )
 
 type QueryType int
@@ -10,21 +15,30 @@ type QueryType int
 const (
 	QueryTypeUnknown QueryType = iota
 	QueryTypeBoolean
+	QueryTypeConstantScore
 	QueryTypeDateRange
+	QueryTypeDisMax
+	QueryTypeExists
+	QueryTypeFunctionScore
 	QueryTypeFuzzy
 	QueryTypeGeoBoundingBox
 	QueryTypeGeoBoundingPolygon
 	QueryTypeGeoDistance
+	QueryTypeIds
+	QueryTypeKNN
 	QueryTypeMatch
 	QueryTypeMatchAll
 	QueryTypeMatchNone
 	QueryTypeMatchPhrase
+	QueryTypeMoreLikeThis
+	QueryTypeMultiMatch
 	QueryTypeMultiPhrase
 	QueryTypeNumericRange
 	QueryTypePrefix
 	QueryTypeQueryString
 	QueryTypeRegexp
 	QueryTypeTerm
+	QueryTypeTerms
 	QueryTypeTermRange
 	QueryTypeWildcard
 )
@@ -34,42 +48,60 @@ var (
 	QueryType_name = map[QueryType]string{
 		QueryTypeUnknown:            "unknown",
 		QueryTypeBoolean:            "boolean",
+		QueryTypeConstantScore:      "constant_score",
 		QueryTypeDateRange:          "date_range",
+		QueryTypeDisMax:             "dis_max",
+		QueryTypeExists:             "exists",
+		QueryTypeFunctionScore:      "function_score",
 		QueryTypeFuzzy:              "fuzzy",
 		QueryTypeGeoBoundingBox:     "geo_bounding_box",
 		QueryTypeGeoBoundingPolygon: "geo_bounding_polygon",
 		QueryTypeGeoDistance:        "geo_distance",
+		QueryTypeIds:                "ids",
+		QueryTypeKNN:                "knn",
 		QueryTypeMatch:              "match",
 		QueryTypeMatchAll:           "match_all",
 		QueryTypeMatchNone:          "match_none",
 		QueryTypeMatchPhrase:        "match_phrase",
+		QueryTypeMoreLikeThis:       "more_like_this",
+		QueryTypeMultiMatch:         "multi_match",
 		QueryTypeMultiPhrase:        "multi_phrase",
 		QueryTypeNumericRange:       "numeric_range",
 		QueryTypePrefix:             "prefix",
 		QueryTypeQueryString:        "query_string",
 		QueryTypeRegexp:             "regexp",
 		QueryTypeTerm:               "term",
+		QueryTypeTerms:              "terms",
 		QueryTypeTermRange:          "term_range",
 		QueryTypeWildcard:           "wildcard",
 	}
 	QueryType_value = map[string]QueryType{
 		"unknown":              QueryTypeUnknown,
 		"boolean":              QueryTypeBoolean,
+		"constant_score":       QueryTypeConstantScore,
 		"date_range":           QueryTypeDateRange,
+		"dis_max":              QueryTypeDisMax,
+		"exists":               QueryTypeExists,
+		"function_score":       QueryTypeFunctionScore,
 		"fuzzy":                QueryTypeFuzzy,
 		"geo_bounding_box":     QueryTypeGeoBoundingBox,
 		"geo_bounding_polygon": QueryTypeGeoBoundingPolygon,
 		"geo_distance":         QueryTypeGeoDistance,
+		"ids":                  QueryTypeIds,
+		"knn":                  QueryTypeKNN,
 		"match":                QueryTypeMatch,
 		"match_all":            QueryTypeMatchAll,
 		"match_none":           QueryTypeMatchNone,
 		"match_phrase":         QueryTypeMatchPhrase,
+		"more_like_this":       QueryTypeMoreLikeThis,
+		"multi_match":          QueryTypeMultiMatch,
 		"multi_phrase":         QueryTypeMultiPhrase,
 		"numeric_range":        QueryTypeNumericRange,
 		"prefix":               QueryTypePrefix,
 		"query_string":         QueryTypeQueryString,
 		"regexp":               QueryTypeRegexp,
 		"term":                 QueryTypeTerm,
+		"terms":                QueryTypeTerms,
 		"term_range":           QueryTypeTermRange,
 		"wildcard":             QueryTypeWildcard,
 	}
@@ -79,8 +111,16 @@ func NewQuery(queryType string, queryOpts map[string]interface{}) (bluge.Query,
 	switch QueryType_value[queryType] {
 	case QueryTypeBoolean:
 		return NewBooleanQueryWithMap(queryOpts)
+	case QueryTypeConstantScore:
+		return NewConstantScoreQueryWithMap(queryOpts)
 	case QueryTypeDateRange:
 		return NewDateRangeQueryWithMap(queryOpts)
+	case QueryTypeDisMax:
+		return NewDisMaxQueryWithMap(queryOpts)
+	case QueryTypeExists:
+		return NewExistsQueryWithMap(queryOpts)
+	case QueryTypeFunctionScore:
+		return NewFunctionScoreQueryWithMap(queryOpts)
 	case QueryTypeFuzzy:
 		return NewFuzzyQueryWithMap(queryOpts)
 	case QueryTypeGeoBoundingBox:
@@ -89,6 +129,10 @@ func NewQuery(queryType string, queryOpts map[string]interface{}) (bluge.Query,
 		return NewGeoBoundingPolygonQueryWithMap(queryOpts)
 	case QueryTypeGeoDistance:
 		return NewGeoDistanceQueryWithMap(queryOpts)
+	case QueryTypeIds:
+		return NewIdsQueryWithMap(queryOpts)
+	case QueryTypeKNN:
+		return NewKNNQueryWithMap(queryOpts)
 	case QueryTypeMatch:
 		return NewMatchQueryWithMap(queryOpts)
 	case QueryTypeMatchAll:
@@ -97,6 +141,10 @@ func NewQuery(queryType string, queryOpts map[string]interface{}) (bluge.Query,
 		return NewMatchNoneQueryWithMap(queryOpts)
 	case QueryTypeMatchPhrase:
 		return NewMatchPhraseQueryWithMap(queryOpts)
+	case QueryTypeMoreLikeThis:
+		return NewMoreLikeThisQueryWithMap(queryOpts)
+	case QueryTypeMultiMatch:
+		return NewMultiMatchQueryWithMap(queryOpts)
 	case QueryTypeMultiPhrase:
 		return NewMultiPhraseQueryWithMap(queryOpts)
 	case QueryTypeNumericRange:
@@ -109,6 +157,8 @@ func NewQuery(queryType string, queryOpts map[string]interface{}) (bluge.Query,
 		return NewRegexpQueryWithMap(queryOpts)
 	case QueryTypeTerm:
 		return NewTermQueryWithMap(queryOpts)
+	case QueryTypeTerms:
+		return NewTermsQueryWithMap(queryOpts)
 	case QueryTypeTermRange:
 		return NewTermRangeQueryWithMap(queryOpts)
 	case QueryTypeWildcard:
@@ -117,3 +167,330 @@ func NewQuery(queryType string, queryOpts map[string]interface{}) (bluge.Query,
 		return nil, errors.ErrUnknownQueryType
 	}
 }
+
+// ApplyIndexMapping fills the query options that are omitted but defined in the index mapping,
+// such as the datetime formats of date_range queries, the search analyzers of the fields
+// and the similarities of dense_vector fields.
+// Named analyzers are replaced with their definitions in the analysis settings of the index.
+// Nested queries are also filled.
+func ApplyIndexMapping(queryType string, queryOpts map[string]interface{}, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) {
+	if queryOpts == nil {
+		return
+	}
+
+	for _, querySetting := range nestedQuerySettings(queryType, queryOpts) {
+		applyIndexMappingToQuerySetting(querySetting, indexMapping, analysisSetting)
+	}
+
+	switch QueryType_value[queryType] {
+	case QueryTypeDateRange:
+		if _, ok := queryOpts["formats"]; !ok {
+			if field, ok := queryOpts["field"].(string); ok {
+				formats := make([]interface{}, 0)
+				for _, format := range indexMapping.GetDateTimeFormats(field) {
+					formats = append(formats, format)
+				}
+				queryOpts["formats"] = formats
+			}
+		}
+	case QueryTypeFunctionScore:
+		functions, ok := queryOpts["functions"].([]interface{})
+		if !ok {
+			break
+		}
+		for _, function := range functions {
+			functionOpts, ok := function.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			// Datetime origins of decay functions are parsed with the formats of the field.
+			for _, curve := range []string{"gauss", "exp", "linear"} {
+				decayOpts, ok := functionOpts[curve].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if _, ok := decayOpts["formats"]; ok {
+					continue
+				}
+				if field, ok := decayOpts["field"].(string); ok {
+					formats := make([]interface{}, 0)
+					for _, format := range indexMapping.GetDateTimeFormats(field) {
+						formats = append(formats, format)
+					}
+					decayOpts["formats"] = formats
+				}
+			}
+		}
+	case QueryTypeKNN:
+		if _, ok := queryOpts["similarity"]; !ok {
+			if field, ok := queryOpts["field"].(string); ok {
+				if _, similarity, err := indexMapping.GetDenseVectorSetting(field); err == nil {
+					queryOpts["similarity"] = string(similarity)
+				}
+			}
+		}
+	case QueryTypeTerms:
+		// The terms are converted according to the type of the field.
+		if _, ok := queryOpts["field_type"]; !ok {
+			if field, ok := queryOpts["field"].(string); ok {
+				if fieldType, err := indexMapping.GetFieldType(field); err == nil {
+					queryOpts["field_type"] = string(fieldType)
+				}
+			}
+		}
+	case QueryTypeMatch, QueryTypeMatchPhrase:
+		applySearchAnalyzer(queryOpts, indexMapping, analysisSetting)
+	case QueryTypeMoreLikeThis, QueryTypeMultiMatch:
+		applyMultiMatchAnalyzers(queryOpts, indexMapping, analysisSetting)
+	case QueryTypeQueryString:
+		applySearchAnalyzers(queryOpts, indexMapping, analysisSetting)
+	}
+}
+
+// applySearchAnalyzer sets the search analyzer of the field to the query
+// unless the query specifies the analyzer or the language.
+// The search analyzer defaults to the analyzer of the field.
+func applySearchAnalyzer(queryOpts map[string]interface{}, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) {
+	if _, ok := queryOpts["language"]; ok {
+		return
+	}
+
+	// The analyzer of the query overrides the analyzer of the field.
+	if analyzerOpts, ok := queryOpts["analyzer"]; ok {
+		if resolvedOpts, err := resolveAnalyzerOptions(analyzerOpts, analysisSetting); err == nil {
+			queryOpts["analyzer"] = resolvedOpts
+		}
+		return
+	}
+
+	field, ok := queryOpts["field"].(string)
+	if !ok {
+		return
+	}
+
+	analyzerSetting, err := indexMapping.GetSearchAnalyzerSetting(field)
+	if err != nil {
+		return
+	}
+	resolved, err := analysisSetting.Resolve(analyzerSetting)
+	if err != nil {
+		return
+	}
+
+	if resolved.Language != "" {
+		// The language of the query text is detected if the field detects the language of its values.
+		queryOpts["language"] = resolved.Language
+		return
+	}
+	if analyzerOpts, err := analyzerSettingToMap(resolved); err == nil {
+		queryOpts["analyzer"] = analyzerOpts
+	}
+}
+
+// applySearchAnalyzers sets the search analyzers of the text fields in the index mapping
+// to the query string query, unless the query specifies the analyzers of the fields.
+func applySearchAnalyzers(queryOpts map[string]interface{}, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) {
+	analyzers, ok := queryOpts["analyzers"].(map[string]interface{})
+	if !ok {
+		analyzers = make(map[string]interface{})
+	}
+
+	// The analyzers of the query override the analyzers of the fields.
+	for field, analyzerOpts := range analyzers {
+		if resolvedOpts, err := resolveAnalyzerOptions(analyzerOpts, analysisSetting); err == nil {
+			analyzers[field] = resolvedOpts
+		}
+	}
+
+	for field, fieldSetting := range indexMapping {
+		if _, ok := analyzers[field]; ok || fieldSetting.FieldType != mapping.TextField {
+			continue
+		}
+		analyzerSetting, err := indexMapping.GetSearchAnalyzerSetting(field)
+		if err != nil {
+			continue
+		}
+		resolved, err := analysisSetting.Resolve(analyzerSetting)
+		if err != nil {
+			continue
+		}
+		if analyzerOpts, err := analyzerSettingToMap(resolved); err == nil {
+			analyzers[field] = analyzerOpts
+		}
+	}
+
+	if len(analyzers) > 0 {
+		queryOpts["analyzers"] = analyzers
+	}
+}
+
+// applyMultiMatchAnalyzers sets the search analyzers of the fields to the multi match query,
+// unless the query specifies the analyzer or the analyzers of the fields.
+func applyMultiMatchAnalyzers(queryOpts map[string]interface{}, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) {
+	// The analyzer of the query overrides the analyzers of the fields.
+	if analyzerOpts, ok := queryOpts["analyzer"]; ok {
+		if resolvedOpts, err := resolveAnalyzerOptions(analyzerOpts, analysisSetting); err == nil {
+			queryOpts["analyzer"] = resolvedOpts
+		}
+		return
+	}
+
+	analyzers, ok := queryOpts["analyzers"].(map[string]interface{})
+	if !ok {
+		analyzers = make(map[string]interface{})
+	}
+	for field, analyzerOpts := range analyzers {
+		if resolvedOpts, err := resolveAnalyzerOptions(analyzerOpts, analysisSetting); err == nil {
+			analyzers[field] = resolvedOpts
+		}
+	}
+
+	fields, ok := queryOpts["fields"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, value := range fields {
+		fieldValue, ok := value.(string)
+		if !ok {
+			continue
+		}
+		field, err := parseBoostedField(fieldValue)
+		if err != nil {
+			continue
+		}
+		if _, ok := analyzers[field.field]; ok {
+			continue
+		}
+		analyzerSetting, err := indexMapping.GetSearchAnalyzerSetting(field.field)
+		if err != nil {
+			continue
+		}
+		resolved, err := analysisSetting.Resolve(analyzerSetting)
+		if err != nil {
+			continue
+		}
+		if analyzerOpts, err := analyzerSettingToMap(resolved); err == nil {
+			analyzers[field.field] = analyzerOpts
+		}
+	}
+
+	if len(analyzers) > 0 {
+		queryOpts["analyzers"] = analyzers
+	}
+}
+
+// resolveAnalyzerOptions replaces the names in the analyzer options with their definitions.
+func resolveAnalyzerOptions(analyzerOpts interface{}, analysisSetting analyzer.AnalysisSetting) (map[string]interface{}, error) {
+	bytes, err := json.Marshal(analyzerOpts)
+	if err != nil {
+		return nil, err
+	}
+	var analyzerSetting analyzer.AnalyzerSetting
+	if err := json.Unmarshal(bytes, &analyzerSetting); err != nil {
+		return nil, err
+	}
+
+	resolved, err := analysisSetting.Resolve(analyzerSetting)
+	if err != nil {
+		return nil, err
+	}
+
+	return analyzerSettingToMap(resolved)
+}
+
+func analyzerSettingToMap(analyzerSetting analyzer.AnalyzerSetting) (map[string]interface{}, error) {
+	bytes, err := json.Marshal(analyzerSetting)
+	if err != nil {
+		return nil, err
+	}
+	var analyzerOpts map[string]interface{}
+	if err := json.Unmarshal(bytes, &analyzerOpts); err != nil {
+		return nil, err
+	}
+
+	return analyzerOpts, nil
+}
+
+// nestedQuerySettings returns the settings of the queries nested in the query,
+// such as the clauses of boolean queries and the filters of knn queries.
+func nestedQuerySettings(queryType string, queryOpts map[string]interface{}) []interface{} {
+	querySettings := make([]interface{}, 0)
+
+	switch QueryType_value[queryType] {
+	case QueryTypeBoolean:
+		for _, clause := range []string{"must", "must_not", "should", "filter"} {
+			if clauseSettings, ok := queryOpts[clause].([]interface{}); ok {
+				querySettings = append(querySettings, clauseSettings...)
+			}
+		}
+	case QueryTypeConstantScore, QueryTypeKNN:
+		if filter, ok := queryOpts["filter"]; ok {
+			querySettings = append(querySettings, filter)
+		}
+	case QueryTypeDisMax:
+		if queries, ok := queryOpts["queries"].([]interface{}); ok {
+			querySettings = append(querySettings, queries...)
+		}
+	case QueryTypeFunctionScore:
+		if query, ok := queryOpts["query"]; ok {
+			querySettings = append(querySettings, query)
+		}
+		if functions, ok := queryOpts["functions"].([]interface{}); ok {
+			for _, function := range functions {
+				if functionOpts, ok := function.(map[string]interface{}); ok {
+					if filter, ok := functionOpts["filter"]; ok {
+						querySettings = append(querySettings, filter)
+					}
+				}
+			}
+		}
+	}
+
+	return querySettings
+}
+
+func applyIndexMappingToQuerySetting(querySetting interface{}, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) {
+	querySettingMap, ok := querySetting.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	queryType, ok := querySettingMap["type"].(string)
+	if !ok {
+		return
+	}
+
+	queryOpts, ok := querySettingMap["options"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	ApplyIndexMapping(queryType, queryOpts, indexMapping, analysisSetting)
+}
+
+// visitMatchingNumbers calls the visitor with the document numbers matching the query, in ascending order.
+// The documents are only used for filtering, so they are not scored.
+func visitMatchingNumbers(i search.Reader, query bluge.Query, options search.SearcherOptions, visitor func(number uint64) error) error {
+	s, err := query.Searcher(i, search.SearcherOptions{
+		SimilarityForField: options.SimilarityForField,
+		DefaultSearchField: options.DefaultSearchField,
+		DefaultAnalyzer:    options.DefaultAnalyzer,
+		Score:              "none",
+	})
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	ctx := search.NewSearchContext(s.DocumentMatchPoolSize(), 0)
+	dm, err := s.Next(ctx)
+	for err == nil && dm != nil {
+		if err := visitor(dm.Number); err != nil {
+			return err
+		}
+		ctx.DocumentMatchPool.Put(dm)
+		dm, err = s.Next(ctx)
+	}
+
+	return err
+}
@@ -0,0 +1,108 @@
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+func TestApplyIndexMapping(t *testing.T) {
+	var indexMapping mapping.IndexMapping
+	if err := json.Unmarshal([]byte(`{
+	  "title": {
+	    "type": "text",
+	    "analyzer": {"tokenizer": {"name": "single_token"}, "token_filters": [{"name": "lower_case"}]}
+	  },
+	  "description": {
+	    "type": "text",
+	    "analyzer": {"tokenizer": {"name": "unicode"}},
+	    "search_analyzer": "lower_whitespace"
+	  },
+	  "embedding": {
+	    "type": "dense_vector",
+	    "dimension": 3,
+	    "similarity": "l2"
+	  }
+	}`), &indexMapping); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var analysisSetting analyzer.AnalysisSetting
+	if err := json.Unmarshal([]byte(`{
+	  "analyzers": {
+	    "lower_whitespace": {"tokenizer": {"name": "whitespace"}, "token_filters": [{"name": "lower_case"}]}
+	  }
+	}`), &analysisSetting); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	cases := []struct {
+		opts     string
+		expected []string
+	}{
+		// The analyzer of the field.
+		{opts: `{"match": "Hello World", "field": "title"}`, expected: []string{"hello world"}},
+		// The named search analyzer of the field.
+		{opts: `{"match": "Hello World", "field": "description"}`, expected: []string{"hello", "world"}},
+		// The named analyzer of the query overrides the analyzer of the field.
+		{opts: `{"match": "Hello World", "field": "title", "analyzer": "lower_whitespace"}`, expected: []string{"hello", "world"}},
+	}
+	for _, c := range cases {
+		var opts map[string]interface{}
+		if err := json.Unmarshal([]byte(c.opts), &opts); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		// Nested queries are also filled.
+		booleanOpts := map[string]interface{}{
+			"must": []interface{}{
+				map[string]interface{}{"type": "match", "options": opts},
+			},
+		}
+		ApplyIndexMapping("boolean", booleanOpts, indexMapping, analysisSetting)
+
+		query, err := NewMatchQueryWithMap(opts)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if query.Analyzer() == nil {
+			t.Fatalf("analyzer is not applied to %v\n", c.opts)
+		}
+		tokens := query.Analyzer().Analyze([]byte(query.Match()))
+		if len(tokens) != len(c.expected) {
+			t.Fatalf("expected %v, but %v\n", c.expected, tokens)
+		}
+		for i, token := range tokens {
+			if string(token.Term) != c.expected[i] {
+				t.Fatalf("expected %v, but %v\n", c.expected, tokens)
+			}
+		}
+	}
+
+	// An analyzer that does not exist is an error instead of being ignored.
+	opts := map[string]interface{}{"match": "Hello World", "field": "title", "analyzer": "unknown"}
+	ApplyIndexMapping("match", opts, indexMapping, analysisSetting)
+	if _, err := NewMatchQueryWithMap(opts); err == nil {
+		t.Fatalf("expected an error for the unknown analyzer\n")
+	}
+
+	opts = map[string]interface{}{"query": "title:Hello"}
+	ApplyIndexMapping("query_string", opts, indexMapping, analysisSetting)
+	analyzers, ok := opts["analyzers"].(map[string]interface{})
+	if !ok || analyzers["title"] == nil || analyzers["description"] == nil || analyzers["embedding"] != nil {
+		t.Fatalf("analyzers are not applied: %v\n", opts["analyzers"])
+	}
+	query, err := NewQueryStringQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if rewritten := RewriteQuery(query, mapping.AllFieldName, nil); rewritten != "(title:hello)" {
+		t.Fatalf("expected (title:hello), but %v\n", rewritten)
+	}
+
+	opts = map[string]interface{}{"field": "embedding", "vector": []interface{}{0.1, 0.2, 0.3}}
+	ApplyIndexMapping("knn", opts, indexMapping, analysisSetting)
+	if opts["similarity"] != "l2" {
+		t.Fatalf("expected l2, but %v\n", opts["similarity"])
+	}
+}
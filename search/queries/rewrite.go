@@ -0,0 +1,235 @@
+package queries
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+)
+
+// RewriteQuery renders the query in a human-readable form like the Lucene query syntax,
+// so that users can see how the query is interpreted.
+// Match queries are analyzed with their analyzer, or the default analyzer if they do not have one.
+func RewriteQuery(query bluge.Query, defaultField string, defaultAnalyzer *analysis.Analyzer) string {
+	r := &queryRewriter{
+		defaultField:    defaultField,
+		defaultAnalyzer: defaultAnalyzer,
+	}
+
+	return r.rewrite(query)
+}
+
+type queryRewriter struct {
+	defaultField    string
+	defaultAnalyzer *analysis.Analyzer
+}
+
+func (r *queryRewriter) field(field string) string {
+	if field == "" {
+		return r.defaultField
+	}
+	return field
+}
+
+func rewriteBoost(boost float64) string {
+	if boost == 1.0 {
+		return ""
+	}
+	return "^" + strconv.FormatFloat(boost, 'f', -1, 64)
+}
+
+func rewriteFuzziness(fuzziness int) string {
+	if fuzziness == 0 {
+		return ""
+	}
+	return fmt.Sprintf("~%d", fuzziness)
+}
+
+func rewriteRange(min string, inclusiveMin bool, max string, inclusiveMax bool) string {
+	open := "{"
+	if inclusiveMin {
+		open = "["
+	}
+	close := "}"
+	if inclusiveMax {
+		close = "]"
+	}
+	return fmt.Sprintf("%s%s TO %s%s", open, min, max, close)
+}
+
+func rewriteNumeric(value float64) string {
+	if math.IsInf(value, 0) {
+		return "*"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func rewriteDatetime(value time.Time) string {
+	if value.IsZero() {
+		return "*"
+	}
+	return value.Format(time.RFC3339Nano)
+}
+
+func rewriteTerm(value string) string {
+	if value == "" {
+		return "*"
+	}
+	return value
+}
+
+func (r *queryRewriter) rewrite(query bluge.Query) string {
+	switch q := query.(type) {
+	case *bluge.BooleanQuery:
+		clauses := make([]string, 0)
+		for _, subQuery := range q.Musts() {
+			// Filter clauses are added as must clauses scored zero.
+			if constantScoreQuery, ok := subQuery.(*ConstantScoreQuery); ok && constantScoreQuery.Boost() == 0.0 {
+				clauses = append(clauses, "#"+r.rewrite(constantScoreQuery.Filter()))
+				continue
+			}
+			clauses = append(clauses, "+"+r.rewrite(subQuery))
+		}
+		for _, subQuery := range q.MustNots() {
+			clauses = append(clauses, "-"+r.rewrite(subQuery))
+		}
+		for _, subQuery := range q.Shoulds() {
+			clauses = append(clauses, r.rewrite(subQuery))
+		}
+		minShould := ""
+		if q.MinShould() > 0 && len(q.Shoulds()) > 0 {
+			minShould = fmt.Sprintf("~%d", q.MinShould())
+		}
+		return fmt.Sprintf("(%s)%s%s", strings.Join(clauses, " "), minShould, rewriteBoost(q.Boost()))
+	case *bluge.TermQuery:
+		return fmt.Sprintf("%s:%s%s", r.field(q.Field()), q.Term(), rewriteBoost(q.Boost()))
+	case *bluge.MatchQuery:
+		analyzer := q.Analyzer()
+		if analyzer == nil {
+			analyzer = r.defaultAnalyzer
+		}
+		terms := []string{q.Match()}
+		if analyzer != nil {
+			terms = terms[:0]
+			for _, token := range analyzer.Analyze([]byte(q.Match())) {
+				terms = append(terms, string(token.Term))
+			}
+		}
+		clauses := make([]string, 0, len(terms))
+		for _, term := range terms {
+			clause := fmt.Sprintf("%s:%s%s", r.field(q.Field()), term, rewriteFuzziness(q.Fuzziness()))
+			if q.Operator() == bluge.MatchQueryOperatorAnd {
+				clause = "+" + clause
+			}
+			clauses = append(clauses, clause)
+		}
+		if len(clauses) == 1 {
+			return clauses[0] + rewriteBoost(q.Boost())
+		}
+		return fmt.Sprintf("(%s)%s", strings.Join(clauses, " "), rewriteBoost(q.Boost()))
+	case *bluge.MatchPhraseQuery:
+		slop := ""
+		if q.Slop() > 0 {
+			slop = fmt.Sprintf("~%d", q.Slop())
+		}
+		return fmt.Sprintf("%s:%q%s%s", r.field(q.Field()), q.Phrase(), slop, rewriteBoost(q.Boost()))
+	case *bluge.MultiPhraseQuery:
+		positions := make([]string, 0, len(q.Terms()))
+		for _, terms := range q.Terms() {
+			if len(terms) == 1 {
+				positions = append(positions, terms[0])
+				continue
+			}
+			positions = append(positions, "("+strings.Join(terms, "|")+")")
+		}
+		slop := ""
+		if q.Slop() > 0 {
+			slop = fmt.Sprintf("~%d", q.Slop())
+		}
+		return fmt.Sprintf("%s:\"%s\"%s%s", r.field(q.Field()), strings.Join(positions, " "), slop, rewriteBoost(q.Boost()))
+	case *bluge.PrefixQuery:
+		return fmt.Sprintf("%s:%s*%s", r.field(q.Field()), q.Prefix(), rewriteBoost(q.Boost()))
+	case *bluge.RegexpQuery:
+		return fmt.Sprintf("%s:/%s/%s", r.field(q.Field()), q.Regexp(), rewriteBoost(q.Boost()))
+	case *bluge.WildcardQuery:
+		return fmt.Sprintf("%s:%s%s", r.field(q.Field()), q.Wildcard(), rewriteBoost(q.Boost()))
+	case *bluge.FuzzyQuery:
+		return fmt.Sprintf("%s:%s~%d%s", r.field(q.Field()), q.Term(), q.Fuzziness(), rewriteBoost(q.Boost()))
+	case *bluge.TermRangeQuery:
+		min, inclusiveMin := q.Min()
+		max, inclusiveMax := q.Max()
+		return fmt.Sprintf("%s:%s%s", r.field(q.Field()), rewriteRange(rewriteTerm(min), inclusiveMin, rewriteTerm(max), inclusiveMax), rewriteBoost(q.Boost()))
+	case *bluge.NumericRangeQuery:
+		min, inclusiveMin := q.Min()
+		max, inclusiveMax := q.Max()
+		return fmt.Sprintf("%s:%s%s", r.field(q.Field()), rewriteRange(rewriteNumeric(min), inclusiveMin, rewriteNumeric(max), inclusiveMax), rewriteBoost(q.Boost()))
+	case *bluge.DateRangeQuery:
+		start, inclusiveStart := q.Start()
+		end, inclusiveEnd := q.End()
+		return fmt.Sprintf("%s:%s%s", r.field(q.Field()), rewriteRange(rewriteDatetime(start), inclusiveStart, rewriteDatetime(end), inclusiveEnd), rewriteBoost(q.Boost()))
+	case *bluge.GeoBoundingBoxQuery:
+		return fmt.Sprintf("%s:geo_bounding_box(top_left=%v, bottom_right=%v)%s", r.field(q.Field()), q.TopLeft(), q.BottomRight(), rewriteBoost(q.Boost()))
+	case *bluge.GeoBoundingPolygonQuery:
+		points := make([]string, 0, len(q.Points()))
+		for _, point := range q.Points() {
+			points = append(points, fmt.Sprintf("[%v %v]", point.Lon, point.Lat))
+		}
+		return fmt.Sprintf("%s:geo_bounding_polygon(points=[%s])%s", r.field(q.Field()), strings.Join(points, " "), rewriteBoost(q.Boost()))
+	case *bluge.GeoDistanceQuery:
+		return fmt.Sprintf("%s:geo_distance(location=%v, distance=%s)%s", r.field(q.Field()), q.Location(), q.Distance(), rewriteBoost(q.Boost()))
+	case *bluge.MatchAllQuery:
+		return "*:*" + rewriteBoost(q.Boost())
+	case *bluge.MatchNoneQuery:
+		return "-*:*" + rewriteBoost(q.Boost())
+	case *TermsQuery:
+		return fmt.Sprintf("%s:(%s)%s", r.field(q.Field()), strings.Join(q.Terms(), " "), rewriteBoost(q.Boost()))
+	case *ExistsQuery:
+		return fmt.Sprintf("_exists_:%s%s", q.Field(), rewriteBoost(q.Boost()))
+	case *MoreLikeThisQuery:
+		exclude := ""
+		if len(q.ExcludeIds()) > 0 {
+			exclude = fmt.Sprintf(", exclude=[%s]", strings.Join(q.ExcludeIds(), " "))
+		}
+		return fmt.Sprintf("more_like_this(fields=[%s]%s)%s", strings.Join(q.Fields(), " "), exclude, rewriteBoost(q.Boost()))
+	case *KNNQuery:
+		filter := ""
+		if q.Filter() != nil {
+			filter = ", filter=" + r.rewrite(q.Filter())
+		}
+		return fmt.Sprintf("%s:knn(k=%d%s)%s", q.Field(), q.K(), filter, rewriteBoost(q.Boost()))
+	case *ConstantScoreQuery:
+		return fmt.Sprintf("constant_score(%s)%s", r.rewrite(q.Filter()), rewriteBoost(q.Boost()))
+	case *DisMaxQuery:
+		clauses := make([]string, 0, len(q.Queries()))
+		for _, subQuery := range q.Queries() {
+			clauses = append(clauses, r.rewrite(subQuery))
+		}
+		tieBreaker := ""
+		if q.TieBreaker() > 0.0 {
+			tieBreaker = "~" + strconv.FormatFloat(q.TieBreaker(), 'f', -1, 64)
+		}
+		return fmt.Sprintf("(%s)%s%s", strings.Join(clauses, " | "), tieBreaker, rewriteBoost(q.Boost()))
+	case *crossFieldsQuery:
+		crossFieldsQuery, err := q.query(r.defaultAnalyzer)
+		if err != nil {
+			return fmt.Sprintf("%T", query)
+		}
+		return r.rewrite(crossFieldsQuery)
+	case *FunctionScoreQuery:
+		functions := make([]string, 0, len(q.Functions()))
+		for _, function := range q.Functions() {
+			if function.Filter() != nil {
+				functions = append(functions, fmt.Sprintf("%s if %s", function.String(), r.rewrite(function.Filter())))
+				continue
+			}
+			functions = append(functions, function.String())
+		}
+		return fmt.Sprintf("function_score(%s, functions=[%s], score_mode=%s, boost_mode=%s)%s", r.rewrite(q.Query()), strings.Join(functions, ", "), ScoreMode_name[q.ScoreMode()], BoostMode_name[q.BoostMode()], rewriteBoost(q.Boost()))
+	default:
+		return fmt.Sprintf("%T", query)
+	}
+}
@@ -0,0 +1,24 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis/analyzer"
+)
+
+func TestRewriteQuery(t *testing.T) {
+	query := bluge.NewBooleanQuery().
+		AddMust(bluge.NewMatchQuery("Hello World").SetField("title").SetOperator(bluge.MatchQueryOperatorAnd)).
+		AddMust(NewTermsQuery("go", "rust").SetField("tags")).
+		AddMustNot(bluge.NewNumericRangeInclusiveQuery(10, bluge.MaxNumeric, true, false).SetField("price")).
+		AddMustNot(NewExistsQuery("thumbnail")).
+		AddMust(NewConstantScoreQuery(bluge.NewTermQuery("book").SetField("category")).SetBoost(0.0)).
+		AddShould(NewConstantScoreQuery(bluge.NewTermQuery("phalanx")).SetBoost(2.0))
+
+	expected := `(+(+title:hello +title:world) +tags:(go rust) #category:book -price:[10 TO *} -_exists_:thumbnail constant_score(_all:phalanx)^2)`
+	actual := RewriteQuery(query, "_all", analyzer.NewStandardAnalyzer())
+	if actual != expected {
+		t.Fatalf("expected %v, but %v\n", expected, actual)
+	}
+}
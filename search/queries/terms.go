@@ -0,0 +1,238 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/blugelabs/bluge/numeric"
+	"github.com/blugelabs/bluge/search"
+	"github.com/blugelabs/bluge/search/searcher"
+	"github.com/blugelabs/bluge/search/similarity"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+type TermsLookupOptions struct {
+	IndexName string `json:"index_name"`
+	Id        string `json:"id"`
+	Field     string `json:"field"`
+}
+
+type TermsQueryOptions struct {
+	Terms       []interface{}       `json:"terms"`
+	Field       string              `json:"field"`
+	FieldType   string              `json:"field_type"`
+	TermsLookup *TermsLookupOptions `json:"terms_lookup"`
+	Boost       float64             `json:"boost"`
+}
+
+func NewTermsQueryOptions() TermsQueryOptions {
+	return TermsQueryOptions{
+		Boost: 1.0,
+	}
+}
+
+// Create new TermsQuery with given options.
+// Options example:
+// {
+//   "terms": ["search", "database"],
+//   "field": "tags",
+//   "boost": 1.0
+// }
+//
+// The terms can be fetched from a field of another document with terms_lookup.
+// {
+//   "terms_lookup": {
+//     "index_name": "users",
+//     "id": "1",
+//     "field": "favorite_tags"
+//   },
+//   "field": "tags"
+// }
+// The terms_lookup must be resolved with ResolveLookups before the query is created.
+//
+// The field_type is the type of the field in the index mapping, and is filled by ApplyIndexMapping.
+// The terms of a numeric field are numbers, and the other field types than text and numeric are not supported.
+func NewTermsQueryWithMap(opts map[string]interface{}) (*TermsQuery, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewTermsQueryOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewTermsQueryWithOptions(options)
+}
+
+func NewTermsQueryWithOptions(opts TermsQueryOptions) (*TermsQuery, error) {
+	if opts.TermsLookup != nil {
+		return nil, fmt.Errorf("terms_lookup option is not resolved: %v", *opts.TermsLookup)
+	}
+
+	// field_type is optional.
+	fieldType := mapping.TextField
+	if opts.FieldType != "" {
+		fieldType = mapping.FieldType(opts.FieldType)
+	}
+
+	terms := make([]string, 0, len(opts.Terms))
+	for _, term := range opts.Terms {
+		switch fieldType {
+		case mapping.TextField:
+			switch value := term.(type) {
+			case string:
+				terms = append(terms, value)
+			case float64:
+				terms = append(terms, strconv.FormatFloat(value, 'f', -1, 64))
+			default:
+				return nil, fmt.Errorf("terms option is unexpected: %v", opts.Terms)
+			}
+		case mapping.NumericField:
+			switch value := term.(type) {
+			case string:
+				if _, err := strconv.ParseFloat(value, 64); err != nil {
+					return nil, fmt.Errorf("terms option is unexpected: %v", opts.Terms)
+				}
+				terms = append(terms, value)
+			case float64:
+				terms = append(terms, strconv.FormatFloat(value, 'f', -1, 64))
+			default:
+				return nil, fmt.Errorf("terms option is unexpected: %v", opts.Terms)
+			}
+		default:
+			return nil, fmt.Errorf("field_type option is unexpected: %v", opts.FieldType)
+		}
+	}
+
+	termsQuery := NewTermsQuery(terms...).SetFieldType(fieldType)
+
+	// field is optional.
+	if opts.Field != "" {
+		termsQuery.SetField(opts.Field)
+	}
+
+	// boost is optional.
+	if opts.Boost >= 0.0 {
+		termsQuery.SetBoost(opts.Boost)
+	}
+
+	return termsQuery, nil
+}
+
+// resolveTermsLookup replaces the terms_lookup option with the terms fetched from the document.
+// The fetched terms are added to the terms of the query.
+func resolveTermsLookup(queryOpts map[string]interface{}, lookup DocumentLookupFunc) error {
+	termsLookupValue, ok := queryOpts["terms_lookup"]
+	if !ok {
+		return nil
+	}
+
+	bytes, err := json.Marshal(termsLookupValue)
+	if err != nil {
+		return err
+	}
+	var termsLookup TermsLookupOptions
+	if err := json.Unmarshal(bytes, &termsLookup); err != nil {
+		return fmt.Errorf("terms_lookup option is unexpected: %v", termsLookupValue)
+	}
+	if termsLookup.IndexName == "" || termsLookup.Id == "" || termsLookup.Field == "" {
+		return fmt.Errorf("terms_lookup option requires index_name, id and field: %v", termsLookupValue)
+	}
+
+	fieldValues, err := lookup(termsLookup.IndexName, termsLookup.Id, []string{termsLookup.Field})
+	if err != nil {
+		return err
+	}
+
+	terms, _ := queryOpts["terms"].([]interface{})
+	for _, term := range fieldValues[termsLookup.Field] {
+		terms = append(terms, term)
+	}
+	queryOpts["terms"] = terms
+	delete(queryOpts, "terms_lookup")
+
+	return nil
+}
+
+// TermsQuery matches the documents containing any of the terms in the field.
+// All the matching documents have the same score, the boost.
+// The terms of a numeric field are the string representations of the numbers.
+type TermsQuery struct {
+	terms     []string
+	field     string
+	fieldType mapping.FieldType
+	boost     float64
+}
+
+func NewTermsQuery(terms ...string) *TermsQuery {
+	return &TermsQuery{
+		terms:     terms,
+		fieldType: mapping.TextField,
+		boost:     1.0,
+	}
+}
+
+func (q *TermsQuery) SetField(f string) *TermsQuery {
+	q.field = f
+	return q
+}
+
+func (q *TermsQuery) SetFieldType(t mapping.FieldType) *TermsQuery {
+	q.fieldType = t
+	return q
+}
+
+func (q *TermsQuery) SetBoost(b float64) *TermsQuery {
+	q.boost = b
+	return q
+}
+
+func (q *TermsQuery) Terms() []string {
+	return q.terms
+}
+
+func (q *TermsQuery) Field() string {
+	return q.field
+}
+
+func (q *TermsQuery) FieldType() mapping.FieldType {
+	return q.fieldType
+}
+
+func (q *TermsQuery) Boost() float64 {
+	return q.boost
+}
+
+func (q *TermsQuery) Searcher(i search.Reader, options search.SearcherOptions) (search.Searcher, error) {
+	field := q.field
+	if field == "" {
+		field = options.DefaultSearchField
+	}
+
+	if len(q.terms) == 0 {
+		return searcher.NewMatchNoneSearcher(i, options)
+	}
+
+	terms := q.terms
+	if q.fieldType == mapping.NumericField {
+		// Numbers are indexed as the prefix coded terms, as bluge.NewNumericField does.
+		terms = make([]string, 0, len(q.terms))
+		for _, term := range q.terms {
+			number, err := strconv.ParseFloat(term, 64)
+			if err != nil {
+				return nil, err
+			}
+			terms = append(terms, string(numeric.MustNewPrefixCodedInt64(numeric.Float64ToInt64(number), 0)))
+		}
+	}
+
+	termsSearcher, err := searcher.NewMultiTermSearcher(i, terms, field, 1.0, similarity.ConstantScorer(1.0), similarity.NewCompositeSumScorer(), filterSearcherOptions(options), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConstantScoreSearcher(termsSearcher, q.boost, options), nil
+}
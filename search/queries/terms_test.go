@@ -0,0 +1,195 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+func TestNewTermsQueryWithMap(t *testing.T) {
+	queryFile := "../../testdata/test_terms_query.json"
+
+	bytes, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	var opts map[string]interface{}
+	if err := json.Unmarshal(bytes, &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewTermsQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(query.Terms(), []string{"search", "database"}) {
+		t.Fatalf("unexpected terms: %v\n", query.Terms())
+	}
+}
+
+func TestResolveLookups(t *testing.T) {
+	var opts map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+	  "must": [
+	    {"type": "terms", "options": {"terms": ["go"], "terms_lookup": {"index_name": "users", "id": "1", "field": "favorite_tags"}, "field": "tags"}}
+	  ]
+	}`), &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// The unresolved terms_lookup is an error.
+	if _, err := NewTermsQueryWithMap(opts["must"].([]interface{})[0].(map[string]interface{})["options"].(map[string]interface{})); err == nil {
+		t.Fatalf("expected error with unresolved terms_lookup\n")
+	}
+
+	lookup := func(indexName string, id string, fields []string) (map[string][]string, error) {
+		if indexName != "users" || id != "1" || !reflect.DeepEqual(fields, []string{"favorite_tags"}) {
+			return nil, fmt.Errorf("unexpected lookup: %s %s %v", indexName, id, fields)
+		}
+		return map[string][]string{"favorite_tags": {"search", "database"}}, nil
+	}
+	if err := ResolveLookups("articles", "boolean", opts, lookup); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	query, err := NewTermsQueryWithMap(opts["must"].([]interface{})[0].(map[string]interface{})["options"].(map[string]interface{}))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(query.Terms(), []string{"go", "search", "database"}) {
+		t.Fatalf("unexpected terms: %v\n", query.Terms())
+	}
+}
+
+// searchIds returns the sorted ids of the documents matching the query.
+func searchIds(t *testing.T, reader *bluge.Reader, query bluge.Query) []string {
+	docMatchIter, err := reader.Search(context.Background(), bluge.NewTopNSearch(10, query))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	ids := make([]string, 0)
+	docMatch, err := docMatchIter.Next()
+	for err == nil && docMatch != nil {
+		if docMatch.Score != 1.0 {
+			t.Fatalf("expected 1.0, but %v\n", docMatch.Score)
+		}
+		err = docMatch.VisitStoredFields(func(field string, value []byte) bool {
+			if field == "_id" {
+				ids = append(ids, string(value))
+			}
+			return true
+		})
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		docMatch, err = docMatchIter.Next()
+	}
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func TestTermsQuery(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	for id, tag := range map[string]string{"1": "search", "2": "database", "3": "network"} {
+		doc := bluge.NewDocument(id)
+		doc.AddField(bluge.NewKeywordField("tags", tag))
+		if err := writer.Update(doc.ID(), doc); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	ids := searchIds(t, reader, NewTermsQuery("search", "database", "unknown").SetField("tags"))
+	if !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Fatalf("unexpected ids: %v\n", ids)
+	}
+
+	idsQuery, err := NewIdsQueryWithOptions(IdsQueryOptions{Ids: []string{"1", "3", "4"}, Boost: 1.0})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	ids = searchIds(t, reader, idsQuery)
+	if !reflect.DeepEqual(ids, []string{"1", "3"}) {
+		t.Fatalf("unexpected ids: %v\n", ids)
+	}
+}
+
+func TestTermsQueryWithNumericField(t *testing.T) {
+	writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer writer.Close()
+
+	for id, price := range map[string]float64{"1": 100, "2": 200.5, "3": 300} {
+		doc := bluge.NewDocument(id)
+		doc.AddField(bluge.NewNumericField("price", price))
+		if err := writer.Update(doc.ID(), doc); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	reader, err := writer.Reader()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer reader.Close()
+
+	// The field type is filled from the index mapping.
+	var indexMapping mapping.IndexMapping
+	if err := json.Unmarshal([]byte(`{"price": {"type": "numeric"}, "created_at": {"type": "datetime"}}`), &indexMapping); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	opts := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(`{"terms": [100, "200.5", 400], "field": "price"}`), &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	ApplyIndexMapping("terms", opts, indexMapping, analyzer.AnalysisSetting{})
+
+	query, err := NewTermsQueryWithMap(opts)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if query.FieldType() != mapping.NumericField {
+		t.Fatalf("expected %v, but %v\n", mapping.NumericField, query.FieldType())
+	}
+	ids := searchIds(t, reader, query)
+	if !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Fatalf("unexpected ids: %v\n", ids)
+	}
+
+	// The terms of a numeric field must be numbers.
+	if _, err := NewTermsQueryWithMap(map[string]interface{}{"terms": []interface{}{"cheap"}, "field": "price", "field_type": "numeric"}); err == nil {
+		t.Fatalf("expected error with a non numeric term\n")
+	}
+
+	// The other field types are not supported.
+	opts = map[string]interface{}{"terms": []interface{}{"2021-01-01T00:00:00Z"}, "field": "created_at"}
+	ApplyIndexMapping("terms", opts, indexMapping, analyzer.AnalysisSetting{})
+	if _, err := NewTermsQueryWithMap(opts); err == nil {
+		t.Fatalf("expected error with a datetime field\n")
+	}
+}
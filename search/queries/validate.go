@@ -0,0 +1,220 @@
+package queries
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blugelabs/bluge"
+	"github.com/mosuka/phalanx/errors"
+	"github.com/mosuka/phalanx/mapping"
+)
+
+// QueryError is an error found in a query.
+// Path is the location of the query in the request, e.g. "query.must[0]".
+type QueryError struct {
+	Path   string `json:"path"`
+	Type   string `json:"type"`
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (e *QueryError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%s (%s query, %s field): %s", e.Path, e.Type, e.Field, e.Reason)
+	}
+	return fmt.Sprintf("%s (%s query): %s", e.Path, e.Type, e.Reason)
+}
+
+// fieldType returns the type of the special fields and the fields in the index mapping.
+func fieldType(field string, indexMapping mapping.IndexMapping) (mapping.FieldType, error) {
+	switch field {
+	case mapping.IdFieldName, mapping.AllFieldName:
+		return mapping.TextField, nil
+	case mapping.TimestampFieldName:
+		return mapping.DatetimeField, nil
+	}
+
+	return indexMapping.GetFieldType(field)
+}
+
+// queryField is a field that a query searches, and the field type the query expects.
+type queryField struct {
+	field     string
+	fieldType mapping.FieldType
+}
+
+// queryFields returns the fields that the query and its nested queries search.
+func queryFields(query bluge.Query) []queryField {
+	fields := make([]queryField, 0)
+
+	switch q := query.(type) {
+	case *bluge.BooleanQuery:
+		for _, clause := range [][]bluge.Query{q.Musts(), q.MustNots(), q.Shoulds()} {
+			for _, subQuery := range clause {
+				fields = append(fields, queryFields(subQuery)...)
+			}
+		}
+	case *bluge.TermQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.MatchQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.MatchPhraseQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.MultiPhraseQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.PrefixQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.RegexpQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.WildcardQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.FuzzyQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.TermRangeQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.TextField})
+	case *bluge.NumericRangeQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.NumericField})
+	case *bluge.DateRangeQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.DatetimeField})
+	case *bluge.GeoBoundingBoxQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.GeoPointField})
+	case *bluge.GeoBoundingPolygonQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.GeoPointField})
+	case *bluge.GeoDistanceQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.GeoPointField})
+	case *TermsQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: q.FieldType()})
+	case *ExistsQuery:
+		// Fields of any type can be checked.
+		fields = append(fields, queryField{field: q.Field()})
+	case *MoreLikeThisQuery:
+		for _, field := range q.Fields() {
+			fields = append(fields, queryField{field: field, fieldType: mapping.TextField})
+		}
+	case *KNNQuery:
+		fields = append(fields, queryField{field: q.Field(), fieldType: mapping.DenseVectorField})
+	case *ConstantScoreQuery:
+		fields = append(fields, queryFields(q.Filter())...)
+	case *DisMaxQuery:
+		for _, subQuery := range q.Queries() {
+			fields = append(fields, queryFields(subQuery)...)
+		}
+	case *crossFieldsQuery:
+		for _, field := range q.fields {
+			fields = append(fields, queryField{field: field.field, fieldType: mapping.TextField})
+		}
+	case *FunctionScoreQuery:
+		fields = append(fields, queryFields(q.Query())...)
+		for _, function := range q.Functions() {
+			if function.Filter() != nil {
+				fields = append(fields, queryFields(function.Filter())...)
+			}
+			if field, fieldType := function.Field(); field != "" {
+				fields = append(fields, queryField{field: field, fieldType: fieldType})
+			}
+		}
+	}
+
+	return fields
+}
+
+// ValidateQuery parses the query and its nested queries, and checks the fields they search
+// against the index mapping.
+// It returns all the errors found, or an empty slice if the query is valid.
+func ValidateQuery(queryType string, queryOpts map[string]interface{}, indexMapping mapping.IndexMapping) []*QueryError {
+	queryErrors := make([]*QueryError, 0)
+	validateQuery("query", queryType, queryOpts, indexMapping, &queryErrors)
+
+	return queryErrors
+}
+
+func validateQuery(path string, queryType string, queryOpts map[string]interface{}, indexMapping mapping.IndexMapping, queryErrors *[]*QueryError) {
+	if queryType == "" {
+		*queryErrors = append(*queryErrors, &QueryError{Path: path, Reason: "query type does not exist"})
+		return
+	}
+	if QueryType_value[queryType] == QueryTypeUnknown {
+		*queryErrors = append(*queryErrors, &QueryError{Path: path, Type: queryType, Reason: errors.ErrUnknownQueryType.Error()})
+		return
+	}
+
+	query, err := NewQuery(queryType, queryOpts)
+
+	switch QueryType_value[queryType] {
+	case QueryTypeBoolean:
+		// Invalid nested queries are skipped by the boolean query, so they are validated one by one.
+		numErrors := len(*queryErrors)
+		for _, clause := range []string{"must", "must_not", "should", "filter"} {
+			clauseValue, ok := queryOpts[clause]
+			if !ok {
+				continue
+			}
+			querySettings, ok := clauseValue.([]interface{})
+			if !ok {
+				*queryErrors = append(*queryErrors, &QueryError{Path: path + "." + clause, Type: queryType, Reason: fmt.Sprintf("%s option is unexpected: %v", clause, clauseValue)})
+				continue
+			}
+			for i, querySetting := range querySettings {
+				validateQuerySetting(fmt.Sprintf("%s.%s[%d]", path, clause, i), querySetting, indexMapping, queryErrors)
+			}
+		}
+		if err != nil && len(*queryErrors) == numErrors {
+			*queryErrors = append(*queryErrors, &QueryError{Path: path, Type: queryType, Reason: err.Error()})
+		}
+		return
+	case QueryTypeDisMax:
+		numErrors := len(*queryErrors)
+		if querySettings, ok := queryOpts["queries"].([]interface{}); ok {
+			for i, querySetting := range querySettings {
+				validateQuerySetting(fmt.Sprintf("%s.queries[%d]", path, i), querySetting, indexMapping, queryErrors)
+			}
+		}
+		// The errors of invalid nested queries are reported at the nested queries.
+		if err != nil && len(*queryErrors) == numErrors {
+			*queryErrors = append(*queryErrors, &QueryError{Path: path, Type: queryType, Reason: err.Error()})
+		}
+		return
+	case QueryTypeConstantScore, QueryTypeKNN:
+		numErrors := len(*queryErrors)
+		if filter, ok := queryOpts["filter"]; ok && filter != nil {
+			validateQuerySetting(path+".filter", filter, indexMapping, queryErrors)
+		}
+		if len(*queryErrors) > numErrors {
+			return
+		}
+	}
+
+	if err != nil {
+		*queryErrors = append(*queryErrors, &QueryError{Path: path, Type: queryType, Reason: err.Error()})
+		return
+	}
+	for _, qf := range queryFields(query) {
+		// The default search field is used if the field is omitted.
+		if qf.field == "" {
+			continue
+		}
+		actualType, err := fieldType(qf.field, indexMapping)
+		if err != nil {
+			*queryErrors = append(*queryErrors, &QueryError{Path: path, Type: queryType, Field: qf.field, Reason: "field does not exist in the index mapping"})
+			continue
+		}
+		if qf.fieldType != "" && actualType != qf.fieldType {
+			*queryErrors = append(*queryErrors, &QueryError{Path: path, Type: queryType, Field: qf.field, Reason: fmt.Sprintf("field type is %s, but the query searches %s fields", actualType, qf.fieldType)})
+		}
+	}
+}
+
+func validateQuerySetting(path string, querySetting interface{}, indexMapping mapping.IndexMapping, queryErrors *[]*QueryError) {
+	bytes, err := json.Marshal(querySetting)
+	if err != nil {
+		*queryErrors = append(*queryErrors, &QueryError{Path: path, Reason: err.Error()})
+		return
+	}
+	var setting QuerySetting
+	if err := json.Unmarshal(bytes, &setting); err != nil {
+		*queryErrors = append(*queryErrors, &QueryError{Path: path, Reason: fmt.Sprintf("query is unexpected: %v", querySetting)})
+		return
+	}
+
+	validateQuery(path, setting.Type, setting.Options, indexMapping, queryErrors)
+}
@@ -0,0 +1,53 @@
+package queries
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mosuka/phalanx/mapping"
+)
+
+func TestValidateQuery(t *testing.T) {
+	var indexMapping mapping.IndexMapping
+	if err := json.Unmarshal([]byte(`{"title": {"type": "text"}, "price": {"type": "numeric"}}`), &indexMapping); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	var opts map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+	  "must": [
+	    {"type": "term", "options": {"term": "hello", "field": "title"}},
+	    {"type": "term", "options": {"term": "hello", "field": "description"}}
+	  ],
+	  "should": [
+	    {"type": "prefix", "options": {"prefix": "he", "field": "price"}},
+	    {"type": "unknown_query", "options": {}}
+	  ],
+	  "filter": [
+	    {"type": "constant_score", "options": {"filter": {"type": "numeric_range", "options": {"min": 1, "field": "title"}}}}
+	  ]
+	}`), &opts); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	queryErrors := ValidateQuery("boolean", opts, indexMapping)
+	if len(queryErrors) != 4 {
+		t.Fatalf("expected 4 errors, but got %v\n", queryErrors)
+	}
+	if queryErrors[0].Path != "query.must[1]" || queryErrors[0].Field != "description" {
+		t.Fatalf("unexpected error: %v\n", queryErrors[0])
+	}
+	if queryErrors[1].Path != "query.should[0]" || queryErrors[1].Field != "price" {
+		t.Fatalf("unexpected error: %v\n", queryErrors[1])
+	}
+	if queryErrors[2].Path != "query.should[1]" || queryErrors[2].Type != "unknown_query" {
+		t.Fatalf("unexpected error: %v\n", queryErrors[2])
+	}
+	if queryErrors[3].Path != "query.filter[0].filter" || queryErrors[3].Field != "title" {
+		t.Fatalf("unexpected error: %v\n", queryErrors[3])
+	}
+
+	if queryErrors := ValidateQuery("match", map[string]interface{}{"match": "hello", "field": "title"}, indexMapping); len(queryErrors) != 0 {
+		t.Fatalf("expected no errors, but got %v\n", queryErrors)
+	}
+}
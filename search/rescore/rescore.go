@@ -0,0 +1,229 @@
+package rescore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/search"
+	"github.com/mosuka/phalanx/mapping"
+	"github.com/mosuka/phalanx/proto"
+	phalanxqueries "github.com/mosuka/phalanx/search/queries"
+)
+
+const (
+	DefaultWindowSize = 10
+)
+
+type ScoreMode int
+
+const (
+	ScoreModeUnknown ScoreMode = iota
+	ScoreModeTotal
+	ScoreModeMultiply
+	ScoreModeMax
+)
+
+// Maps for ScoreMode.
+var (
+	ScoreMode_name = map[ScoreMode]string{
+		ScoreModeUnknown:  "unknown",
+		ScoreModeTotal:    "total",
+		ScoreModeMultiply: "multiply",
+		ScoreModeMax:      "max",
+	}
+	ScoreMode_value = map[string]ScoreMode{
+		"unknown":  ScoreModeUnknown,
+		"total":    ScoreModeTotal,
+		"multiply": ScoreModeMultiply,
+		"max":      ScoreModeMax,
+	}
+)
+
+type RescorerOptions struct {
+	WindowSize         int     `json:"window_size"`
+	ScoreMode          string  `json:"score_mode"`
+	QueryWeight        float64 `json:"query_weight"`
+	RescoreQueryWeight float64 `json:"rescore_query_weight"`
+}
+
+func NewRescorerOptions() RescorerOptions {
+	return RescorerOptions{
+		WindowSize:         DefaultWindowSize,
+		ScoreMode:          ScoreMode_name[ScoreModeTotal],
+		QueryWeight:        1.0,
+		RescoreQueryWeight: 1.0,
+	}
+}
+
+// Create new Rescorer with the rescore query and given options.
+// Options example:
+// {
+//   "window_size": 50,
+//   "score_mode": "total",
+//   "query_weight": 0.7,
+//   "rescore_query_weight": 1.2
+// }
+func NewRescorerWithMap(query bluge.Query, opts map[string]interface{}) (*Rescorer, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewRescorerOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewRescorerWithOptions(query, options)
+}
+
+func NewRescorerWithOptions(query bluge.Query, opts RescorerOptions) (*Rescorer, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query does not exist")
+	}
+
+	if opts.WindowSize < 1 {
+		return nil, fmt.Errorf("window_size option is unexpected: %v", opts.WindowSize)
+	}
+
+	scoreMode, ok := ScoreMode_value[opts.ScoreMode]
+	if !ok || scoreMode == ScoreModeUnknown {
+		return nil, fmt.Errorf("score_mode option is unexpected: %v", opts.ScoreMode)
+	}
+
+	return &Rescorer{
+		query:              query,
+		windowSize:         opts.WindowSize,
+		scoreMode:          scoreMode,
+		queryWeight:        opts.QueryWeight,
+		rescoreQueryWeight: opts.RescoreQueryWeight,
+	}, nil
+}
+
+// Rescorer re-scores the top documents of a search with a secondary query.
+// The score of a document matching the rescore query is the combination of
+// the score of the query times query_weight and the score of the rescore query times rescore_query_weight.
+// The score of a document not matching the rescore query is the score of the query times query_weight.
+type Rescorer struct {
+	query              bluge.Query
+	windowSize         int
+	scoreMode          ScoreMode
+	queryWeight        float64
+	rescoreQueryWeight float64
+}
+
+func (r *Rescorer) WindowSize() int {
+	return r.windowSize
+}
+
+// Combine returns the new score of a document from the score of the query and the score of the rescore query.
+func (r *Rescorer) Combine(score float64, rescoreScore float64, matched bool) float64 {
+	score *= r.queryWeight
+	if !matched {
+		return score
+	}
+	rescoreScore *= r.rescoreQueryWeight
+
+	switch r.scoreMode {
+	case ScoreModeMultiply:
+		return score * rescoreScore
+	case ScoreModeMax:
+		return math.Max(score, rescoreScore)
+	default:
+		return score + rescoreScore
+	}
+}
+
+// Rescore re-scores the top window_size documents of a shard with the rescore query searched on the readers of the shard,
+// marks them as re-scored and sorts them by the new scores. The other documents follow them with the scores of the query.
+// The scores of the re-scored documents and of the other documents are not comparable,
+// so the re-scored documents are ranked ahead of the others when the documents of the shards are merged.
+// The explanations of the documents are combined if explain is true.
+func (r *Rescorer) Rescore(ctx context.Context, readers []*bluge.Reader, docs []*proto.Document, explain bool) ([]*proto.Document, error) {
+	window := docs
+	if len(window) > r.windowSize {
+		window = window[:r.windowSize]
+	}
+	if len(window) == 0 {
+		return docs, nil
+	}
+
+	// Search the rescore query only for the documents in the window.
+	ids := make([]string, 0, len(window))
+	for _, doc := range window {
+		ids = append(ids, doc.Id)
+	}
+	query := bluge.NewBooleanQuery().
+		AddMust(r.query).
+		AddMust(phalanxqueries.NewConstantScoreQuery(phalanxqueries.NewTermsQuery(ids...).SetField(mapping.IdFieldName)).SetBoost(0.0))
+
+	request := bluge.NewTopNSearch(len(window), query)
+	if explain {
+		request.ExplainScores()
+	}
+	docMatchIter, err := bluge.MultiSearch(ctx, request, readers...)
+	if err != nil {
+		return nil, err
+	}
+
+	rescoreMatches := make(map[string]*search.DocumentMatch)
+	docMatch, err := docMatchIter.Next()
+	for err == nil && docMatch != nil {
+		id := ""
+		err = docMatch.VisitStoredFields(func(field string, value []byte) bool {
+			if field == mapping.IdFieldName {
+				id = string(value)
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		rescoreMatches[id] = docMatch
+		docMatch, err = docMatchIter.Next()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, doc := range window {
+		rescoreMatch, matched := rescoreMatches[doc.Id]
+		rescoreScore := 0.0
+		if matched {
+			rescoreScore = rescoreMatch.Score
+		}
+		score := r.Combine(doc.Score, rescoreScore, matched)
+
+		if explain && len(doc.Explanation) > 0 {
+			var explanation search.Explanation
+			if err := json.Unmarshal(doc.Explanation, &explanation); err != nil {
+				return nil, err
+			}
+			children := []*search.Explanation{
+				search.NewExplanation(doc.Score*r.queryWeight, fmt.Sprintf("query score, times query_weight %v, of:", r.queryWeight), &explanation),
+			}
+			if matched {
+				children = append(children, search.NewExplanation(rescoreScore*r.rescoreQueryWeight, fmt.Sprintf("rescore query score, times rescore_query_weight %v, of:", r.rescoreQueryWeight), rescoreMatch.Explanation))
+			}
+			explanationBytes, err := json.Marshal(search.NewExplanation(score, fmt.Sprintf("rescored with score_mode %s of:", ScoreMode_name[r.scoreMode]), children...))
+			if err != nil {
+				return nil, err
+			}
+			doc.Explanation = explanationBytes
+		}
+
+		doc.Score = score
+		doc.Rescored = true
+	}
+
+	sort.SliceStable(window, func(i, j int) bool {
+		return window[i].Score > window[j].Score
+	})
+
+	return docs, nil
+}
@@ -0,0 +1,168 @@
+package rescore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+	"github.com/mosuka/phalanx/proto"
+	phalanxqueries "github.com/mosuka/phalanx/search/queries"
+)
+
+// openShards indexes the documents into two shards alternately.
+func openShards(t *testing.T, tags map[string]string) []*bluge.Reader {
+	writers := []*bluge.Writer{}
+	for i := 0; i < 2; i++ {
+		writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		writers = append(writers, writer)
+	}
+
+	i := 0
+	for id, tag := range tags {
+		doc := bluge.NewDocument(id)
+		doc.AddField(bluge.NewKeywordField("tag", tag))
+		if err := writers[i%2].Update(doc.ID(), doc); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		i++
+	}
+
+	readers := []*bluge.Reader{}
+	for _, writer := range writers {
+		reader, err := writer.Reader()
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		readers = append(readers, reader)
+		if err := writer.Close(); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	return readers
+}
+
+func newDocs() []*proto.Document {
+	return []*proto.Document{
+		{Id: "a", Score: 5.0},
+		{Id: "b", Score: 4.0},
+		{Id: "c", Score: 3.0},
+		{Id: "d", Score: 2.0},
+		{Id: "e", Score: 1.0},
+	}
+}
+
+func docIds(docs []*proto.Document) []string {
+	ids := []string{}
+	for _, doc := range docs {
+		ids = append(ids, doc.Id)
+	}
+	return ids
+}
+
+func TestRescorerCombine(t *testing.T) {
+	cases := []struct {
+		scoreMode string
+		matched   bool
+		expected  float64
+	}{
+		{scoreMode: "total", matched: true, expected: 2.0*0.5 + 3.0*2.0},
+		{scoreMode: "multiply", matched: true, expected: 2.0 * 0.5 * 3.0 * 2.0},
+		{scoreMode: "max", matched: true, expected: 3.0 * 2.0},
+		// The documents not matching the rescore query keep the weighted score of the query.
+		{scoreMode: "total", matched: false, expected: 2.0 * 0.5},
+		{scoreMode: "multiply", matched: false, expected: 2.0 * 0.5},
+	}
+
+	for _, c := range cases {
+		rescorer, err := NewRescorerWithMap(bluge.NewMatchAllQuery(), map[string]interface{}{
+			"score_mode":           c.scoreMode,
+			"query_weight":         0.5,
+			"rescore_query_weight": 2.0,
+		})
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		score := rescorer.Combine(2.0, 3.0, c.matched)
+		if score != c.expected {
+			t.Fatalf("unexpected score with %s: %v\n", c.scoreMode, score)
+		}
+	}
+}
+
+func TestNewRescorer(t *testing.T) {
+	if _, err := NewRescorerWithMap(nil, map[string]interface{}{}); err == nil {
+		t.Fatalf("expected error without query\n")
+	}
+
+	if _, err := NewRescorerWithMap(bluge.NewMatchAllQuery(), map[string]interface{}{"window_size": 0}); err == nil {
+		t.Fatalf("expected error with window_size 0\n")
+	}
+
+	if _, err := NewRescorerWithMap(bluge.NewMatchAllQuery(), map[string]interface{}{"score_mode": "avg"}); err == nil {
+		t.Fatalf("expected error with unknown score_mode\n")
+	}
+}
+
+func TestRescorerRescore(t *testing.T) {
+	readers := openShards(t, map[string]string{"a": "x", "b": "y", "c": "y", "d": "x", "e": "y"})
+	rescoreQuery := phalanxqueries.NewConstantScoreQuery(bluge.NewTermQuery("y").SetField("tag")).SetBoost(10.0)
+
+	rescorer, err := NewRescorerWithMap(rescoreQuery, map[string]interface{}{
+		"window_size": 2,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// Only the documents in the window are re-scored.
+	docs, err := rescorer.Rescore(context.Background(), readers, newDocs(), false)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(docIds(docs), []string{"b", "a", "c", "d", "e"}) {
+		t.Fatalf("unexpected documents: %v\n", docIds(docs))
+	}
+	scores := []float64{14.0, 5.0, 3.0, 2.0, 1.0}
+	for i, doc := range docs {
+		if doc.Score != scores[i] {
+			t.Fatalf("unexpected score of %s: %v\n", doc.Id, doc.Score)
+		}
+	}
+}
+
+func TestRescorerRescoreWindow(t *testing.T) {
+	readers := openShards(t, map[string]string{"a": "x", "b": "y", "c": "y", "d": "x", "e": "y"})
+	rescoreQuery := phalanxqueries.NewConstantScoreQuery(bluge.NewTermQuery("y").SetField("tag")).SetBoost(0.1)
+
+	rescorer, err := NewRescorerWithMap(rescoreQuery, map[string]interface{}{
+		"window_size": 2,
+		"score_mode":  "multiply",
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// The documents after the window follow the re-scored documents with the scores of the query.
+	docs, err := rescorer.Rescore(context.Background(), readers, newDocs(), true)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if !reflect.DeepEqual(docIds(docs), []string{"a", "b", "c", "d", "e"}) {
+		t.Fatalf("unexpected documents: %v\n", docIds(docs))
+	}
+	scores := []float64{5.0, 4.0 * 0.1, 3.0, 2.0, 1.0}
+	for i, doc := range docs {
+		if doc.Score != scores[i] {
+			t.Fatalf("unexpected score of %s: %v\n", doc.Id, doc.Score)
+		}
+		if doc.Rescored != (i < 2) {
+			t.Fatalf("unexpected rescored of %s: %v\n", doc.Id, doc.Rescored)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package suggest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/proto"
+)
+
+type CompletionSuggesterOptions struct {
+	Text      string                   `json:"text"`
+	Field     string                   `json:"field"`
+	Analyzer  analyzer.AnalyzerSetting `json:"analyzer"`
+	Size      int                      `json:"size"`
+	ShardSize int                      `json:"shard_size"`
+}
+
+func NewCompletionSuggesterOptions() CompletionSuggesterOptions {
+	return CompletionSuggesterOptions{
+		Size: 5,
+	}
+}
+
+// Create new CompletionSuggester with given options.
+// Options example:
+// {
+//   "text": "search eng",
+//   "field": "title",
+//   "size": 5,
+//   "shard_size": 5
+// }
+func NewCompletionSuggesterWithMap(opts map[string]interface{}) (*CompletionSuggester, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewCompletionSuggesterOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewCompletionSuggesterWithOptions(options)
+}
+
+func NewCompletionSuggesterWithOptions(opts CompletionSuggesterOptions) (*CompletionSuggester, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.Size < 1 {
+		return nil, fmt.Errorf("size option is unexpected: %v", opts.Size)
+	}
+
+	// shard_size defaults to size.
+	if opts.ShardSize < opts.Size {
+		opts.ShardSize = opts.Size
+	}
+
+	textAnalyzer, err := newAnalyzer(opts.Analyzer)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer option is unexpected: %v", err)
+	}
+
+	return &CompletionSuggester{
+		text:      opts.Text,
+		field:     opts.Field,
+		analyzer:  textAnalyzer,
+		size:      opts.Size,
+		shardSize: opts.ShardSize,
+	}, nil
+}
+
+// CompletionSuggester completes the last token of the text with the terms of the field starting with it,
+// ranked by the document frequency.
+// It is a prefix expansion over the term dictionaries of the segments, not a dedicated completion structure,
+// so the completions are the terms as they are indexed, e.g. stemmed by the analyzer of the field.
+// The field analyzed with the keyword analyzer completes the whole values of the field.
+type CompletionSuggester struct {
+	text      string
+	field     string
+	analyzer  *analysis.Analyzer
+	size      int
+	shardSize int
+}
+
+// Collect returns the top shard_size completions by the document frequency.
+func (s *CompletionSuggester) Collect(readers []*bluge.Reader) (*proto.Suggestion, error) {
+	suggestion := &proto.Suggestion{
+		Entries: make([]*proto.SuggestEntry, 0),
+	}
+
+	tokens := s.analyzer.Analyze([]byte(s.text))
+	if len(tokens) == 0 {
+		return suggestion, nil
+	}
+	lastToken := tokens[len(tokens)-1]
+	prefix := lastToken.Term
+
+	entry := &proto.SuggestEntry{
+		Text:    s.text,
+		Offset:  0,
+		Length:  int32(len(s.text)),
+		Options: make([]*proto.SuggestOption, 0),
+	}
+
+	// The text before the last token is kept as is.
+	completionPrefix := s.text[:lastToken.Start]
+	freqs := make(map[string]uint64)
+	for _, reader := range readers {
+		dict, err := reader.DictionaryIterator(s.field, nil, prefix, incrementBytes(prefix))
+		if err != nil {
+			return nil, err
+		}
+		dictEntry, err := dict.Next()
+		for err == nil && dictEntry != nil {
+			freqs[completionPrefix+dictEntry.Term()] += dictEntry.Count()
+			dictEntry, err = dict.Next()
+		}
+		if closeErr := dict.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for text, freq := range freqs {
+		entry.Options = append(entry.Options, &proto.SuggestOption{
+			Text:  text,
+			Score: float64(freq),
+			Freq:  freq,
+		})
+	}
+	sortOptions(entry.Options)
+	if len(entry.Options) > s.shardSize {
+		entry.Options = entry.Options[:s.shardSize]
+	}
+
+	suggestion.Entries = append(suggestion.Entries, entry)
+
+	return suggestion, nil
+}
+
+// Reduce takes the top size completions by the document frequency.
+func (s *CompletionSuggester) Reduce(collected *proto.Suggestion) *proto.Suggestion {
+	suggestion := &proto.Suggestion{
+		Entries: make([]*proto.SuggestEntry, 0),
+	}
+	if collected == nil {
+		return suggestion
+	}
+
+	for _, collectedEntry := range collected.Entries {
+		entry := &proto.SuggestEntry{
+			Text:    collectedEntry.Text,
+			Offset:  collectedEntry.Offset,
+			Length:  collectedEntry.Length,
+			Options: make([]*proto.SuggestOption, 0, len(collectedEntry.Options)),
+		}
+		for _, option := range collectedEntry.Options {
+			entry.Options = append(entry.Options, &proto.SuggestOption{
+				Text:  option.Text,
+				Score: float64(option.Freq),
+				Freq:  option.Freq,
+			})
+		}
+		sortOptions(entry.Options)
+		if len(entry.Options) > s.size {
+			entry.Options = entry.Options[:s.size]
+		}
+		suggestion.Entries = append(suggestion.Entries, entry)
+	}
+
+	return suggestion
+}
@@ -0,0 +1,248 @@
+package suggest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/proto"
+)
+
+const (
+	// The number of the partial phrases kept while the corrections of the tokens are combined.
+	phraseBeamWidth = 100
+)
+
+type PhraseSuggesterOptions struct {
+	Text                    string                   `json:"text"`
+	Field                   string                   `json:"field"`
+	Analyzer                analyzer.AnalyzerSetting `json:"analyzer"`
+	Size                    int                      `json:"size"`
+	ShardSize               int                      `json:"shard_size"`
+	MaxEdits                int                      `json:"max_edits"`
+	PrefixLength            int                      `json:"prefix_length"`
+	MinWordLength           int                      `json:"min_word_length"`
+	MaxErrors               int                      `json:"max_errors"`
+	RealWordErrorLikelihood float64                  `json:"real_word_error_likelihood"`
+	Confidence              float64                  `json:"confidence"`
+}
+
+func NewPhraseSuggesterOptions() PhraseSuggesterOptions {
+	return PhraseSuggesterOptions{
+		Size:                    5,
+		ShardSize:               5,
+		MaxEdits:                2,
+		PrefixLength:            1,
+		MinWordLength:           4,
+		MaxErrors:               1,
+		RealWordErrorLikelihood: 0.95,
+		Confidence:              1.0,
+	}
+}
+
+// Create new PhraseSuggester with given options.
+// Options example:
+// {
+//   "text": "serch engne written in go",
+//   "field": "title",
+//   "size": 5,
+//   "shard_size": 5,
+//   "max_edits": 2,
+//   "prefix_length": 1,
+//   "min_word_length": 4,
+//   "max_errors": 2,
+//   "real_word_error_likelihood": 0.95,
+//   "confidence": 1.0
+// }
+func NewPhraseSuggesterWithMap(opts map[string]interface{}) (*PhraseSuggester, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewPhraseSuggesterOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewPhraseSuggesterWithOptions(options)
+}
+
+func NewPhraseSuggesterWithOptions(opts PhraseSuggesterOptions) (*PhraseSuggester, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.Size < 1 {
+		return nil, fmt.Errorf("size option is unexpected: %v", opts.Size)
+	}
+
+	if opts.ShardSize < 1 {
+		return nil, fmt.Errorf("shard_size option is unexpected: %v", opts.ShardSize)
+	}
+
+	if opts.MaxEdits < 1 || opts.MaxEdits > MaxEdits {
+		return nil, fmt.Errorf("max_edits option is unexpected: %v", opts.MaxEdits)
+	}
+
+	if opts.MaxErrors < 1 {
+		return nil, fmt.Errorf("max_errors option is unexpected: %v", opts.MaxErrors)
+	}
+
+	if opts.RealWordErrorLikelihood <= 0.0 || opts.RealWordErrorLikelihood >= 1.0 {
+		return nil, fmt.Errorf("real_word_error_likelihood option is unexpected: %v", opts.RealWordErrorLikelihood)
+	}
+
+	if opts.Confidence < 0.0 {
+		return nil, fmt.Errorf("confidence option is unexpected: %v", opts.Confidence)
+	}
+
+	textAnalyzer, err := newAnalyzer(opts.Analyzer)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer option is unexpected: %v", err)
+	}
+
+	return &PhraseSuggester{
+		termCollector: termCollector{
+			text:          opts.Text,
+			field:         opts.Field,
+			analyzer:      textAnalyzer,
+			shardSize:     opts.ShardSize,
+			maxEdits:      opts.MaxEdits,
+			prefixLength:  opts.PrefixLength,
+			minWordLength: opts.MinWordLength,
+		},
+		size:                    opts.Size,
+		maxErrors:               opts.MaxErrors,
+		realWordErrorLikelihood: opts.RealWordErrorLikelihood,
+		confidence:              opts.Confidence,
+	}, nil
+}
+
+// PhraseSuggester suggests the corrections of the whole text by combining the corrections of the tokens.
+// A token is weighted with (document frequency + 1) times the likelihood that it is intended:
+// real_word_error_likelihood for the token in the field, 1 - real_word_error_likelihood for the token
+// not in the field, and 1 - real_word_error_likelihood times the similarity for the corrections.
+// The score of a phrase is the product of the weights of its tokens normalized per token.
+type PhraseSuggester struct {
+	termCollector
+	size                    int
+	maxErrors               int
+	realWordErrorLikelihood float64
+	confidence              float64
+}
+
+type phraseCandidate struct {
+	texts  []string
+	score  float64
+	errors int
+}
+
+// Reduce returns the top size phrases with at most max_errors corrected tokens,
+// that score higher than the text times confidence.
+func (s *PhraseSuggester) Reduce(collected *proto.Suggestion) *proto.Suggestion {
+	suggestion := &proto.Suggestion{
+		Entries: make([]*proto.SuggestEntry, 0),
+	}
+	if collected == nil || len(collected.Entries) == 0 {
+		return suggestion
+	}
+
+	entry := &proto.SuggestEntry{
+		Text:    s.text,
+		Offset:  0,
+		Length:  int32(len(s.text)),
+		Options: make([]*proto.SuggestOption, 0),
+	}
+	suggestion.Entries = append(suggestion.Entries, entry)
+
+	phrases := []phraseCandidate{{texts: []string{}, score: 1.0}}
+	originalScore := 1.0
+	for _, tokenEntry := range collected.Entries {
+		originalWeight := float64(tokenEntry.Freq+1) * s.realWordErrorLikelihood
+		if tokenEntry.Freq == 0 {
+			originalWeight = 1.0 - s.realWordErrorLikelihood
+		}
+		weights := make([]float64, 0, len(tokenEntry.Options))
+		totalWeight := originalWeight
+		for _, option := range tokenEntry.Options {
+			weight := float64(option.Freq+1) * (1.0 - s.realWordErrorLikelihood) * option.Score
+			weights = append(weights, weight)
+			totalWeight += weight
+		}
+		originalScore *= originalWeight / totalWeight
+
+		nextPhrases := make([]phraseCandidate, 0, len(phrases)*(len(tokenEntry.Options)+1))
+		for _, phrase := range phrases {
+			nextPhrases = append(nextPhrases, phraseCandidate{
+				texts:  append(append([]string{}, phrase.texts...), tokenEntry.Text),
+				score:  phrase.score * originalWeight / totalWeight,
+				errors: phrase.errors,
+			})
+			if phrase.errors >= s.maxErrors {
+				continue
+			}
+			for i, option := range tokenEntry.Options {
+				nextPhrases = append(nextPhrases, phraseCandidate{
+					texts:  append(append([]string{}, phrase.texts...), option.Text),
+					score:  phrase.score * weights[i] / totalWeight,
+					errors: phrase.errors + 1,
+				})
+			}
+		}
+		sortPhraseCandidates(nextPhrases)
+		if len(nextPhrases) > phraseBeamWidth {
+			nextPhrases = nextPhrases[:phraseBeamWidth]
+		}
+		phrases = nextPhrases
+	}
+
+	for _, phrase := range phrases {
+		if phrase.errors == 0 || phrase.score <= originalScore*s.confidence {
+			continue
+		}
+		entry.Options = append(entry.Options, &proto.SuggestOption{
+			Text:  s.replaceTokens(collected.Entries, phrase.texts),
+			Score: phrase.score,
+		})
+		if len(entry.Options) >= s.size {
+			break
+		}
+	}
+
+	return suggestion
+}
+
+// replaceTokens replaces the tokens of the text with the texts, keeping the text between the tokens.
+func (s *PhraseSuggester) replaceTokens(tokenEntries []*proto.SuggestEntry, texts []string) string {
+	replaced := ""
+	cursor := 0
+	for i, tokenEntry := range tokenEntries {
+		start := int(tokenEntry.Offset)
+		end := start + int(tokenEntry.Length)
+		// Overlapping tokens are not replaced.
+		if start < cursor || end > len(s.text) {
+			continue
+		}
+		replaced += s.text[cursor:start]
+		if texts[i] == tokenEntry.Text {
+			replaced += s.text[start:end]
+		} else {
+			replaced += texts[i]
+		}
+		cursor = end
+	}
+	replaced += s.text[cursor:]
+
+	return replaced
+}
+
+func sortPhraseCandidates(phrases []phraseCandidate) {
+	sort.SliceStable(phrases, func(i, j int) bool {
+		if phrases[i].score != phrases[j].score {
+			return phrases[i].score > phrases[j].score
+		}
+		return phrases[i].errors < phrases[j].errors
+	})
+}
@@ -0,0 +1,329 @@
+package suggest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/blevesearch/vellum/levenshtein"
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+	blugeanalyzer "github.com/blugelabs/bluge/analysis/analyzer"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/errors"
+	"github.com/mosuka/phalanx/mapping"
+	"github.com/mosuka/phalanx/proto"
+)
+
+const (
+	// The levenshtein automatons are built up to the max edits.
+	MaxEdits = 2
+)
+
+type SuggesterType int
+
+const (
+	SuggesterTypeUnknown SuggesterType = iota
+	SuggesterTypeCompletion
+	SuggesterTypePhrase
+	SuggesterTypeTerm
+)
+
+// Maps for SuggesterType.
+var (
+	SuggesterType_name = map[SuggesterType]string{
+		SuggesterTypeUnknown:    "unknown",
+		SuggesterTypeCompletion: "completion",
+		SuggesterTypePhrase:     "phrase",
+		SuggesterTypeTerm:       "term",
+	}
+	SuggesterType_value = map[string]SuggesterType{
+		"unknown":    SuggesterTypeUnknown,
+		"completion": SuggesterTypeCompletion,
+		"phrase":     SuggesterTypePhrase,
+		"term":       SuggesterTypeTerm,
+	}
+)
+
+// Suggester suggests texts from the terms of a field in two phases.
+// The candidates are collected from the shards, merged on the coordinator with Merge,
+// and then reduced to the suggestion.
+type Suggester interface {
+	// Collect collects the candidates from the readers of the shards.
+	Collect(readers []*bluge.Reader) (*proto.Suggestion, error)
+	// Reduce makes the suggestion from the candidates collected from all the shards.
+	Reduce(collected *proto.Suggestion) *proto.Suggestion
+}
+
+func NewSuggester(suggesterType string, suggesterOpts map[string]interface{}) (Suggester, error) {
+	switch SuggesterType_value[suggesterType] {
+	case SuggesterTypeCompletion:
+		return NewCompletionSuggesterWithMap(suggesterOpts)
+	case SuggesterTypePhrase:
+		return NewPhraseSuggesterWithMap(suggesterOpts)
+	case SuggesterTypeTerm:
+		return NewTermSuggesterWithMap(suggesterOpts)
+	default:
+		return nil, errors.ErrUnknownSuggesterType
+	}
+}
+
+// ApplyIndexMapping sets the search analyzer of the field to the suggester options
+// unless the options specify the analyzer.
+// Named analyzers are replaced with their definitions in the analysis settings of the index.
+func ApplyIndexMapping(suggesterOpts map[string]interface{}, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) error {
+	var analyzerSetting analyzer.AnalyzerSetting
+	if analyzerOpts, ok := suggesterOpts["analyzer"]; ok {
+		bytes, err := json.Marshal(analyzerOpts)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(bytes, &analyzerSetting); err != nil {
+			return fmt.Errorf("analyzer option is unexpected: %v", err)
+		}
+	} else {
+		field, ok := suggesterOpts["field"].(string)
+		if !ok || !indexMapping.Exists(field) {
+			return nil
+		}
+		setting, err := indexMapping.GetSearchAnalyzerSetting(field)
+		if err != nil {
+			return err
+		}
+		analyzerSetting = setting
+	}
+
+	resolved, err := analysisSetting.Resolve(analyzerSetting)
+	if err != nil {
+		return err
+	}
+	suggesterOpts["analyzer"] = resolved
+
+	return nil
+}
+
+// Merge merges the candidates collected from different shards.
+// The entries are the tokens of the same text, so they are merged in order.
+// The frequencies of the same texts are summed up.
+func Merge(suggestion1 *proto.Suggestion, suggestion2 *proto.Suggestion) *proto.Suggestion {
+	if suggestion1 == nil || len(suggestion1.Entries) == 0 {
+		return suggestion2
+	}
+	if suggestion2 == nil || len(suggestion2.Entries) == 0 {
+		return suggestion1
+	}
+
+	merged := &proto.Suggestion{
+		Entries: make([]*proto.SuggestEntry, 0, len(suggestion1.Entries)),
+	}
+	for i, entry1 := range suggestion1.Entries {
+		if i >= len(suggestion2.Entries) {
+			merged.Entries = append(merged.Entries, entry1)
+			continue
+		}
+		entry2 := suggestion2.Entries[i]
+
+		entry := &proto.SuggestEntry{
+			Text:    entry1.Text,
+			Offset:  entry1.Offset,
+			Length:  entry1.Length,
+			Freq:    entry1.Freq + entry2.Freq,
+			Options: make([]*proto.SuggestOption, 0, len(entry1.Options)+len(entry2.Options)),
+		}
+		options := make(map[string]*proto.SuggestOption)
+		for _, option := range append(entry1.Options, entry2.Options...) {
+			if mergedOption, ok := options[option.Text]; ok {
+				mergedOption.Freq += option.Freq
+				continue
+			}
+			mergedOption := &proto.SuggestOption{
+				Text:  option.Text,
+				Score: option.Score,
+				Freq:  option.Freq,
+			}
+			options[option.Text] = mergedOption
+			entry.Options = append(entry.Options, mergedOption)
+		}
+		merged.Entries = append(merged.Entries, entry)
+	}
+
+	return merged
+}
+
+// newAnalyzer creates the analyzer of the text.
+// The text is analyzed with the standard analyzer without an analyzer, the same as unmapped fields when indexing.
+func newAnalyzer(analyzerSetting analyzer.AnalyzerSetting) (*analysis.Analyzer, error) {
+	if analyzerSetting.Name == "" && analyzerSetting.Language == "" && analyzerSetting.TokenizerSetting.Name == "" &&
+		len(analyzerSetting.CharFilterSettings) == 0 && len(analyzerSetting.TokenFilterSettings) == 0 {
+		return blugeanalyzer.NewStandardAnalyzer(), nil
+	}
+
+	return analyzer.NewAnalyzer(analyzerSetting)
+}
+
+// docFreq returns the number of the documents containing the term in the field of the readers.
+func docFreq(readers []*bluge.Reader, field string, term string) (uint64, error) {
+	count := uint64(0)
+	end := append([]byte(term), 0)
+	for _, reader := range readers {
+		dict, err := reader.DictionaryIterator(field, nil, []byte(term), end)
+		if err != nil {
+			return 0, err
+		}
+		entry, err := dict.Next()
+		for err == nil && entry != nil {
+			if entry.Term() == term {
+				count += entry.Count()
+			}
+			entry, err = dict.Next()
+		}
+		if closeErr := dict.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// levAutomatonBuilders are the reusable levenshtein automaton builders for each number of edits.
+var levAutomatonBuilders = make(map[int]*levenshtein.LevenshteinAutomatonBuilder)
+
+func init() {
+	for edits := 1; edits <= MaxEdits; edits++ {
+		builder, err := levenshtein.NewLevenshteinAutomatonBuilder(uint8(edits), true)
+		if err != nil {
+			panic(fmt.Errorf("levenshtein automaton builder error: %v", err))
+		}
+		levAutomatonBuilders[edits] = builder
+	}
+}
+
+// candidates returns the terms in the field of the readers within the edits of the term,
+// sharing the prefix of the term, and their document frequencies.
+// The term itself is not a candidate.
+func candidates(readers []*bluge.Reader, field string, term string, maxEdits int, prefixLength int) (map[string]uint64, error) {
+	candidateFreqs := make(map[string]uint64)
+
+	builder, ok := levAutomatonBuilders[maxEdits]
+	if !ok {
+		return nil, fmt.Errorf("max_edits option is unexpected: %v", maxEdits)
+	}
+	automaton, err := builder.BuildDfa(term, uint8(maxEdits))
+	if err != nil {
+		return nil, err
+	}
+
+	// The prefix is counted in runes.
+	var start, end []byte
+	prefix := ""
+	for i, r := range term {
+		if utf8.RuneCountInString(term[:i]) >= prefixLength {
+			break
+		}
+		prefix += string(r)
+	}
+	if prefix != "" {
+		start = []byte(prefix)
+		end = incrementBytes(start)
+	}
+
+	for _, reader := range readers {
+		dict, err := reader.DictionaryIterator(field, automaton, start, end)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := dict.Next()
+		for err == nil && entry != nil {
+			if entry.Term() != term {
+				candidateFreqs[entry.Term()] += entry.Count()
+			}
+			entry, err = dict.Next()
+		}
+		if closeErr := dict.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return candidateFreqs, nil
+}
+
+// incrementBytes returns the smallest bytes greater than all the bytes with the prefix.
+func incrementBytes(in []byte) []byte {
+	rv := make([]byte, len(in))
+	copy(rv, in)
+	for i := len(rv) - 1; i >= 0; i-- {
+		rv[i] = rv[i] + 1
+		if rv[i] != 0 {
+			return rv
+		}
+	}
+	return nil
+}
+
+// similarity returns 1 - distance / length of the longer text, the similarity of the texts by the edit distance.
+func similarity(text1 string, text2 string) float64 {
+	runes1 := []rune(text1)
+	runes2 := []rune(text2)
+
+	maxLength := len(runes1)
+	if len(runes2) > maxLength {
+		maxLength = len(runes2)
+	}
+	if maxLength == 0 {
+		return 1.0
+	}
+
+	return 1.0 - float64(editDistance(runes1, runes2))/float64(maxLength)
+}
+
+// editDistance returns the levenshtein distance of the runes.
+func editDistance(runes1 []rune, runes2 []rune) int {
+	prev := make([]int, len(runes2)+1)
+	curr := make([]int, len(runes2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(runes1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(runes2); j++ {
+			cost := 1
+			if runes1[i-1] == runes2[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(runes2)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// sortOptions sorts the options by the frequency, the score and the text.
+func sortOptions(options []*proto.SuggestOption) {
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].Freq != options[j].Freq {
+			return options[i].Freq > options[j].Freq
+		}
+		if options[i].Score != options[j].Score {
+			return options[i].Score > options[j].Score
+		}
+		return options[i].Text < options[j].Text
+	})
+}
@@ -0,0 +1,207 @@
+package suggest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis/lang/en"
+	"github.com/mosuka/phalanx/proto"
+)
+
+// openShards indexes the titles into two shards alternately.
+func openShards(t *testing.T, titles []string) []*bluge.Reader {
+	writers := []*bluge.Writer{}
+	for i := 0; i < 2; i++ {
+		writer, err := bluge.OpenWriter(bluge.InMemoryOnlyConfig())
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		writers = append(writers, writer)
+	}
+
+	for i, title := range titles {
+		doc := bluge.NewDocument(string(rune('a' + i)))
+		doc.AddField(bluge.NewTextField("title", title))
+		doc.AddField(bluge.NewKeywordField("title_keyword", title))
+		doc.AddField(bluge.NewTextField("title_en", title).WithAnalyzer(en.NewAnalyzer()))
+		if err := writers[i%2].Update(doc.ID(), doc); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	readers := []*bluge.Reader{}
+	for _, writer := range writers {
+		reader, err := writer.Reader()
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		readers = append(readers, reader)
+		if err := writer.Close(); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	return readers
+}
+
+// suggest collects the candidates from each shard, and merges and reduces them as the coordinator does.
+func suggest(t *testing.T, suggester Suggester, readers []*bluge.Reader) *proto.Suggestion {
+	var collected *proto.Suggestion
+	for _, reader := range readers {
+		suggestion, err := suggester.Collect([]*bluge.Reader{reader})
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		collected = Merge(collected, suggestion)
+	}
+
+	return suggester.Reduce(collected)
+}
+
+func optionTexts(entry *proto.SuggestEntry) []string {
+	texts := []string{}
+	for _, option := range entry.Options {
+		texts = append(texts, option.Text)
+	}
+	return texts
+}
+
+var titles = []string{
+	"search engine",
+	"search engine written in go",
+	"search results",
+	"searching documents",
+	"seared steak",
+	"distributed search engine",
+}
+
+func TestTermSuggester(t *testing.T) {
+	readers := openShards(t, titles)
+
+	suggester, err := NewSuggester("term", map[string]interface{}{
+		"text":  "serch engine",
+		"field": "title",
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	suggestion := suggest(t, suggester, readers)
+	if len(suggestion.Entries) != 2 {
+		t.Fatalf("unexpected entries: %v\n", suggestion.Entries)
+	}
+
+	// The candidates are ranked by the document frequencies summed up across the shards.
+	if suggestion.Entries[0].Text != "serch" || suggestion.Entries[0].Freq != 0 {
+		t.Fatalf("unexpected entry: %v\n", suggestion.Entries[0])
+	}
+	if !reflect.DeepEqual(optionTexts(suggestion.Entries[0]), []string{"search"}) {
+		t.Fatalf("unexpected options: %v\n", optionTexts(suggestion.Entries[0]))
+	}
+	if suggestion.Entries[0].Options[0].Freq != 4 {
+		t.Fatalf("unexpected freq: %v\n", suggestion.Entries[0].Options[0].Freq)
+	}
+
+	// The tokens in the field are not corrected in the missing mode.
+	if suggestion.Entries[1].Text != "engine" || suggestion.Entries[1].Freq != 3 || len(suggestion.Entries[1].Options) != 0 {
+		t.Fatalf("unexpected entry: %v\n", suggestion.Entries[1])
+	}
+}
+
+func TestPhraseSuggester(t *testing.T) {
+	readers := openShards(t, titles)
+
+	suggester, err := NewSuggester("phrase", map[string]interface{}{
+		"text":       "Serch engne",
+		"field":      "title",
+		"max_errors": 2,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	suggestion := suggest(t, suggester, readers)
+	if len(suggestion.Entries) != 1 || len(suggestion.Entries[0].Options) == 0 {
+		t.Fatalf("unexpected entries: %v\n", suggestion.Entries)
+	}
+	if suggestion.Entries[0].Options[0].Text != "search engine" {
+		t.Fatalf("unexpected phrase: %v\n", suggestion.Entries[0].Options[0].Text)
+	}
+
+	// The correct phrase is not suggested.
+	suggester, err = NewSuggester("phrase", map[string]interface{}{
+		"text":  "search engine",
+		"field": "title",
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	suggestion = suggest(t, suggester, readers)
+	if len(suggestion.Entries[0].Options) != 0 {
+		t.Fatalf("unexpected options: %v\n", optionTexts(suggestion.Entries[0]))
+	}
+}
+
+func TestCompletionSuggester(t *testing.T) {
+	readers := openShards(t, titles)
+
+	// The last token is completed with the terms of the text field.
+	suggester, err := NewSuggester("completion", map[string]interface{}{
+		"text":  "distributed sea",
+		"field": "title",
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	suggestion := suggest(t, suggester, readers)
+	if !reflect.DeepEqual(optionTexts(suggestion.Entries[0]), []string{"distributed search", "distributed searching", "distributed seared"}) {
+		t.Fatalf("unexpected options: %v\n", optionTexts(suggestion.Entries[0]))
+	}
+
+	// The whole values of the keyword field are completed.
+	suggester, err = NewSuggester("completion", map[string]interface{}{
+		"text":  "search e",
+		"field": "title_keyword",
+		"analyzer": map[string]interface{}{
+			"tokenizer": map[string]interface{}{
+				"name": "single_token",
+			},
+		},
+		"size": 1,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	suggestion = suggest(t, suggester, readers)
+	if !reflect.DeepEqual(optionTexts(suggestion.Entries[0]), []string{"search engine"}) {
+		t.Fatalf("unexpected options: %v\n", optionTexts(suggestion.Entries[0]))
+	}
+
+	// The terms of the analyzed field are completed as they are indexed, stemmed and lower cased,
+	// and the text before the last token is kept as is.
+	suggester, err = NewSuggester("completion", map[string]interface{}{
+		"text":  "Distributed Sea",
+		"field": "title_en",
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	suggestion = suggest(t, suggester, readers)
+	if !reflect.DeepEqual(optionTexts(suggestion.Entries[0]), []string{"Distributed search", "Distributed sear"}) {
+		t.Fatalf("unexpected options: %v\n", optionTexts(suggestion.Entries[0]))
+	}
+	if suggestion.Entries[0].Options[0].Freq != 5 {
+		t.Fatalf("unexpected freq: %v\n", suggestion.Entries[0].Options[0].Freq)
+	}
+}
+
+func TestNewSuggester(t *testing.T) {
+	if _, err := NewSuggester("unknown", map[string]interface{}{}); err == nil {
+		t.Fatalf("expected error with unknown suggester type\n")
+	}
+
+	if _, err := NewSuggester("term", map[string]interface{}{"text": "search"}); err == nil {
+		t.Fatalf("expected error without field\n")
+	}
+}
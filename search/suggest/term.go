@@ -0,0 +1,240 @@
+package suggest
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/blugelabs/bluge"
+	"github.com/blugelabs/bluge/analysis"
+	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/proto"
+)
+
+type SuggestMode int
+
+const (
+	SuggestModeUnknown SuggestMode = iota
+	SuggestModeMissing
+	SuggestModePopular
+	SuggestModeAlways
+)
+
+// Maps for SuggestMode.
+var (
+	SuggestMode_name = map[SuggestMode]string{
+		SuggestModeUnknown: "unknown",
+		SuggestModeMissing: "missing",
+		SuggestModePopular: "popular",
+		SuggestModeAlways:  "always",
+	}
+	SuggestMode_value = map[string]SuggestMode{
+		"unknown": SuggestModeUnknown,
+		"missing": SuggestModeMissing,
+		"popular": SuggestModePopular,
+		"always":  SuggestModeAlways,
+	}
+)
+
+type TermSuggesterOptions struct {
+	Text          string                   `json:"text"`
+	Field         string                   `json:"field"`
+	Analyzer      analyzer.AnalyzerSetting `json:"analyzer"`
+	Size          int                      `json:"size"`
+	ShardSize     int                      `json:"shard_size"`
+	MaxEdits      int                      `json:"max_edits"`
+	PrefixLength  int                      `json:"prefix_length"`
+	MinWordLength int                      `json:"min_word_length"`
+	SuggestMode   string                   `json:"suggest_mode"`
+}
+
+func NewTermSuggesterOptions() TermSuggesterOptions {
+	return TermSuggesterOptions{
+		Size:          5,
+		MaxEdits:      2,
+		PrefixLength:  1,
+		MinWordLength: 4,
+		SuggestMode:   SuggestMode_name[SuggestModeMissing],
+	}
+}
+
+// Create new TermSuggester with given options.
+// Options example:
+// {
+//   "text": "serch engne",
+//   "field": "title",
+//   "size": 5,
+//   "shard_size": 5,
+//   "max_edits": 2,
+//   "prefix_length": 1,
+//   "min_word_length": 4,
+//   "suggest_mode": "missing"
+// }
+func NewTermSuggesterWithMap(opts map[string]interface{}) (*TermSuggester, error) {
+	bytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := NewTermSuggesterOptions()
+	if err := json.Unmarshal(bytes, &options); err != nil {
+		return nil, err
+	}
+
+	return NewTermSuggesterWithOptions(options)
+}
+
+func NewTermSuggesterWithOptions(opts TermSuggesterOptions) (*TermSuggester, error) {
+	if opts.Field == "" {
+		return nil, fmt.Errorf("field option does not exist")
+	}
+
+	if opts.Size < 1 {
+		return nil, fmt.Errorf("size option is unexpected: %v", opts.Size)
+	}
+
+	// shard_size defaults to size.
+	if opts.ShardSize < opts.Size {
+		opts.ShardSize = opts.Size
+	}
+
+	if opts.MaxEdits < 1 || opts.MaxEdits > MaxEdits {
+		return nil, fmt.Errorf("max_edits option is unexpected: %v", opts.MaxEdits)
+	}
+
+	suggestMode, ok := SuggestMode_value[opts.SuggestMode]
+	if !ok || suggestMode == SuggestModeUnknown {
+		return nil, fmt.Errorf("suggest_mode option is unexpected: %v", opts.SuggestMode)
+	}
+
+	textAnalyzer, err := newAnalyzer(opts.Analyzer)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer option is unexpected: %v", err)
+	}
+
+	return &TermSuggester{
+		termCollector: termCollector{
+			text:          opts.Text,
+			field:         opts.Field,
+			analyzer:      textAnalyzer,
+			shardSize:     opts.ShardSize,
+			maxEdits:      opts.MaxEdits,
+			prefixLength:  opts.PrefixLength,
+			minWordLength: opts.MinWordLength,
+		},
+		size:        opts.Size,
+		suggestMode: suggestMode,
+	}, nil
+}
+
+// termCollector collects the candidates of the corrections of each token of the text
+// from the term dictionary of the field.
+type termCollector struct {
+	text          string
+	field         string
+	analyzer      *analysis.Analyzer
+	shardSize     int
+	maxEdits      int
+	prefixLength  int
+	minWordLength int
+}
+
+// Collect returns an entry per token of the text with the document frequency of the token,
+// and the top shard_size candidates within max_edits by the document frequency.
+func (c *termCollector) Collect(readers []*bluge.Reader) (*proto.Suggestion, error) {
+	suggestion := &proto.Suggestion{
+		Entries: make([]*proto.SuggestEntry, 0),
+	}
+
+	for _, token := range c.analyzer.Analyze([]byte(c.text)) {
+		term := string(token.Term)
+
+		freq, err := docFreq(readers, c.field, term)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &proto.SuggestEntry{
+			Text:    term,
+			Offset:  int32(token.Start),
+			Length:  int32(token.End - token.Start),
+			Freq:    freq,
+			Options: make([]*proto.SuggestOption, 0),
+		}
+
+		// Short terms have too many candidates to be corrected.
+		if utf8.RuneCountInString(term) >= c.minWordLength {
+			candidateFreqs, err := candidates(readers, c.field, term, c.maxEdits, c.prefixLength)
+			if err != nil {
+				return nil, err
+			}
+			for candidate, candidateFreq := range candidateFreqs {
+				entry.Options = append(entry.Options, &proto.SuggestOption{
+					Text:  candidate,
+					Score: similarity(term, candidate),
+					Freq:  candidateFreq,
+				})
+			}
+			sortOptions(entry.Options)
+			if len(entry.Options) > c.shardSize {
+				entry.Options = entry.Options[:c.shardSize]
+			}
+		}
+
+		suggestion.Entries = append(suggestion.Entries, entry)
+	}
+
+	return suggestion, nil
+}
+
+// TermSuggester suggests the corrections of each token of the text
+// from the terms of the field within the edit distance, ranked by the document frequency.
+type TermSuggester struct {
+	termCollector
+	size        int
+	suggestMode SuggestMode
+}
+
+// Reduce filters the candidates with the suggest mode and takes the top size candidates of each token.
+// The missing mode suggests only for the tokens not in the field, and the popular mode suggests
+// only the candidates more frequent than the token.
+func (s *TermSuggester) Reduce(collected *proto.Suggestion) *proto.Suggestion {
+	suggestion := &proto.Suggestion{
+		Entries: make([]*proto.SuggestEntry, 0),
+	}
+	if collected == nil {
+		return suggestion
+	}
+
+	for _, collectedEntry := range collected.Entries {
+		entry := &proto.SuggestEntry{
+			Text:    collectedEntry.Text,
+			Offset:  collectedEntry.Offset,
+			Length:  collectedEntry.Length,
+			Freq:    collectedEntry.Freq,
+			Options: make([]*proto.SuggestOption, 0),
+		}
+
+		for _, option := range collectedEntry.Options {
+			switch s.suggestMode {
+			case SuggestModeMissing:
+				if collectedEntry.Freq > 0 {
+					continue
+				}
+			case SuggestModePopular:
+				if option.Freq <= collectedEntry.Freq {
+					continue
+				}
+			}
+			entry.Options = append(entry.Options, option)
+		}
+		sortOptions(entry.Options)
+		if len(entry.Options) > s.size {
+			entry.Options = entry.Options[:s.size]
+		}
+
+		suggestion.Entries = append(suggestion.Entries, entry)
+	}
+
+	return suggestion
+}
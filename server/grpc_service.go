@@ -125,3 +125,73 @@ func (s *GRPCIndexService) Search(ctx context.Context, req *proto.SearchRequest)
 
 	return resp, nil
 }
+
+func (s *GRPCIndexService) PutPipeline(ctx context.Context, req *proto.PutPipelineRequest) (*proto.PutPipelineResponse, error) {
+	resp, err := s.indexService.PutPipeline(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCIndexService) GetPipeline(ctx context.Context, req *proto.GetPipelineRequest) (*proto.GetPipelineResponse, error) {
+	resp, err := s.indexService.GetPipeline(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCIndexService) DeletePipeline(ctx context.Context, req *proto.DeletePipelineRequest) (*proto.DeletePipelineResponse, error) {
+	resp, err := s.indexService.DeletePipeline(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCIndexService) Explain(ctx context.Context, req *proto.ExplainRequest) (*proto.ExplainResponse, error) {
+	resp, err := s.indexService.Explain(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCIndexService) ValidateQuery(ctx context.Context, req *proto.ValidateQueryRequest) (*proto.ValidateQueryResponse, error) {
+	resp, err := s.indexService.ValidateQuery(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCIndexService) Suggest(ctx context.Context, req *proto.SuggestRequest) (*proto.SuggestResponse, error) {
+	resp, err := s.indexService.Suggest(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
+
+func (s *GRPCIndexService) Analyze(ctx context.Context, req *proto.AnalyzeRequest) (*proto.AnalyzeResponse, error) {
+	resp, err := s.indexService.Analyze(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp, nil
+}
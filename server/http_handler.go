@@ -269,6 +269,7 @@ func addDocumentsHandlerFunc(ctx *gin.Context) {
 
 	req := &proto.AddDocumentsRequest{}
 	req.IndexName = ctx.Param("index_name")
+	req.Pipeline = ctx.Query("pipeline")
 	req.Documents = make([]*proto.Document, 0)
 
 	reader := bufio.NewReader(ctx.Request.Body)
@@ -442,3 +443,303 @@ func searchHandlerFunc(ctx *gin.Context) {
 
 	ctx.Data(http.StatusOK, "application/json", respBytes)
 }
+
+func explainHandlerFunc(ctx *gin.Context) {
+	body, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marshaler, err := getMarshaler(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &proto.ExplainRequest{}
+	if err := marshaler.Unmarshal(body, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Override with the index name and the document ID specified by the URI.
+	req.IndexName = ctx.Param("index_name")
+	req.Id = ctx.Param("id")
+
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer clientCancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := client.Explain(clientCtx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respBytes, err := marshaler.Marshal(grpcResp)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json", respBytes)
+}
+
+func validateQueryHandlerFunc(ctx *gin.Context) {
+	body, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marshaler, err := getMarshaler(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &proto.ValidateQueryRequest{}
+	if err := marshaler.Unmarshal(body, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Override with the index name specified by the URI.
+	req.IndexName = ctx.Param("index_name")
+
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer clientCancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := client.ValidateQuery(clientCtx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respBytes, err := marshaler.Marshal(grpcResp)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json", respBytes)
+}
+
+func suggestHandlerFunc(ctx *gin.Context) {
+	body, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marshaler, err := getMarshaler(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &proto.SuggestRequest{}
+	if err := marshaler.Unmarshal(body, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Override with the index name specified by the URI.
+	req.IndexName = ctx.Param("index_name")
+
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer clientCancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := client.Suggest(clientCtx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respBytes, err := marshaler.Marshal(grpcResp)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json", respBytes)
+}
+
+func analyzeHandlerFunc(ctx *gin.Context) {
+	body, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marshaler, err := getMarshaler(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &proto.AnalyzeRequest{}
+	if err := marshaler.Unmarshal(body, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if indexName := ctx.Param("index_name"); indexName != "" {
+		req.IndexName = indexName
+	}
+	if field := ctx.Query("field"); field != "" {
+		req.Field = field
+	}
+
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer clientCancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := client.Analyze(clientCtx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respBytes, err := marshaler.Marshal(grpcResp)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json", respBytes)
+}
+
+func putPipelineHandlerFunc(ctx *gin.Context) {
+	body, err := ioutil.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marshaler, err := getMarshaler(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &proto.PutPipelineRequest{}
+	if err := marshaler.Unmarshal(body, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	req.PipelineName = ctx.Param("pipeline_name")
+
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer clientCancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	grpcResp, err := client.PutPipeline(clientCtx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respBytes, err := marshaler.Marshal(grpcResp)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json", respBytes)
+}
+
+func getPipelineHandlerFunc(ctx *gin.Context) {
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer clientCancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &proto.GetPipelineRequest{}
+	req.PipelineName = ctx.Param("pipeline_name")
+
+	grpcResp, err := client.GetPipeline(clientCtx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marshaler, err := getMarshaler(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respBytes, err := marshaler.Marshal(grpcResp)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json", respBytes)
+}
+
+func deletePipelineHandlerFunc(ctx *gin.Context) {
+	clientCtx, clientCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer clientCancel()
+
+	client, err := getClient(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	req := &proto.DeletePipelineRequest{}
+	req.PipelineName = ctx.Param("pipeline_name")
+
+	grpcResp, err := client.DeletePipeline(clientCtx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marshaler, err := getMarshaler(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respBytes, err := marshaler.Marshal(grpcResp)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json", respBytes)
+}
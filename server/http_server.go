@@ -73,6 +73,14 @@ func NewHTTPIndexServerWithTLS(httpAddress string, grpcAddress string, certifica
 	router.PUT("/v1/indexes/:index_name/documents", addDocumentsHandlerFunc)
 	router.DELETE("/v1/indexes/:index_name/documents", deleteDocumentsHandlerFunc)
 	router.POST("/v1/indexes/:index_name/_search", searchHandlerFunc)
+	router.POST("/v1/indexes/:index_name/_explain/:id", explainHandlerFunc)
+	router.POST("/v1/indexes/:index_name/_validate", validateQueryHandlerFunc)
+	router.POST("/v1/indexes/:index_name/_suggest", suggestHandlerFunc)
+	router.POST("/v1/_analyze", analyzeHandlerFunc)
+	router.POST("/v1/indexes/:index_name/_analyze", analyzeHandlerFunc)
+	router.PUT("/v1/pipelines/:pipeline_name", putPipelineHandlerFunc)
+	router.GET("/v1/pipelines/:pipeline_name", getPipelineHandlerFunc)
+	router.DELETE("/v1/pipelines/:pipeline_name", deletePipelineHandlerFunc)
 
 	listener, err := net.Listen("tcp", httpAddress)
 	if err != nil {
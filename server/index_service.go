@@ -7,25 +7,33 @@ import (
 	"math/rand"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/blugelabs/bluge"
 	"github.com/blugelabs/bluge/numeric/geo"
+	"github.com/blugelabs/bluge/search"
 	"github.com/jinzhu/copier"
 	"github.com/mosuka/phalanx/analysis/analyzer"
+	"github.com/mosuka/phalanx/analysis/resource"
 	phalanxclients "github.com/mosuka/phalanx/clients"
 	phalanxcluster "github.com/mosuka/phalanx/cluster"
 	"github.com/mosuka/phalanx/directory"
 	"github.com/mosuka/phalanx/errors"
 	"github.com/mosuka/phalanx/index"
+	"github.com/mosuka/phalanx/ingest"
 	"github.com/mosuka/phalanx/mapping"
 	phalanxmetastore "github.com/mosuka/phalanx/metastore"
 	"github.com/mosuka/phalanx/proto"
 	phalanxaggregations "github.com/mosuka/phalanx/search/aggregations"
+	phalanxcollapse "github.com/mosuka/phalanx/search/collapse"
+	phalanxfusion "github.com/mosuka/phalanx/search/fusion"
 	phalanxhighlight "github.com/mosuka/phalanx/search/highlight"
 	phalanxqueries "github.com/mosuka/phalanx/search/queries"
+	phalanxrescore "github.com/mosuka/phalanx/search/rescore"
+	phalanxsuggest "github.com/mosuka/phalanx/search/suggest"
 	"github.com/mosuka/phalanx/util/wildcard"
 	"github.com/thanhpk/randstr"
 	"go.uber.org/zap"
@@ -49,40 +57,51 @@ func shuffleNodes(nodeNames []string) {
 }
 
 type IndexService struct {
-	cluster            *phalanxcluster.Cluster
-	metastore          *phalanxmetastore.Metastore
-	certificateFile    string
-	commonName         string
-	logger             *zap.Logger
-	indexWriters       *index.IndexWriters
-	indexReaders       *index.IndexReaders
-	stopWatching       chan bool
-	indexerAssignment  map[string]map[string]string
-	searcherAssignment map[string]map[string][]string
-	clients            map[string]*phalanxclients.GRPCIndexClient
-	mutex              sync.RWMutex
+	cluster                *phalanxcluster.Cluster
+	metastore              *phalanxmetastore.Metastore
+	resourceReloadInterval time.Duration
+	certificateFile        string
+	commonName             string
+	logger                 *zap.Logger
+	indexWriters           *index.IndexWriters
+	indexReaders           *index.IndexReaders
+	stopWatching           chan bool
+	indexerAssignment      map[string]map[string]string
+	searcherAssignment     map[string]map[string][]string
+	clients                map[string]*phalanxclients.GRPCIndexClient
+	resourceReloader       *resource.Reloader
+	mutex                  sync.RWMutex
 }
 
-func NewIndexService(cluster *phalanxcluster.Cluster, metastore *phalanxmetastore.Metastore, certificateFile string, commonName string, logger *zap.Logger) (*IndexService, error) {
+func NewIndexService(cluster *phalanxcluster.Cluster, metastore *phalanxmetastore.Metastore, resourceReloadInterval time.Duration, certificateFile string, commonName string, logger *zap.Logger) (*IndexService, error) {
+	if resourceReloadInterval <= 0 {
+		return nil, fmt.Errorf("resource reload interval is unexpected: %v", resourceReloadInterval)
+	}
+
 	managerLogger := logger.Named("manager")
 
 	return &IndexService{
-		cluster:            cluster,
-		metastore:          metastore,
-		certificateFile:    certificateFile,
-		commonName:         commonName,
-		logger:             logger,
-		indexWriters:       index.NewIndexWriters(managerLogger),
-		indexReaders:       index.NewIndexReaders(managerLogger),
-		stopWatching:       make(chan bool),
-		indexerAssignment:  map[string]map[string]string{},
-		searcherAssignment: map[string]map[string][]string{},
-		clients:            map[string]*phalanxclients.GRPCIndexClient{},
-		mutex:              sync.RWMutex{},
+		cluster:                cluster,
+		metastore:              metastore,
+		resourceReloadInterval: resourceReloadInterval,
+		certificateFile:        certificateFile,
+		commonName:             commonName,
+		logger:                 logger,
+		indexWriters:           index.NewIndexWriters(managerLogger),
+		indexReaders:           index.NewIndexReaders(managerLogger),
+		stopWatching:           make(chan bool),
+		indexerAssignment:      map[string]map[string]string{},
+		searcherAssignment:     map[string]map[string][]string{},
+		clients:                map[string]*phalanxclients.GRPCIndexClient{},
+		mutex:                  sync.RWMutex{},
 	}, nil
 }
 
 func (s *IndexService) Start() error {
+	// Reload the analysis resources, such as word lists, when they change.
+	s.resourceReloader = resource.NewReloader(s.resourceReloadInterval, s.logger)
+	s.resourceReloader.Start()
+
 	// Watch metastore events and cluster events.
 	go func() {
 		for {
@@ -130,6 +149,7 @@ func (s *IndexService) Start() error {
 
 func (s *IndexService) Stop() error {
 	s.stopWatching <- true
+	s.resourceReloader.Stop()
 
 	// Close all index writers.
 	if err := s.indexWriters.CloseAll(); err != nil {
@@ -546,6 +566,13 @@ func (s *IndexService) Cluster(ctx context.Context, req *proto.ClusterRequest) (
 }
 
 func (s *IndexService) CreateIndex(ctx context.Context, req *proto.CreateIndexRequest) (*proto.CreateIndexResponse, error) {
+	// The pipelines directory cannot be used as an index.
+	if req.IndexName == phalanxmetastore.PipelinesDirName {
+		err := fmt.Errorf("index name is reserved: %s", req.IndexName)
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
 	// Check if the index has already been opened.
 	if s.metastore.IndexMetadataExists(req.IndexName) {
 		err := errors.ErrIndexMetadataAlreadyExists
@@ -571,6 +598,73 @@ func (s *IndexService) CreateIndex(ctx context.Context, req *proto.CreateIndexRe
 		}
 	}
 
+	// Load the analysis settings.
+	var analysisSetting analyzer.AnalysisSetting
+	if len(req.Analysis) > 0 {
+		if err := json.Unmarshal(req.Analysis, &analysisSetting); err != nil {
+			s.logger.Error(err.Error())
+			return nil, err
+		}
+	}
+	if err := analysisSetting.Validate(); err != nil {
+		s.logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Check the datetime formats, the dense vector settings, the languages and that the named analyzers referred to by fields exist.
+	for fieldName, fieldSetting := range indexMapping {
+		if err := mapping.ValidateDateTimeFormats(fieldSetting.Formats); err != nil {
+			s.logger.Error(err.Error(), zap.String("field_name", fieldName))
+			return nil, err
+		}
+		if fieldSetting.FieldType == mapping.DenseVectorField {
+			if err := mapping.ValidateDenseVectorSetting(fieldSetting.Dimension, fieldSetting.Similarity); err != nil {
+				s.logger.Error(err.Error(), zap.String("field_name", fieldName))
+				return nil, err
+			}
+		}
+		if fieldSetting.AnalyzerSetting.Language != "" {
+			if _, err := analysisSetting.NewAnalyzer(fieldSetting.AnalyzerSetting); err != nil {
+				s.logger.Error(err.Error(), zap.String("field_name", fieldName))
+				return nil, err
+			}
+		}
+		if fieldSetting.SearchAnalyzerSetting != nil {
+			if _, err := analysisSetting.NewAnalyzer(*fieldSetting.SearchAnalyzerSetting); err != nil {
+				s.logger.Error(err.Error(), zap.String("field_name", fieldName))
+				return nil, err
+			}
+		}
+		if fieldSetting.AnalyzerSetting.Name == "" {
+			continue
+		}
+		if _, err := analysisSetting.Resolve(fieldSetting.AnalyzerSetting); err != nil {
+			s.logger.Error(err.Error(), zap.String("field_name", fieldName))
+			return nil, err
+		}
+	}
+
+	if defaultAnalyzer.Name != "" {
+		if _, err := analysisSetting.Resolve(defaultAnalyzer); err != nil {
+			s.logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	// Load the dynamic policy.
+	dynamic, err := mapping.NewDynamicPolicy(req.Dynamic)
+	if err != nil {
+		s.logger.Error(err.Error())
+		return nil, err
+	}
+
+	// Check that the default pipeline exists.
+	if req.DefaultPipeline != "" && !s.metastore.PipelineExists(req.DefaultPipeline) {
+		err := errors.ErrPipelineDoesNotExist
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.DefaultPipeline))
+		return nil, err
+	}
+
 	// Make the index metadata.
 	indexMetadata := phalanxmetastore.NewIndexMetadata()
 	indexMetadata.IndexName = req.IndexName
@@ -580,6 +674,9 @@ func (s *IndexService) CreateIndex(ctx context.Context, req *proto.CreateIndexRe
 	indexMetadata.IndexMappingVersion = time.Now().UTC().UnixNano()
 	indexMetadata.DefaultSearchField = req.DefaultSearchField
 	indexMetadata.DefaultAnalyzer = defaultAnalyzer
+	indexMetadata.Dynamic = dynamic
+	indexMetadata.Analysis = analysisSetting
+	indexMetadata.DefaultPipeline = req.DefaultPipeline
 
 	// Make shards
 	numShards := req.NumShards
@@ -681,6 +778,19 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 
 	isRootRequest := req.ShardName == ""
 
+	// Preprocess documents with the ingest pipeline.
+	// Pipelines run only once on the root request, before the documents are routed to shards.
+	documents := req.Documents
+	pipelineErrors := make([]*proto.DocumentError, 0)
+	if isRootRequest {
+		var err error
+		documents, pipelineErrors, err = s.executePipeline(req)
+		if err != nil {
+			s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("pipeline_name", req.Pipeline))
+			return nil, err
+		}
+	}
+
 	// Assign shards to nodes.
 	assignedNodes := make(map[string][]string)
 	if isRootRequest {
@@ -697,7 +807,7 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 	// Assign documents.
 	addDocumentsRequests := make(map[string]*proto.AddDocumentsRequest)
 	if isRootRequest {
-		for _, doc := range req.Documents {
+		for _, doc := range documents {
 			shardName := s.metastore.GetResponsibleShard(req.IndexName, doc.Id)
 			if _, ok := addDocumentsRequests[shardName]; !ok {
 				addDocumentsRequests[shardName] = &proto.AddDocumentsRequest{
@@ -715,6 +825,7 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 	type addDocumentsResponse struct {
 		indexName string
 		shardName string
+		errors    []*proto.DocumentError
 		err       error
 	}
 
@@ -743,19 +854,40 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 					if nodeName == s.cluster.LocalNodeName() {
 						s.logger.Debug("adding documents", zap.String("node_name", nodeName), zap.String("index_name", request.IndexName), zap.String("shard_name", request.ShardName))
 
+						// Get mapping.
+						indexMapping, err := s.metastore.GetMapping(request.IndexName)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
+							return err
+						}
+
+						// Get dynamic policy.
+						dynamic, err := s.metastore.GetDynamicPolicy(request.IndexName)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
+							return err
+						}
+
+						// Get analyzers.
+						analyzers, err := s.metastore.GetAnalyzerCache(request.IndexName)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
+							return err
+						}
+
 						// Make batch.
 						batch := bluge.NewBatch()
+						docErrors := make([]*proto.DocumentError, 0)
 						for _, doc := range request.Documents {
-							// Get mapping.
-							indexMapping, err := s.metastore.GetMapping(request.IndexName)
-							if err != nil {
-								s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
-								return err
-							}
-
 							// Create bluge document.
-							blugeDoc, err := indexMapping.MakeDocument(doc)
+							blugeDoc, err := indexMapping.MakeDocument(doc, dynamic, analyzers)
 							if err != nil {
+								// Invalid documents are skipped and reported to the client.
+								if validationErr, ok := err.(*mapping.ValidationError); ok {
+									s.logger.Warn(err.Error(), zap.String("index_name", request.IndexName), zap.String("shard_name", request.ShardName), zap.String("id", doc.Id))
+									docErrors = append(docErrors, makeDocumentError(validationErr))
+									continue
+								}
 								s.logger.Error(err.Error(), zap.String("index_name", request.IndexName), zap.String("shard_name", request.ShardName), zap.Any("doc", doc))
 								return err
 							}
@@ -796,6 +928,15 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 							}
 							return err
 						}
+
+						// Update successfull.
+						responsesChan <- addDocumentsResponse{
+							indexName: request.IndexName,
+							shardName: request.ShardName,
+							errors:    docErrors,
+							err:       nil,
+						}
+						return nil
 					} else {
 						metadata, err := s.cluster.NodeMetadata(nodeName)
 						if err != nil {
@@ -832,7 +973,7 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 							return err
 						}
 
-						_, err = client.AddDocuments(ctx, request)
+						resp, err := client.AddDocuments(ctx, request)
 						if err != nil {
 							s.logger.Error(err.Error(), zap.String("node_name", nodeName), zap.String("address", grpcAddress), zap.String("index_name", request.IndexName), zap.String("shard_name", request.ShardName))
 							responsesChan <- addDocumentsResponse{
@@ -842,15 +983,16 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 							}
 							return err
 						}
-					}
 
-					// Update successfull.
-					responsesChan <- addDocumentsResponse{
-						indexName: request.IndexName,
-						shardName: request.ShardName,
-						err:       nil,
+						// Update successfull.
+						responsesChan <- addDocumentsResponse{
+							indexName: request.IndexName,
+							shardName: request.ShardName,
+							errors:    resp.Errors,
+							err:       nil,
+						}
+						return nil
 					}
-					return nil
 				}
 
 			})
@@ -863,14 +1005,148 @@ func (s *IndexService) AddDocuments(ctx context.Context, req *proto.AddDocuments
 	}
 	close(responsesChan)
 
+	resp := &proto.AddDocumentsResponse{
+		Errors: pipelineErrors,
+	}
 	for response := range responsesChan {
 		if response.err != nil {
 			s.logger.Error(response.err.Error(), zap.String("index_name", response.indexName), zap.String("shard_name", response.shardName))
 			return nil, response.err
 		}
+		resp.Errors = append(resp.Errors, response.errors...)
+	}
+
+	return resp, nil
+}
+
+// executePipeline runs the ingest pipeline of the request on the documents.
+// The pipeline in the request overrides the default pipeline of the index.
+// Documents rejected by the pipeline are reported as document errors, and dropped documents are omitted.
+func (s *IndexService) executePipeline(req *proto.AddDocumentsRequest) ([]*proto.Document, []*proto.DocumentError, error) {
+	docErrors := make([]*proto.DocumentError, 0)
+
+	pipelineName := req.Pipeline
+	if pipelineName == "" {
+		indexMetadata := s.metastore.GetIndexMetadata(req.IndexName)
+		if indexMetadata == nil {
+			return nil, nil, errors.ErrIndexMetadataDoesNotExist
+		}
+		pipelineName = indexMetadata.DefaultPipeline
+	}
+	if pipelineName == "" || pipelineName == ingest.NonePipelineName {
+		return req.Documents, docErrors, nil
+	}
+
+	pipelineSetting, err := s.metastore.GetPipeline(pipelineName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := ingest.NewPipeline(pipelineSetting)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	documents := make([]*proto.Document, 0, len(req.Documents))
+	for _, doc := range req.Documents {
+		processedDoc, err := pipeline.Execute(doc)
+		if err != nil {
+			s.logger.Warn(err.Error(), zap.String("index_name", req.IndexName), zap.String("pipeline_name", pipelineName), zap.String("id", doc.Id))
+			docErrors = append(docErrors, &proto.DocumentError{
+				Id:            doc.Id,
+				ReceivedValue: []byte("null"),
+				Reason:        err.Error(),
+			})
+			continue
+		}
+		if processedDoc == nil {
+			s.logger.Debug("document dropped", zap.String("index_name", req.IndexName), zap.String("pipeline_name", pipelineName), zap.String("id", doc.Id))
+			continue
+		}
+		documents = append(documents, processedDoc)
+	}
+
+	return documents, docErrors, nil
+}
+
+func (s *IndexService) PutPipeline(ctx context.Context, req *proto.PutPipelineRequest) (*proto.PutPipelineResponse, error) {
+	if !ingest.IsValidPipelineName(req.PipelineName) {
+		err := fmt.Errorf("%w: pipeline name is unexpected: %s", errors.ErrInvalidPipeline, req.PipelineName)
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName))
+		return nil, err
+	}
+
+	pipelineSetting, err := ingest.NewPipelineSetting(req.Pipeline)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName))
+		return nil, err
+	}
+
+	// Check that the processors can be created.
+	if _, err := ingest.NewPipeline(pipelineSetting); err != nil {
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName))
+		return nil, fmt.Errorf("%w: %v", errors.ErrInvalidPipeline, err)
+	}
+
+	if err := s.metastore.SetPipeline(req.PipelineName, pipelineSetting); err != nil {
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName))
+		return nil, err
+	}
+
+	return &proto.PutPipelineResponse{}, nil
+}
+
+func (s *IndexService) GetPipeline(ctx context.Context, req *proto.GetPipelineRequest) (*proto.GetPipelineResponse, error) {
+	pipelineSetting, err := s.metastore.GetPipeline(req.PipelineName)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName))
+		return nil, err
+	}
+
+	pipeline, err := pipelineSetting.Marshal()
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName))
+		return nil, err
+	}
+
+	return &proto.GetPipelineResponse{
+		PipelineName: req.PipelineName,
+		Pipeline:     pipeline,
+	}, nil
+}
+
+func (s *IndexService) DeletePipeline(ctx context.Context, req *proto.DeletePipelineRequest) (*proto.DeletePipelineResponse, error) {
+	// The pipeline used as the default pipeline of an index cannot be deleted.
+	for item := range s.metastore.IndexMetadataIter() {
+		if indexMetadata, ok := item.Val.(*phalanxmetastore.IndexMetadata); ok && indexMetadata.DefaultPipeline == req.PipelineName {
+			err := fmt.Errorf("%w: default pipeline of index %s", errors.ErrPipelineInUse, item.Key)
+			s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName), zap.String("index_name", item.Key))
+			return nil, err
+		}
+	}
+
+	if err := s.metastore.DeletePipeline(req.PipelineName); err != nil {
+		s.logger.Error(err.Error(), zap.String("pipeline_name", req.PipelineName))
+		return nil, err
+	}
+
+	return &proto.DeletePipelineResponse{}, nil
+}
+
+func makeDocumentError(err *mapping.ValidationError) *proto.DocumentError {
+	// The received value is kept as JSON so that its original type is preserved.
+	receivedValue, marshalErr := json.Marshal(err.ReceivedValue)
+	if marshalErr != nil {
+		receivedValue = []byte("null")
 	}
 
-	return &proto.AddDocumentsResponse{}, nil
+	return &proto.DocumentError{
+		Id:            err.DocumentId,
+		Field:         err.Field,
+		ExpectedType:  string(err.ExpectedType),
+		ReceivedValue: receivedValue,
+		Reason:        err.Reason,
+	}
 }
 
 func (s *IndexService) DeleteDocuments(ctx context.Context, req *proto.DeleteDocumentsRequest) (*proto.DeleteDocumentsResponse, error) {
@@ -1044,25 +1320,47 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 
 	isRootRequest := len(req.ShardNames) == 0
 
-	assignedNodes := make(map[string][]string)
+	// The documents referred to by the queries are fetched on the coordinator,
+	// so that all the shards search with the same values.
 	if isRootRequest {
-		for shardName, nodeNames := range s.searcherAssignment[req.IndexName] {
-			if len(nodeNames) == 0 {
-				err := fmt.Errorf("no nodes assigned")
-				s.logger.Warn(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
-				continue
+		request := &proto.SearchRequest{}
+		copier.Copy(request, req)
+		for _, query := range []**proto.Query{&request.Query, &request.PostFilter, &request.Knn} {
+			resolvedQuery, err := s.resolveLookups(ctx, req.IndexName, *query)
+			if err != nil {
+				s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+				return nil, err
 			}
-			shuffleNodes(nodeNames)
-
-			if _, ok := assignedNodes[nodeNames[0]]; !ok {
-				assignedNodes[nodeNames[0]] = []string{}
+			*query = resolvedQuery
+		}
+		if request.Rescore != nil {
+			rescoreQuery, err := s.resolveLookups(ctx, req.IndexName, request.Rescore.Query)
+			if err != nil {
+				s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+				return nil, err
+			}
+			request.Rescore = &proto.Rescore{
+				Query:   rescoreQuery,
+				Options: request.Rescore.Options,
 			}
-			assignedNodes[nodeNames[0]] = append(assignedNodes[nodeNames[0]], shardName)
 		}
-	} else {
-		assignedNodes[s.cluster.LocalNodeName()] = req.ShardNames
+		req = request
+	}
+
+	// Collapsing keeps the best document per group, which neither fusion nor rescoring preserves.
+	if isRootRequest && req.Collapse != nil && (req.Knn != nil || req.Rescore != nil) {
+		err := fmt.Errorf("collapse cannot be used with knn or rescore")
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
 	}
 
+	// A kNN query is searched separately from the query and fused on the coordinator.
+	if isRootRequest && req.Knn != nil {
+		return s.hybridSearch(ctx, req)
+	}
+
+	assignedNodes := s.assignNodes(req.IndexName, req.ShardNames)
+
 	type searchResponse struct {
 		nodeName   string
 		indexName  string
@@ -1070,18 +1368,38 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 		resp       *proto.SearchResponse
 		err        error
 	}
-	responsesChan := make(chan searchResponse, len(assignedNodes))
+
+	// The top documents of each shard are re-scored with the rescore query, so the shards are searched separately.
+	type searchTarget struct {
+		nodeName   string
+		shardNames []string
+	}
+	targets := make([]searchTarget, 0)
+	for nodeName, shardNames := range assignedNodes {
+		if req.Rescore == nil {
+			targets = append(targets, searchTarget{nodeName: nodeName, shardNames: shardNames})
+			continue
+		}
+		for _, shardName := range shardNames {
+			targets = append(targets, searchTarget{nodeName: nodeName, shardNames: []string{shardName}})
+		}
+	}
+	responsesChan := make(chan searchResponse, len(targets))
+
+	// The context of the errgroup is canceled when the nodes have been searched,
+	// so the inner hits are searched with the context of the request.
+	reqCtx := ctx
 
 	baseCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 	eg, ctx := errgroup.WithContext(baseCtx)
 
-	for nodeName, shardNames := range assignedNodes {
-		nodeName := nodeName
+	for _, target := range targets {
+		nodeName := target.nodeName
 
 		request := &proto.SearchRequest{}
 		copier.Copy(request, req)
-		request.ShardNames = shardNames
+		request.ShardNames = target.shardNames
 		request.Num = request.Start + request.Num
 		request.Start = 0
 
@@ -1135,33 +1453,8 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						}
 						return err
 					}
-					query, err := phalanxqueries.NewQuery(request.Query.Type, queryOpts)
-					if err != nil {
-						s.logger.Error(err.Error(), zap.Any("query", query))
-						responsesChan <- searchResponse{
-							nodeName:   nodeName,
-							indexName:  request.IndexName,
-							shardNames: request.ShardNames,
-							resp:       nil,
-							err:        err,
-						}
-						return err
-					}
-
-					blugeRequest := bluge.NewTopNSearch(int(request.Num), query).
-						SetFrom(int(request.Start)).
-						WithStandardAggregations().
-						ExplainScores().
-						IncludeLocations()
-
-					if request.SortBy != "" {
-						blugeRequest.SortBy([]string{request.SortBy})
-					} else {
-						blugeRequest.SortBy([]string{"-_score"})
-					}
-
-					// Set aggregations
-					aggs, err := phalanxaggregations.NewAggregations(request.Aggregations)
+					// Fill the options from the index mapping.
+					indexMapping, err := s.metastore.GetMapping(request.IndexName)
 					if err != nil {
 						s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
 						responsesChan <- searchResponse{
@@ -1173,11 +1466,7 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						}
 						return err
 					}
-					for name, agg := range aggs {
-						blugeRequest.AddAggregation(name, agg)
-					}
-
-					docMatchIter, err := bluge.MultiSearch(ctx, blugeRequest, readers...)
+					analysisSetting, err := s.metastore.GetAnalysis(request.IndexName)
 					if err != nil {
 						s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
 						responsesChan <- searchResponse{
@@ -1189,13 +1478,11 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						}
 						return err
 					}
+					phalanxqueries.ApplyIndexMapping(request.Query.Type, queryOpts, indexMapping, analysisSetting)
 
-					// Get hits.
-					resp.Hits = docMatchIter.Aggregations().Count()
-
-					docMatch, err := docMatchIter.Next()
+					query, err := phalanxqueries.NewQuery(request.Query.Type, queryOpts)
 					if err != nil {
-						s.logger.Error(err.Error())
+						s.logger.Error(err.Error(), zap.Any("query", query))
 						responsesChan <- searchResponse{
 							nodeName:   nodeName,
 							indexName:  request.IndexName,
@@ -1206,27 +1493,26 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						return err
 					}
 
-					indexMapping, err := s.metastore.GetMapping(request.IndexName)
-					if err != nil {
-						s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
-						responsesChan <- searchResponse{
-							nodeName:   nodeName,
-							indexName:  request.IndexName,
-							shardNames: request.ShardNames,
-							resp:       nil,
-							err:        err,
+					// The post filter narrows the hits, but not the documents that are aggregated.
+					hitsQuery := query
+					if request.PostFilter != nil {
+						var postFilterOpts map[string]interface{}
+						if err := json.Unmarshal(request.PostFilter.Options, &postFilterOpts); err != nil {
+							s.logger.Error(err.Error(), zap.Any("post_filter", request.PostFilter))
+							responsesChan <- searchResponse{
+								nodeName:   nodeName,
+								indexName:  request.IndexName,
+								shardNames: request.ShardNames,
+								resp:       nil,
+								err:        err,
+							}
+							return err
 						}
-						return err
-					}
-
-					// Make highlights.
+						phalanxqueries.ApplyIndexMapping(request.PostFilter.Type, postFilterOpts, indexMapping, analysisSetting)
 
-					highlightRequests := make(map[string]*phalanxhighlight.HighlightRequest)
-					for fieldName, highlight := range request.Highlights {
-						fmt.Println(fieldName, highlight)
-						opts := make(map[string]interface{})
-						if err := json.Unmarshal(highlight.Highlighter.Options, &opts); err != nil {
-							s.logger.Error(err.Error(), zap.String("field_name", fieldName), zap.String("highlighter_type", highlight.Highlighter.Type))
+						postFilter, err := phalanxqueries.NewQuery(request.PostFilter.Type, postFilterOpts)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.Any("post_filter", request.PostFilter))
 							responsesChan <- searchResponse{
 								nodeName:   nodeName,
 								indexName:  request.IndexName,
@@ -1236,9 +1522,19 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 							}
 							return err
 						}
-						highliter, err := phalanxhighlight.NewHighlighter(highlight.Highlighter.Type, opts)
+						hitsQuery = bluge.NewBooleanQuery().
+							AddMust(query).
+							AddMust(phalanxqueries.NewConstantScoreQuery(postFilter).SetBoost(0.0))
+					}
+
+					// The top documents of the shard are re-scored with the rescore query
+					// before they are merged on the coordinator.
+					num := int(request.Num)
+					var rescorer *phalanxrescore.Rescorer
+					if request.Rescore != nil {
+						rescorer, err = s.newRescorer(request.Rescore, request.SortBy, indexMapping, analysisSetting)
 						if err != nil {
-							s.logger.Error(err.Error(), zap.String("field_name", fieldName), zap.String("highlighter_type", highlight.Highlighter.Type))
+							s.logger.Error(err.Error(), zap.Any("rescore", request.Rescore))
 							responsesChan <- searchResponse{
 								nodeName:   nodeName,
 								indexName:  request.IndexName,
@@ -1248,20 +1544,170 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 							}
 							return err
 						}
+						if rescorer.WindowSize() > num {
+							num = rescorer.WindowSize()
+						}
+					}
 
-						highlightRequests[fieldName] = &phalanxhighlight.HighlightRequest{
-							Highlighter: highliter,
-							Num:         int(highlight.Num),
+					sortBy := "-_score"
+					if request.SortBy != "" {
+						sortBy = request.SortBy
+					}
+
+					// The top documents are searched until they contain the top groups of the collapse field.
+					var collapser *phalanxcollapse.Collapser
+					if request.Collapse != nil {
+						collapser, err = phalanxcollapse.NewCollapser(request.Collapse.Field, indexMapping, analysisSetting)
+						if err == nil {
+							num, err = collapser.Size(ctx, readers, hitsQuery, sortBy, num)
+						}
+						if err != nil {
+							s.logger.Error(err.Error(), zap.Any("collapse", request.Collapse))
+							responsesChan <- searchResponse{
+								nodeName:   nodeName,
+								indexName:  request.IndexName,
+								shardNames: request.ShardNames,
+								resp:       nil,
+								err:        err,
+							}
+							return err
 						}
 					}
 
-					// Make docs
-					for err == nil && docMatch != nil {
-						// Load stored fields.
+					blugeRequest := bluge.NewTopNSearch(num, hitsQuery).
+						SetFrom(int(request.Start)).
+						WithStandardAggregations().
+						IncludeLocations()
+
+					// Explaining scores has a cost, so it is only done on request.
+					if request.Explain {
+						blugeRequest.ExplainScores()
+					}
+
+					blugeRequest.SortBy([]string{sortBy})
+
+					// Set aggregations
+					aggs, err := phalanxaggregations.NewAggregations(request.Aggregations, indexMapping)
+					if err != nil {
+						s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
+						responsesChan <- searchResponse{
+							nodeName:   nodeName,
+							indexName:  request.IndexName,
+							shardNames: request.ShardNames,
+							resp:       nil,
+							err:        err,
+						}
+						return err
+					}
+					// With a post filter, the aggregations are computed by a separate search of the query.
+					var aggsRequest *bluge.TopNSearch
+					if hitsQuery != query {
+						aggsRequest = bluge.NewTopNSearch(0, query)
+					}
+					for name, agg := range aggs {
+						if aggsRequest != nil {
+							aggsRequest.AddAggregation(name, agg)
+							continue
+						}
+						blugeRequest.AddAggregation(name, agg)
+					}
+
+					docMatchIter, err := bluge.MultiSearch(ctx, blugeRequest, readers...)
+					if err != nil {
+						s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
+						responsesChan <- searchResponse{
+							nodeName:   nodeName,
+							indexName:  request.IndexName,
+							shardNames: request.ShardNames,
+							resp:       nil,
+							err:        err,
+						}
+						return err
+					}
+
+					aggsIter := docMatchIter
+					if aggsRequest != nil {
+						aggsIter, err = bluge.MultiSearch(ctx, aggsRequest, readers...)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.String("index_name", request.IndexName))
+							responsesChan <- searchResponse{
+								nodeName:   nodeName,
+								indexName:  request.IndexName,
+								shardNames: request.ShardNames,
+								resp:       nil,
+								err:        err,
+							}
+							return err
+						}
+					}
+
+					// Get hits.
+					resp.Hits = docMatchIter.Aggregations().Count()
+
+					docMatch, err := docMatchIter.Next()
+					if err != nil {
+						s.logger.Error(err.Error())
+						responsesChan <- searchResponse{
+							nodeName:   nodeName,
+							indexName:  request.IndexName,
+							shardNames: request.ShardNames,
+							resp:       nil,
+							err:        err,
+						}
+						return err
+					}
+
+					// Make highlights.
+
+					highlightRequests := make(map[string]*phalanxhighlight.HighlightRequest)
+					for fieldName, highlight := range request.Highlights {
+						fmt.Println(fieldName, highlight)
+						opts := make(map[string]interface{})
+						if err := json.Unmarshal(highlight.Highlighter.Options, &opts); err != nil {
+							s.logger.Error(err.Error(), zap.String("field_name", fieldName), zap.String("highlighter_type", highlight.Highlighter.Type))
+							responsesChan <- searchResponse{
+								nodeName:   nodeName,
+								indexName:  request.IndexName,
+								shardNames: request.ShardNames,
+								resp:       nil,
+								err:        err,
+							}
+							return err
+						}
+						highliter, err := phalanxhighlight.NewHighlighter(highlight.Highlighter.Type, opts)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.String("field_name", fieldName), zap.String("highlighter_type", highlight.Highlighter.Type))
+							responsesChan <- searchResponse{
+								nodeName:   nodeName,
+								indexName:  request.IndexName,
+								shardNames: request.ShardNames,
+								resp:       nil,
+								err:        err,
+							}
+							return err
+						}
+
+						highlightRequests[fieldName] = &phalanxhighlight.HighlightRequest{
+							Highlighter: highliter,
+							Num:         int(highlight.Num),
+						}
+					}
+
+					// Make docs
+					for err == nil && docMatch != nil {
+						// Load stored fields.
 						doc := &proto.Document{}
 						fields := make(map[string][]interface{})
 						highlights := make(map[string][]string)
 						err := docMatch.VisitStoredFields(func(field string, value []byte) bool {
+							if collapser != nil && field == collapser.Field() && doc.CollapseKey == "" {
+								collapseKey, err := collapser.Key(value)
+								if err != nil {
+									s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.Any("field", field))
+								}
+								doc.CollapseKey = collapseKey
+							}
+
 							switch field {
 							case mapping.IdFieldName:
 								doc.Id = string(value)
@@ -1325,6 +1771,12 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 											s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.Any("field", field))
 										}
 										fields[field] = append(fields[field], geo.Point{Lat: lat, Lon: lon})
+									case mapping.DenseVectorField:
+										vector, err := mapping.DecodeDenseVector(value)
+										if err != nil {
+											s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.Any("field", field))
+										}
+										fields[field] = append(fields[field], vector)
 									}
 								}
 							}
@@ -1375,6 +1827,23 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						}
 						doc.Highlights = highlightsBytes
 
+						// Serialize explanation.
+						if request.Explain && docMatch.Explanation != nil {
+							explanationBytes, err := json.Marshal(docMatch.Explanation)
+							if err != nil {
+								s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("doc_id", doc.Id))
+								responsesChan <- searchResponse{
+									nodeName:   nodeName,
+									indexName:  request.IndexName,
+									shardNames: request.ShardNames,
+									resp:       nil,
+									err:        err,
+								}
+								return err
+							}
+							doc.Explanation = explanationBytes
+						}
+
 						resp.Documents = append(resp.Documents, doc)
 
 						docMatch, err = docMatchIter.Next()
@@ -1391,6 +1860,30 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						}
 					}
 
+					// Keep the best document per group.
+					if collapser != nil {
+						resp.Documents = phalanxcollapse.Collapse(resp.Documents)
+					}
+
+					// Re-score the top documents.
+					if rescorer != nil {
+						resp.Documents, err = rescorer.Rescore(ctx, readers, resp.Documents, request.Explain)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+							responsesChan <- searchResponse{
+								nodeName:   nodeName,
+								indexName:  request.IndexName,
+								shardNames: request.ShardNames,
+								resp:       nil,
+								err:        err,
+							}
+							return err
+						}
+						if len(resp.Documents) > int(request.Num) {
+							resp.Documents = resp.Documents[:request.Num]
+						}
+					}
+
 					// Make aggregation responses.
 					resp.Aggregations = make(map[string]*proto.AggregationResponse)
 					for name, agg := range request.Aggregations {
@@ -1400,7 +1893,7 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						case phalanxaggregations.AggregationType_name[phalanxaggregations.AggregationTypeRange]:
 							fallthrough
 						case phalanxaggregations.AggregationType_name[phalanxaggregations.AggregationTypeDateRange]:
-							buckets := docMatchIter.Aggregations().Buckets(name)
+							buckets := aggsIter.Aggregations().Buckets(name)
 							resp.Aggregations[name] = &proto.AggregationResponse{
 								Buckets: make(map[string]float64),
 							}
@@ -1414,7 +1907,7 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 						case phalanxaggregations.AggregationType_name[phalanxaggregations.AggregationTypeMax]:
 							fallthrough
 						case phalanxaggregations.AggregationType_name[phalanxaggregations.AggregationTypeAvg]:
-							metric := docMatchIter.Aggregations().Metric(name)
+							metric := aggsIter.Aggregations().Metric(name)
 							resp.Aggregations[name] = &proto.AggregationResponse{
 								Buckets: make(map[string]float64),
 							}
@@ -1588,13 +2081,36 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 		}
 	}
 
+	// The best documents of the same group from different nodes are collapsed again.
+	if req.Collapse != nil {
+		resp.Documents = phalanxcollapse.Collapse(resp.Documents)
+	}
+
 	// Extract the specified range of documents.
-	if int(req.Start+req.Num) > len(resp.Documents) {
+	// The collapsed documents can be fewer than the start.
+	if int(req.Start) > len(resp.Documents) {
+		resp.Documents = resp.Documents[:0]
+	} else if int(req.Start+req.Num) > len(resp.Documents) {
 		resp.Documents = resp.Documents[req.Start:]
 	} else {
 		resp.Documents = resp.Documents[req.Start : req.Start+req.Num]
 	}
 
+	// The inner hits of the groups are searched from all the shards once the groups are fixed.
+	if isRootRequest && req.Collapse != nil && req.Collapse.InnerHits > 0 {
+		for _, doc := range resp.Documents {
+			if doc.CollapseKey == "" {
+				continue
+			}
+			innerHits, err := s.searchInnerHits(reqCtx, req, doc.CollapseKey)
+			if err != nil {
+				s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("collapse_key", doc.CollapseKey))
+				return nil, err
+			}
+			doc.InnerHits = innerHits
+		}
+	}
+
 	// Extract top n aggregations.
 	for aggName, aggResp := range resp.Aggregations {
 		aggReq := req.Aggregations[aggName]
@@ -1626,77 +2142,989 @@ func (s *IndexService) Search(ctx context.Context, req *proto.SearchRequest) (*p
 	return resp, nil
 }
 
-type sortOrder int
+// assignNodes returns the shards of the index to search for each node.
+// A root request searches each shard on one of the nodes the shard is assigned to,
+// and a request with the shard names searches them on the local node.
+func (s *IndexService) assignNodes(indexName string, shardNames []string) map[string][]string {
+	assignedNodes := make(map[string][]string)
+	if len(shardNames) > 0 {
+		assignedNodes[s.cluster.LocalNodeName()] = shardNames
+		return assignedNodes
+	}
 
-const (
-	sortOrderAsc sortOrder = iota
-	sortOrderDesc
-)
+	for shardName, nodeNames := range s.searcherAssignment[indexName] {
+		if len(nodeNames) == 0 {
+			err := fmt.Errorf("no nodes assigned")
+			s.logger.Warn(err.Error(), zap.String("index_name", indexName), zap.String("shard_name", shardName))
+			continue
+		}
+		shuffleNodes(nodeNames)
 
-func mergeDocs(sortBy string, docs1 []*proto.Document, docs2 []*proto.Document) []*proto.Document {
-	if len(docs1) == 0 {
-		return docs2
+		if _, ok := assignedNodes[nodeNames[0]]; !ok {
+			assignedNodes[nodeNames[0]] = []string{}
+		}
+		assignedNodes[nodeNames[0]] = append(assignedNodes[nodeNames[0]], shardName)
 	}
 
-	if len(docs2) == 0 {
-		return docs1
+	return assignedNodes
+}
+
+// searchInnerHits searches the top documents of the group of the collapse key.
+// The group is searched with the query narrowed by the post filter of the collapse key,
+// so that the documents are scored the same as the collapsed documents.
+func (s *IndexService) searchInnerHits(ctx context.Context, req *proto.SearchRequest, collapseKey string) ([]*proto.Document, error) {
+	indexMapping, err := s.metastore.GetMapping(req.IndexName)
+	if err != nil {
+		return nil, err
+	}
+	analysisSetting, err := s.metastore.GetAnalysis(req.IndexName)
+	if err != nil {
+		return nil, err
+	}
+	collapser, err := phalanxcollapse.NewCollapser(req.Collapse.Field, indexMapping, analysisSetting)
+	if err != nil {
+		return nil, err
 	}
 
-	order := sortOrderAsc
-	field := sortBy
-	if strings.HasPrefix(sortBy, "-") {
-		order = sortOrderDesc
-		field = sortBy[1:]
+	var groupFilter map[string]interface{}
+	switch collapser.FieldType() {
+	case mapping.NumericField:
+		value, err := strconv.ParseFloat(collapseKey, 64)
+		if err != nil {
+			return nil, err
+		}
+		groupFilter = map[string]interface{}{
+			"type": phalanxqueries.QueryType_name[phalanxqueries.QueryTypeNumericRange],
+			"options": map[string]interface{}{
+				"min":           value,
+				"max":           value,
+				"inclusive_min": true,
+				"inclusive_max": true,
+				"field":         collapser.Field(),
+			},
+		}
+	default:
+		groupFilter = map[string]interface{}{
+			"type": phalanxqueries.QueryType_name[phalanxqueries.QueryTypeTerm],
+			"options": map[string]interface{}{
+				"term":  collapseKey,
+				"field": collapser.Field(),
+			},
+		}
 	}
 
-	retDocs := make([]*proto.Document, 0)
+	postFilter := groupFilter
+	if req.PostFilter != nil {
+		postFilter = map[string]interface{}{
+			"type": phalanxqueries.QueryType_name[phalanxqueries.QueryTypeBoolean],
+			"options": map[string]interface{}{
+				"must": []interface{}{
+					map[string]interface{}{
+						"type":    req.PostFilter.Type,
+						"options": json.RawMessage(req.PostFilter.Options),
+					},
+					groupFilter,
+				},
+			},
+		}
+	}
+	postFilterOpts, err := json.Marshal(postFilter["options"])
+	if err != nil {
+		return nil, err
+	}
 
-	var sortValue1 float64
-	var sortValue2 float64
+	innerHitsRequest := &proto.SearchRequest{}
+	copier.Copy(innerHitsRequest, req)
+	innerHitsRequest.Collapse = nil
+	innerHitsRequest.Aggregations = nil
+	innerHitsRequest.Start = 0
+	innerHitsRequest.Num = req.Collapse.InnerHits
+	innerHitsRequest.PostFilter = &proto.Query{
+		Type:    postFilter["type"].(string),
+		Options: postFilterOpts,
+	}
 
-	if field == mapping.ScoreFieldName {
-		sortValue1 = docs1[0].Score
-		sortValue2 = docs2[0].Score
-	} else {
-		fields1 := make(map[string]interface{})
-		json.Unmarshal(docs1[0].Fields, &fields1)
+	resp, err := s.Search(ctx, innerHitsRequest)
+	if err != nil {
+		return nil, err
+	}
 
-		fields2 := make(map[string]interface{})
-		json.Unmarshal(docs2[0].Fields, &fields2)
+	return resp.Documents, nil
+}
 
-		var ok bool
-		sortValue1, ok = fields1[field].(float64)
-		if !ok {
-			sortValue1 = 0.0
-		}
-		sortValue2, ok = fields2[field].(float64)
-		if !ok {
-			sortValue2 = 0.0
-		}
+func (s *IndexService) hybridSearch(ctx context.Context, req *proto.SearchRequest) (*proto.SearchResponse, error) {
+	knnRequest := &proto.SearchRequest{}
+	copier.Copy(knnRequest, req)
+	knnRequest.Query = req.Knn
+	knnRequest.Knn = nil
+	knnRequest.Fusion = nil
+
+	// Without a query, the kNN query is an ordinary search.
+	if req.Query == nil {
+		return s.Search(ctx, knnRequest)
 	}
 
-	for len(docs1) > 0 && len(docs2) > 0 {
-		// Add document with high scores to the list.
-		var doc *proto.Document
-		if order == sortOrderDesc {
-			if sortValue1 > sortValue2 {
-				doc, docs1 = docs1[0], docs1[1:]
-			} else {
-				doc, docs2 = docs2[0], docs2[1:]
-			}
-		} else {
-			if sortValue1 < sortValue2 {
-				doc, docs1 = docs1[0], docs1[1:]
-			} else {
-				doc, docs2 = docs2[0], docs2[1:]
+	// The documents are ranked by the fused scores.
+	if req.SortBy != "" && req.SortBy != "-"+mapping.ScoreFieldName {
+		err := fmt.Errorf("sort_by cannot be used with knn and query: %s", req.SortBy)
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	fusionType := phalanxfusion.FusionType_name[phalanxfusion.FusionTypeRrf]
+	fusionOpts := make(map[string]interface{})
+	if req.Fusion != nil {
+		if req.Fusion.Type != "" {
+			fusionType = req.Fusion.Type
+		}
+		if len(req.Fusion.Options) > 0 {
+			if err := json.Unmarshal(req.Fusion.Options, &fusionOpts); err != nil {
+				s.logger.Error(err.Error(), zap.Any("fusion", req.Fusion))
+				return nil, err
 			}
 		}
-		retDocs = append(retDocs, doc)
+	}
+	fusion, err := phalanxfusion.NewFusion(fusionType, fusionOpts)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("fusion_type", fusionType))
+		return nil, err
 	}
 
-	// Append the remaining list to the end.
-	retDocs = append(retDocs, docs1...)
-	retDocs = append(retDocs, docs2...)
+	windowSize := fusion.WindowSize()
+	if int(req.Start+req.Num) > windowSize {
+		windowSize = int(req.Start + req.Num)
+	}
 
-	return retDocs
+	queryRequest := &proto.SearchRequest{}
+	copier.Copy(queryRequest, req)
+	queryRequest.Knn = nil
+	queryRequest.Fusion = nil
+	queryRequest.Start = 0
+	queryRequest.Num = int32(windowSize)
+	queryRequest.SortBy = ""
+
+	// Aggregations and rescoring are done by the query only.
+	knnRequest.Aggregations = nil
+	knnRequest.Rescore = nil
+	knnRequest.Start = 0
+	knnRequest.Num = int32(windowSize)
+	knnRequest.SortBy = ""
+
+	queryResp, err := s.Search(ctx, queryRequest)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	knnResp, err := s.Search(ctx, knnRequest)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	hits, err := s.hybridHits(ctx, req)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	resp := &proto.SearchResponse{
+		IndexName:    req.IndexName,
+		Hits:         hits,
+		Documents:    fusion.Fuse(queryResp.Documents, knnResp.Documents),
+		Aggregations: queryResp.Aggregations,
+	}
+
+	// Extract the specified range of documents.
+	if int(req.Start) > len(resp.Documents) {
+		resp.Documents = resp.Documents[:0]
+	} else if int(req.Start+req.Num) > len(resp.Documents) {
+		resp.Documents = resp.Documents[req.Start:]
+	} else {
+		resp.Documents = resp.Documents[req.Start : req.Start+req.Num]
+	}
+
+	return resp, nil
+}
+
+// hybridHits counts the documents matching either the query or the kNN query of the hybrid search.
+func (s *IndexService) hybridHits(ctx context.Context, req *proto.SearchRequest) (uint64, error) {
+	hitsQueryOpts, err := json.Marshal(map[string]interface{}{
+		"should": []interface{}{
+			map[string]interface{}{
+				"type":    req.Query.Type,
+				"options": json.RawMessage(req.Query.Options),
+			},
+			map[string]interface{}{
+				"type":    req.Knn.Type,
+				"options": json.RawMessage(req.Knn.Options),
+			},
+		},
+		"min_should": 1,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	hitsRequest := &proto.SearchRequest{
+		IndexName: req.IndexName,
+		Query: &proto.Query{
+			Type:    phalanxqueries.QueryType_name[phalanxqueries.QueryTypeBoolean],
+			Options: hitsQueryOpts,
+		},
+		Start:      0,
+		Num:        0,
+		PostFilter: req.PostFilter,
+	}
+
+	hitsResp, err := s.Search(ctx, hitsRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	return hitsResp.Hits, nil
+}
+
+type sortOrder int
+
+const (
+	sortOrderAsc sortOrder = iota
+	sortOrderDesc
+)
+
+func sortValue(field string, doc *proto.Document) float64 {
+	if field == mapping.ScoreFieldName {
+		return doc.Score
+	}
+
+	fields := make(map[string]interface{})
+	json.Unmarshal(doc.Fields, &fields)
+
+	// Stored fields are returned as lists of values.
+	value := fields[field]
+	if values, ok := value.([]interface{}); ok && len(values) > 0 {
+		value = values[0]
+	}
+	f64Value, ok := value.(float64)
+	if !ok {
+		return 0.0
+	}
+
+	return f64Value
+}
+
+func mergeDocs(sortBy string, docs1 []*proto.Document, docs2 []*proto.Document) []*proto.Document {
+	if len(docs1) == 0 {
+		return docs2
+	}
+
+	if len(docs2) == 0 {
+		return docs1
+	}
+
+	if sortBy == "" {
+		sortBy = "-" + mapping.ScoreFieldName
+	}
+
+	order := sortOrderAsc
+	field := sortBy
+	if strings.HasPrefix(sortBy, "-") {
+		order = sortOrderDesc
+		field = sortBy[1:]
+	}
+
+	retDocs := make([]*proto.Document, 0)
+
+	for len(docs1) > 0 && len(docs2) > 0 {
+		sortValue1 := sortValue(field, docs1[0])
+		sortValue2 := sortValue(field, docs2[0])
+
+		// Add document with high scores to the list.
+		// The re-scored documents are ranked ahead of the others, since their scores are not comparable.
+		var doc *proto.Document
+		if field == mapping.ScoreFieldName && docs1[0].Rescored != docs2[0].Rescored {
+			if docs1[0].Rescored {
+				doc, docs1 = docs1[0], docs1[1:]
+			} else {
+				doc, docs2 = docs2[0], docs2[1:]
+			}
+		} else if order == sortOrderDesc {
+			if sortValue1 > sortValue2 {
+				doc, docs1 = docs1[0], docs1[1:]
+			} else {
+				doc, docs2 = docs2[0], docs2[1:]
+			}
+		} else {
+			if sortValue1 < sortValue2 {
+				doc, docs1 = docs1[0], docs1[1:]
+			} else {
+				doc, docs2 = docs2[0], docs2[1:]
+			}
+		}
+		retDocs = append(retDocs, doc)
+	}
+
+	// Append the remaining list to the end.
+	retDocs = append(retDocs, docs1...)
+	retDocs = append(retDocs, docs2...)
+
+	return retDocs
+}
+
+// resolveLookups returns the query whose options that refer to other documents are replaced with
+// the values fetched from the documents, such as terms_lookup of terms queries and ids of more_like_this queries.
+func (s *IndexService) resolveLookups(ctx context.Context, indexName string, query *proto.Query) (*proto.Query, error) {
+	if query == nil || len(query.Options) == 0 {
+		return query, nil
+	}
+
+	var queryOpts map[string]interface{}
+	if err := json.Unmarshal(query.Options, &queryOpts); err != nil {
+		return nil, err
+	}
+
+	lookup := func(lookupIndexName string, id string, fields []string) (map[string][]string, error) {
+		return s.lookupDocument(ctx, lookupIndexName, id, fields)
+	}
+	if err := phalanxqueries.ResolveLookups(indexName, query.Type, queryOpts, lookup); err != nil {
+		return nil, err
+	}
+
+	queryOptsBytes, err := json.Marshal(queryOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Query{
+		Type:    query.Type,
+		Options: queryOptsBytes,
+	}, nil
+}
+
+// lookupDocument searches the document by the id, and returns the values of the fields as strings.
+// No values are returned if the document does not exist.
+func (s *IndexService) lookupDocument(ctx context.Context, indexName string, id string, fields []string) (map[string][]string, error) {
+	idsOpts, err := json.Marshal(map[string]interface{}{
+		"ids": []string{id},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Search(ctx, &proto.SearchRequest{
+		IndexName: indexName,
+		Query: &proto.Query{
+			Type:    phalanxqueries.QueryType_name[phalanxqueries.QueryTypeIds],
+			Options: idsOpts,
+		},
+		Num:    1,
+		Fields: fields,
+	})
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", indexName), zap.String("doc_id", id))
+		return nil, err
+	}
+
+	fieldValues := make(map[string][]string)
+	for _, doc := range resp.Documents {
+		docFields := make(map[string][]interface{})
+		if err := json.Unmarshal(doc.Fields, &docFields); err != nil {
+			s.logger.Error(err.Error(), zap.String("index_name", indexName), zap.String("doc_id", id))
+			return nil, err
+		}
+		for field, values := range docFields {
+			for _, value := range values {
+				switch v := value.(type) {
+				case string:
+					fieldValues[field] = append(fieldValues[field], v)
+				default:
+					fieldValues[field] = append(fieldValues[field], fmt.Sprintf("%v", v))
+				}
+			}
+		}
+	}
+
+	return fieldValues, nil
+}
+
+// newRescorer creates the rescorer of the request with the rescore query filled from the index mapping.
+// Rescoring combines the scores of the documents, so the documents must be sorted by the score.
+func (s *IndexService) newRescorer(rescore *proto.Rescore, sortBy string, indexMapping mapping.IndexMapping, analysisSetting analyzer.AnalysisSetting) (*phalanxrescore.Rescorer, error) {
+	if sortBy != "" && sortBy != "-_score" {
+		return nil, fmt.Errorf("rescore cannot be used with sort_by: %s", sortBy)
+	}
+
+	if rescore.Query == nil {
+		return nil, fmt.Errorf("rescore query does not exist")
+	}
+
+	var queryOpts map[string]interface{}
+	if err := json.Unmarshal(rescore.Query.Options, &queryOpts); err != nil {
+		return nil, err
+	}
+	phalanxqueries.ApplyIndexMapping(rescore.Query.Type, queryOpts, indexMapping, analysisSetting)
+
+	query, err := phalanxqueries.NewQuery(rescore.Query.Type, queryOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	rescoreOpts := make(map[string]interface{})
+	if len(rescore.Options) > 0 {
+		if err := json.Unmarshal(rescore.Options, &rescoreOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	return phalanxrescore.NewRescorerWithMap(query, rescoreOpts)
+}
+
+// Explain explains the score of the document with the given ID for the query,
+// or the clauses of the query that the document does not match.
+// The request is routed to a node that searches the shard responsible for the document.
+func (s *IndexService) Explain(ctx context.Context, req *proto.ExplainRequest) (*proto.ExplainResponse, error) {
+	if !s.metastore.IndexMetadataExists(req.IndexName) {
+		err := errors.ErrIndexMetadataDoesNotExist
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	if req.Id == "" {
+		err := errors.ErrDocumentIdDoesNotExist
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	if req.Query == nil {
+		err := fmt.Errorf("query does not exist")
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	isRootRequest := req.ShardName == ""
+
+	shardName := req.ShardName
+	nodeName := s.cluster.LocalNodeName()
+	if isRootRequest {
+		query, err := s.resolveLookups(ctx, req.IndexName, req.Query)
+		if err != nil {
+			s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+			return nil, err
+		}
+		request := &proto.ExplainRequest{}
+		copier.Copy(request, req)
+		request.Query = query
+		req = request
+
+		shardName = s.metastore.GetResponsibleShard(req.IndexName, req.Id)
+		nodeNames := s.searcherAssignment[req.IndexName][shardName]
+		if len(nodeNames) == 0 {
+			err := fmt.Errorf("no nodes assigned")
+			s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
+			return nil, err
+		}
+		shuffleNodes(nodeNames)
+		nodeName = nodeNames[0]
+	}
+
+	if nodeName != s.cluster.LocalNodeName() {
+		metadata, err := s.cluster.NodeMetadata(nodeName)
+		if err != nil {
+			s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("node_name", nodeName))
+			return nil, err
+		}
+
+		nodeAddr, err := s.cluster.NodeAddress(nodeName)
+		if err != nil {
+			s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("node_name", nodeName))
+			return nil, err
+		}
+
+		grpcAddr := fmt.Sprintf("%s:%d", nodeAddr, metadata.GrpcPort)
+		client, ok := s.clients[grpcAddr]
+		if !ok {
+			err := errors.ErrNodeDoesNotFound
+			s.logger.Error(err.Error(), zap.String("node_name", nodeName), zap.String("grpc_address", grpcAddr))
+			return nil, err
+		}
+
+		request := &proto.ExplainRequest{}
+		copier.Copy(request, req)
+		request.ShardName = shardName
+
+		baseCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		defer cancel()
+
+		return client.Explain(baseCtx, request)
+	}
+
+	// local node
+	reader, err := s.indexReaders.Get(req.IndexName, shardName)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
+		return nil, err
+	}
+
+	var queryOpts map[string]interface{}
+	if err := json.Unmarshal(req.Query.Options, &queryOpts); err != nil {
+		s.logger.Error(err.Error(), zap.Any("query", req.Query))
+		return nil, err
+	}
+	// Fill the options from the index mapping.
+	indexMapping, err := s.metastore.GetMapping(req.IndexName)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+	analysisSetting, err := s.metastore.GetAnalysis(req.IndexName)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+	phalanxqueries.ApplyIndexMapping(req.Query.Type, queryOpts, indexMapping, analysisSetting)
+
+	query, err := phalanxqueries.NewQuery(req.Query.Type, queryOpts)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.Any("query", req.Query))
+		return nil, err
+	}
+
+	// Check that the document exists, to tell a missing document from a document that does not match.
+	idQuery := bluge.NewTermQuery(req.Id).SetField(mapping.IdFieldName)
+	docMatchIter, err := reader.BlugeReader().Search(ctx, bluge.NewTopNSearch(1, idQuery))
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
+		return nil, err
+	}
+	docMatch, err := docMatchIter.Next()
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
+		return nil, err
+	}
+	if docMatch == nil {
+		err := errors.ErrDocumentDoesNotExist
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("doc_id", req.Id))
+		return nil, err
+	}
+
+	blugeRequest := bluge.NewTopNSearch(1, phalanxqueries.NewExplainQuery(query, req.Id)).
+		ExplainScores()
+	docMatchIter, err = reader.BlugeReader().Search(ctx, blugeRequest)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
+		return nil, err
+	}
+	docMatch, err = docMatchIter.Next()
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
+		return nil, err
+	}
+
+	resp := &proto.ExplainResponse{
+		IndexName: req.IndexName,
+		Id:        req.Id,
+		Matched:   docMatch != nil,
+	}
+
+	var explanation *search.Explanation
+	if docMatch != nil && docMatch.Explanation != nil {
+		explanation = docMatch.Explanation
+	} else {
+		// Explain the clauses that the document does not match.
+		indexMetadata := s.metastore.GetIndexMetadata(req.IndexName)
+		defaultSearchField := indexMetadata.DefaultSearchField
+		if defaultSearchField == "" {
+			defaultSearchField = mapping.AllFieldName
+		}
+		defaultAnalyzer, err := indexMetadata.AnalyzerCache().Get(indexMetadata.DefaultAnalyzer)
+		if err != nil {
+			s.logger.Warn(err.Error(), zap.String("index_name", req.IndexName))
+			defaultAnalyzer = nil
+		}
+		explanation, err = phalanxqueries.ExplainNoMatch(ctx, reader.BlugeReader(), query, req.Id, defaultSearchField, defaultAnalyzer)
+		if err != nil {
+			s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("shard_name", shardName))
+			return nil, err
+		}
+	}
+	explanationBytes, err := json.Marshal(explanation)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("doc_id", req.Id))
+		return nil, err
+	}
+	resp.Explanation = explanationBytes
+
+	return resp, nil
+}
+
+// ValidateQuery checks the query without executing it.
+// It returns the errors in the query and its nested queries, such as unknown query types,
+// invalid options and fields that do not exist in the index mapping or have an unexpected type,
+// and the query as it is interpreted.
+// The query is validated on the node that receives the request.
+func (s *IndexService) ValidateQuery(ctx context.Context, req *proto.ValidateQueryRequest) (*proto.ValidateQueryResponse, error) {
+	indexMetadata := s.metastore.GetIndexMetadata(req.IndexName)
+	if indexMetadata == nil {
+		err := errors.ErrIndexMetadataDoesNotExist
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	resp := &proto.ValidateQueryResponse{}
+
+	queryType := ""
+	var queryOpts map[string]interface{}
+	if req.Query != nil {
+		queryType = req.Query.Type
+		if len(req.Query.Options) > 0 {
+			if err := json.Unmarshal(req.Query.Options, &queryOpts); err != nil {
+				resp.Errors = append(resp.Errors, &proto.QueryError{
+					Path:   "query",
+					Type:   queryType,
+					Reason: fmt.Sprintf("query options are not an object: %v", err),
+				})
+				return resp, nil
+			}
+		}
+	}
+	if queryOpts == nil {
+		queryOpts = make(map[string]interface{})
+	}
+
+	// Fetch the documents referred to by the queries, as the search does.
+	lookup := func(indexName string, id string, fields []string) (map[string][]string, error) {
+		return s.lookupDocument(ctx, indexName, id, fields)
+	}
+	if err := phalanxqueries.ResolveLookups(req.IndexName, queryType, queryOpts, lookup); err != nil {
+		resp.Errors = append(resp.Errors, &proto.QueryError{
+			Path:   "query",
+			Type:   queryType,
+			Reason: err.Error(),
+		})
+		return resp, nil
+	}
+
+	// Fill the options from the index mapping, as the search does.
+	phalanxqueries.ApplyIndexMapping(queryType, queryOpts, indexMetadata.IndexMapping, indexMetadata.Analysis)
+
+	for _, queryError := range phalanxqueries.ValidateQuery(queryType, queryOpts, indexMetadata.IndexMapping) {
+		resp.Errors = append(resp.Errors, &proto.QueryError{
+			Path:   queryError.Path,
+			Type:   queryError.Type,
+			Field:  queryError.Field,
+			Reason: queryError.Reason,
+		})
+	}
+
+	if query, err := phalanxqueries.NewQuery(queryType, queryOpts); err == nil {
+		defaultSearchField := indexMetadata.DefaultSearchField
+		if defaultSearchField == "" {
+			defaultSearchField = mapping.AllFieldName
+		}
+		defaultAnalyzer, err := indexMetadata.AnalyzerCache().Get(indexMetadata.DefaultAnalyzer)
+		if err != nil {
+			s.logger.Warn(err.Error(), zap.String("index_name", req.IndexName))
+			defaultAnalyzer = nil
+		}
+		resp.RewrittenQuery = phalanxqueries.RewriteQuery(query, defaultSearchField, defaultAnalyzer)
+	}
+
+	resp.Valid = len(resp.Errors) == 0
+
+	return resp, nil
+}
+
+// Suggest suggests texts from the terms of the fields of the index.
+// Each node collects the candidates from its shards, and the coordinator merges them into the suggestions.
+func (s *IndexService) Suggest(ctx context.Context, req *proto.SuggestRequest) (*proto.SuggestResponse, error) {
+	indexMetadata := s.metastore.GetIndexMetadata(req.IndexName)
+	if indexMetadata == nil {
+		err := errors.ErrIndexMetadataDoesNotExist
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	isRootRequest := len(req.ShardNames) == 0
+
+	suggesters := make(map[string]phalanxsuggest.Suggester)
+	for name, suggesterReq := range req.Suggesters {
+		suggesterOpts := make(map[string]interface{})
+		if len(suggesterReq.Options) > 0 {
+			if err := json.Unmarshal(suggesterReq.Options, &suggesterOpts); err != nil {
+				s.logger.Error(err.Error(), zap.String("suggester_name", name))
+				return nil, err
+			}
+		}
+		// Fill the analyzer from the index mapping.
+		if err := phalanxsuggest.ApplyIndexMapping(suggesterOpts, indexMetadata.IndexMapping, indexMetadata.Analysis); err != nil {
+			s.logger.Error(err.Error(), zap.String("suggester_name", name))
+			return nil, err
+		}
+		suggester, err := phalanxsuggest.NewSuggester(suggesterReq.Type, suggesterOpts)
+		if err != nil {
+			s.logger.Error(err.Error(), zap.String("suggester_name", name), zap.String("suggester_type", suggesterReq.Type))
+			return nil, err
+		}
+		suggesters[name] = suggester
+	}
+
+	assignedNodes := s.assignNodes(req.IndexName, req.ShardNames)
+
+	type suggestResponse struct {
+		nodeName   string
+		shardNames []string
+		resp       *proto.SuggestResponse
+		err        error
+	}
+	responsesChan := make(chan suggestResponse, len(assignedNodes))
+
+	baseCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	eg, ctx := errgroup.WithContext(baseCtx)
+
+	for nodeName, shardNames := range assignedNodes {
+		nodeName := nodeName
+
+		request := &proto.SuggestRequest{}
+		copier.Copy(request, req)
+		request.ShardNames = shardNames
+
+		eg.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				if nodeName == s.cluster.LocalNodeName() {
+					resp := &proto.SuggestResponse{
+						IndexName:   request.IndexName,
+						Suggestions: make(map[string]*proto.Suggestion),
+					}
+
+					// local node
+					readers := make([]*bluge.Reader, 0)
+					for _, shardName := range request.ShardNames {
+						reader, err := s.indexReaders.Get(request.IndexName, shardName)
+						if err != nil {
+							s.logger.Warn(err.Error(), zap.String("index_name", request.IndexName), zap.String("shard_name", shardName))
+							continue
+						}
+						readers = append(readers, reader.BlugeReader())
+					}
+
+					// The candidates of the shards are collected, and made into the suggestions on the coordinator.
+					for name, suggester := range suggesters {
+						collected, err := suggester.Collect(readers)
+						if err != nil {
+							s.logger.Error(err.Error(), zap.String("index_name", request.IndexName), zap.String("suggester_name", name))
+							responsesChan <- suggestResponse{
+								nodeName:   nodeName,
+								shardNames: request.ShardNames,
+								resp:       nil,
+								err:        err,
+							}
+							return err
+						}
+						resp.Suggestions[name] = collected
+					}
+
+					responsesChan <- suggestResponse{
+						nodeName:   nodeName,
+						shardNames: request.ShardNames,
+						resp:       resp,
+						err:        nil,
+					}
+					return nil
+				} else {
+					metadata, err := s.cluster.NodeMetadata(nodeName)
+					if err != nil {
+						s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("node_name", nodeName))
+						responsesChan <- suggestResponse{
+							nodeName:   nodeName,
+							shardNames: request.ShardNames,
+							resp:       nil,
+							err:        err,
+						}
+						return err
+					}
+
+					nodeAddr, err := s.cluster.NodeAddress(nodeName)
+					if err != nil {
+						s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("node_name", nodeName))
+						responsesChan <- suggestResponse{
+							nodeName:   nodeName,
+							shardNames: request.ShardNames,
+							resp:       nil,
+							err:        err,
+						}
+						return err
+					}
+
+					grpcAddr := fmt.Sprintf("%s:%d", nodeAddr, metadata.GrpcPort)
+					client, ok := s.clients[grpcAddr]
+					if !ok {
+						err := errors.ErrNodeDoesNotFound
+						s.logger.Error(err.Error(), zap.String("node_name", nodeName), zap.String("grpc_address", grpcAddr))
+						responsesChan <- suggestResponse{
+							nodeName:   nodeName,
+							shardNames: request.ShardNames,
+							resp:       nil,
+							err:        err,
+						}
+						return err
+					}
+
+					remoteResp, err := client.Suggest(ctx, request)
+					if err != nil {
+						s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+						responsesChan <- suggestResponse{
+							nodeName:   nodeName,
+							shardNames: request.ShardNames,
+							resp:       nil,
+							err:        err,
+						}
+						return err
+					}
+
+					responsesChan <- suggestResponse{
+						nodeName:   nodeName,
+						shardNames: request.ShardNames,
+						resp:       remoteResp,
+						err:        nil,
+					}
+					return nil
+				}
+			}
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+	close(responsesChan)
+
+	// Merge the candidates.
+	collected := make(map[string]*proto.Suggestion)
+	for response := range responsesChan {
+		for name, suggestion := range response.resp.Suggestions {
+			collected[name] = phalanxsuggest.Merge(collected[name], suggestion)
+		}
+	}
+
+	resp := &proto.SuggestResponse{
+		IndexName:   req.IndexName,
+		Suggestions: collected,
+	}
+
+	// Make the suggestions from the candidates of all the shards.
+	if isRootRequest {
+		resp.Suggestions = make(map[string]*proto.Suggestion)
+		for name, suggester := range suggesters {
+			resp.Suggestions[name] = suggester.Reduce(collected[name])
+		}
+	}
+
+	return resp, nil
+}
+
+// Analyze analyzes the text and returns the tokens.
+// The analyzer is chosen in the following order:
+// the analyzer in the request, the analyzer of the field, the default analyzer of the index
+// and the standard analyzer.
+// Named analyzers can be used if the index is specified.
+// The text is analyzed on the node that receives the request.
+func (s *IndexService) Analyze(ctx context.Context, req *proto.AnalyzeRequest) (*proto.AnalyzeResponse, error) {
+	analysisSetting := analyzer.AnalysisSetting{}
+	analyzerSetting := analyzer.NewStandardAnalyzerSetting()
+	analyzers := analyzer.NewAnalyzerCache(analysisSetting)
+
+	if req.IndexName != "" {
+		indexMetadata := s.metastore.GetIndexMetadata(req.IndexName)
+		if indexMetadata == nil {
+			err := errors.ErrIndexMetadataDoesNotExist
+			s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+			return nil, err
+		}
+		analysisSetting = indexMetadata.Analysis
+		analyzers = indexMetadata.AnalyzerCache()
+
+		if req.Field != "" {
+			fieldType, err := indexMetadata.IndexMapping.GetFieldType(req.Field)
+			if err != nil {
+				s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("field", req.Field))
+				return nil, err
+			}
+			if fieldType != mapping.TextField {
+				err := fmt.Errorf("field is not a text field: %s", req.Field)
+				s.logger.Error(err.Error(), zap.String("index_name", req.IndexName), zap.String("field", req.Field))
+				return nil, err
+			}
+
+			// Unmapped text fields are analyzed with the standard analyzer, the same as when indexing.
+			if fieldAnalyzerSetting, err := indexMetadata.IndexMapping.GetAnalyzerSetting(req.Field); err == nil {
+				if _, err := analyzers.Get(fieldAnalyzerSetting); err == nil {
+					analyzerSetting = fieldAnalyzerSetting
+				}
+			}
+		} else if _, err := analyzers.Get(indexMetadata.DefaultAnalyzer); err == nil {
+			analyzerSetting = indexMetadata.DefaultAnalyzer
+		}
+	}
+
+	if len(req.Analyzer) > 0 {
+		analyzerSetting = analyzer.AnalyzerSetting{}
+		if err := json.Unmarshal(req.Analyzer, &analyzerSetting); err != nil {
+			s.logger.Error(err.Error())
+			return nil, err
+		}
+	}
+
+	resolvedSetting, err := analysisSetting.Resolve(analyzerSetting)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	textAnalyzer, err := analyzers.Get(resolvedSetting)
+	if err != nil {
+		s.logger.Error(err.Error(), zap.String("index_name", req.IndexName))
+		return nil, err
+	}
+
+	resp := &proto.AnalyzeResponse{
+		Tokens: make([]*proto.AnalyzedToken, 0),
+		Stages: make([]*proto.AnalyzeStage, 0),
+	}
+
+	if req.Explain {
+		tokens, stages := analyzer.AnalyzeStages(textAnalyzer, resolvedSetting, req.Text)
+		resp.Tokens = makeAnalyzedTokens(tokens)
+		for _, stage := range stages {
+			resp.Stages = append(resp.Stages, &proto.AnalyzeStage{
+				Name:   stage.Name,
+				Text:   stage.Text,
+				Tokens: makeAnalyzedTokens(stage.Tokens),
+			})
+		}
+	} else {
+		resp.Tokens = makeAnalyzedTokens(analyzer.Analyze(textAnalyzer, req.Text))
+	}
+
+	return resp, nil
+}
+
+func makeAnalyzedTokens(tokens []analyzer.AnalyzedToken) []*proto.AnalyzedToken {
+	analyzedTokens := make([]*proto.AnalyzedToken, 0, len(tokens))
+	for _, token := range tokens {
+		analyzedTokens = append(analyzedTokens, &proto.AnalyzedToken{
+			Term:     token.Term,
+			Position: int64(token.Position),
+			Start:    int64(token.Start),
+			End:      int64(token.End),
+			Type:     token.Type,
+			Keyword:  token.Keyword,
+		})
+	}
+
+	return analyzedTokens
 }
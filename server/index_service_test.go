@@ -0,0 +1,467 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mosuka/phalanx/analysis/resource"
+	phalanxcluster "github.com/mosuka/phalanx/cluster"
+	phalanxerrors "github.com/mosuka/phalanx/errors"
+	phalanxmetastore "github.com/mosuka/phalanx/metastore"
+	"github.com/mosuka/phalanx/proto"
+	"go.uber.org/zap"
+)
+
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// startIndexService starts a single node that indexes and searches all the shards.
+func startIndexService(t *testing.T) *IndexService {
+	logger := zap.NewNop()
+	dir := t.TempDir()
+
+	nodeMetadata := phalanxcluster.NodeMetadata{
+		GrpcPort: freePort(t),
+		HttpPort: freePort(t),
+		Roles:    []phalanxcluster.NodeRole{phalanxcluster.NodeRoleIndexer, phalanxcluster.NodeRoleSearcher},
+	}
+	cluster, err := phalanxcluster.NewCluster("127.0.0.1", freePort(t), nodeMetadata, true, logger)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	metastore, err := phalanxmetastore.NewMetastoreWithUri(fmt.Sprintf("file://%s", filepath.Join(dir, "metastore")), logger)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	indexService, err := NewIndexService(cluster, metastore, resource.DefaultReloadInterval, "", "", logger)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if err := indexService.Start(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if err := cluster.Start(); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	t.Cleanup(func() {
+		indexService.Stop()
+		cluster.Stop()
+		cluster.Leave(time.Second)
+		metastore.Close()
+	})
+
+	return indexService
+}
+
+// waitForWriters waits until the index writers of the shards are opened on the node.
+// The index readers are opened after the documents are added to the shards.
+func waitForWriters(t *testing.T, indexService *IndexService, indexName string, numShards int) {
+	for i := 0; i < 100; i++ {
+		if len(indexService.indexWriters.Shards(indexName)) == numShards {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("shards have not been assigned: %s\n", indexName)
+}
+
+func TestSearchWithCollapse(t *testing.T) {
+	indexService := startIndexService(t)
+	ctx := context.Background()
+
+	indexMapping := map[string]interface{}{
+		"title": map[string]interface{}{
+			"type": "text",
+			"options": map[string]interface{}{
+				"index": true,
+				"store": true,
+			},
+			"analyzer": map[string]interface{}{
+				"tokenizer": map[string]interface{}{
+					"name": "unicode",
+				},
+			},
+		},
+		"brand": map[string]interface{}{
+			"type": "text",
+			"options": map[string]interface{}{
+				"index": true,
+				"store": true,
+			},
+			"analyzer": map[string]interface{}{
+				"tokenizer": map[string]interface{}{
+					"name": "single_token",
+				},
+			},
+		},
+	}
+	indexMappingBytes, err := json.Marshal(indexMapping)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	dir := t.TempDir()
+	if _, err := indexService.CreateIndex(ctx, &proto.CreateIndexRequest{
+		IndexName:          "example",
+		IndexUri:           fmt.Sprintf("file://%s", filepath.Join(dir, "example")),
+		LockUri:            "",
+		IndexMapping:       indexMappingBytes,
+		NumShards:          2,
+		DefaultSearchField: "title",
+		DefaultAnalyzer:    []byte(`{"tokenizer": {"name": "unicode"}}`),
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	waitForWriters(t, indexService, "example", 2)
+
+	docs := []map[string]interface{}{
+		{"title": "red shirt", "brand": "Acme Wear"},
+		{"title": "red red shirt", "brand": "Acme Wear"},
+		{"title": "red hat", "brand": "Other Co"},
+		{"title": "blue shirt", "brand": "Other Co"},
+		{"title": "red socks"},
+	}
+	addRequest := &proto.AddDocumentsRequest{
+		IndexName: "example",
+		Documents: make([]*proto.Document, 0),
+	}
+	for i, doc := range docs {
+		fieldsBytes, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		addRequest.Documents = append(addRequest.Documents, &proto.Document{
+			Id:     fmt.Sprintf("%d", i+1),
+			Fields: fieldsBytes,
+		})
+	}
+	if _, err := indexService.AddDocuments(ctx, addRequest); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// The documents are searchable once the readers are reopened.
+	searchRequest := &proto.SearchRequest{
+		IndexName: "example",
+		Query: &proto.Query{
+			Type:    "match",
+			Options: []byte(`{"match": "red", "field": "title", "boost": 1.0}`),
+		},
+		Num:      10,
+		Fields:   []string{"brand"},
+		Collapse: &proto.Collapse{Field: "brand", InnerHits: 5},
+	}
+	var resp *proto.SearchResponse
+	for i := 0; i < 100; i++ {
+		resp, err = indexService.Search(ctx, searchRequest)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if resp.Hits == 4 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if resp.Hits != 4 {
+		t.Fatalf("unexpected hits: %v\n", resp.Hits)
+	}
+
+	// One document per brand, and the documents without a brand are all kept.
+	if len(resp.Documents) != 3 {
+		t.Fatalf("unexpected documents: %v\n", resp.Documents)
+	}
+	innerHits := make(map[string][]string)
+	for _, doc := range resp.Documents {
+		ids := make([]string, 0)
+		for _, innerHit := range doc.InnerHits {
+			ids = append(ids, innerHit.Id)
+		}
+
+		// The collapsed document is the best of the group.
+		if len(ids) > 0 && ids[0] != doc.Id {
+			t.Fatalf("unexpected inner hits: %v, %v\n", doc.Id, ids)
+		}
+
+		sort.Strings(ids)
+		innerHits[doc.CollapseKey] = ids
+	}
+	if !reflect.DeepEqual(innerHits["Acme Wear"], []string{"1", "2"}) {
+		t.Fatalf("unexpected inner hits: %v\n", innerHits["Acme Wear"])
+	}
+	if !reflect.DeepEqual(innerHits["Other Co"], []string{"3"}) {
+		t.Fatalf("unexpected inner hits: %v\n", innerHits["Other Co"])
+	}
+	if len(innerHits[""]) != 0 {
+		t.Fatalf("unexpected inner hits: %v\n", innerHits[""])
+	}
+	// The page past the collapsed documents is empty.
+	searchRequest.Start = 5
+	resp, err = indexService.Search(ctx, searchRequest)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(resp.Documents) != 0 {
+		t.Fatalf("unexpected documents: %v\n", resp.Documents)
+	}
+}
+
+func TestDeletePipelineInUse(t *testing.T) {
+	indexService := startIndexService(t)
+	ctx := context.Background()
+
+	pipeline, err := ioutil.ReadFile("../testdata/test_pipeline.json")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// The pipeline names are the file names in the metastore.
+	if _, err := indexService.PutPipeline(ctx, &proto.PutPipelineRequest{PipelineName: "../example", Pipeline: pipeline}); !errors.Is(err, phalanxerrors.ErrInvalidPipeline) {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	if _, err := indexService.PutPipeline(ctx, &proto.PutPipelineRequest{PipelineName: "example", Pipeline: pipeline}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	dir := t.TempDir()
+	if _, err := indexService.CreateIndex(ctx, &proto.CreateIndexRequest{
+		IndexName:       "example",
+		IndexUri:        fmt.Sprintf("file://%s", filepath.Join(dir, "example")),
+		NumShards:       1,
+		DefaultAnalyzer: []byte(`{"tokenizer": {"name": "unicode"}}`),
+		DefaultPipeline: "example",
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	waitForWriters(t, indexService, "example", 1)
+
+	if _, err := indexService.DeletePipeline(ctx, &proto.DeletePipelineRequest{PipelineName: "example"}); !errors.Is(err, phalanxerrors.ErrPipelineInUse) {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	if _, err := indexService.DeleteIndex(ctx, &proto.DeleteIndexRequest{IndexName: "example"}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	for i := 0; i < 100 && indexService.metastore.IndexMetadataExists("example"); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if _, err := indexService.DeletePipeline(ctx, &proto.DeletePipelineRequest{PipelineName: "example"}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+}
+
+func TestHybridSearch(t *testing.T) {
+	indexService := startIndexService(t)
+	ctx := context.Background()
+
+	indexMapping := []byte(`{
+		"title": {
+			"type": "text",
+			"options": {"index": true, "store": true},
+			"analyzer": {"tokenizer": {"name": "unicode"}}
+		},
+		"vector": {
+			"type": "dense_vector",
+			"dimension": 2,
+			"similarity": "cosine"
+		}
+	}`)
+
+	dir := t.TempDir()
+	if _, err := indexService.CreateIndex(ctx, &proto.CreateIndexRequest{
+		IndexName:          "example",
+		IndexUri:           fmt.Sprintf("file://%s", filepath.Join(dir, "example")),
+		IndexMapping:       indexMapping,
+		NumShards:          1,
+		DefaultSearchField: "title",
+		DefaultAnalyzer:    []byte(`{"tokenizer": {"name": "unicode"}}`),
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	waitForWriters(t, indexService, "example", 1)
+
+	docs := []string{
+		`{"title": "red shirt", "vector": [1.0, 0.0]}`,
+		`{"title": "red hat", "vector": [0.0, 1.0]}`,
+		`{"title": "blue shirt", "vector": [1.0, 0.1]}`,
+		`{"title": "green socks", "vector": [0.0, 1.0]}`,
+	}
+	addRequest := &proto.AddDocumentsRequest{
+		IndexName: "example",
+		Documents: make([]*proto.Document, 0),
+	}
+	for i, doc := range docs {
+		addRequest.Documents = append(addRequest.Documents, &proto.Document{
+			Id:     fmt.Sprintf("%d", i+1),
+			Fields: []byte(doc),
+		})
+	}
+	if _, err := indexService.AddDocuments(ctx, addRequest); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	searchRequest := &proto.SearchRequest{
+		IndexName: "example",
+		Query: &proto.Query{
+			Type:    "match",
+			Options: []byte(`{"match": "red", "field": "title", "boost": 1.0}`),
+		},
+		Knn: &proto.Query{
+			Type:    "knn",
+			Options: []byte(`{"field": "vector", "vector": [1.0, 0.0], "k": 2}`),
+		},
+		Fusion: &proto.Fusion{
+			Type:    "rrf",
+			Options: []byte(`{"window_size": 1}`),
+		},
+		Num: 1,
+	}
+	var resp *proto.SearchResponse
+	var err error
+	for i := 0; i < 100; i++ {
+		resp, err = indexService.Search(ctx, searchRequest)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if resp.Hits > 0 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// The hits are the documents matching either query, not only the fused documents.
+	if resp.Hits != 3 {
+		t.Fatalf("unexpected hits: %v\n", resp.Hits)
+	}
+	if len(resp.Documents) != 1 || resp.Documents[0].Id != "1" {
+		t.Fatalf("unexpected documents: %v\n", resp.Documents)
+	}
+
+	// The documents are ranked by the fused scores.
+	searchRequest.SortBy = "title"
+	if _, err := indexService.Search(ctx, searchRequest); err == nil {
+		t.Fatalf("expected error with sort_by\n")
+	}
+}
+
+func TestMergeDocsWithRescored(t *testing.T) {
+	docs1 := []*proto.Document{
+		{Id: "1", Score: 0.5, Rescored: true},
+		{Id: "2", Score: 3.0},
+	}
+	docs2 := []*proto.Document{
+		{Id: "3", Score: 0.8, Rescored: true},
+		{Id: "4", Score: 2.0},
+	}
+
+	// The re-scored documents are ranked ahead of the others.
+	ids := make([]string, 0)
+	for _, doc := range mergeDocs("", docs1, docs2) {
+		ids = append(ids, doc.Id)
+	}
+	if !reflect.DeepEqual(ids, []string{"3", "1", "2", "4"}) {
+		t.Fatalf("unexpected documents: %v\n", ids)
+	}
+}
+
+func TestSearchWithRescore(t *testing.T) {
+	indexService := startIndexService(t)
+	ctx := context.Background()
+
+	indexMapping := []byte(`{
+		"title": {
+			"type": "text",
+			"options": {"index": true, "store": true},
+			"analyzer": {"tokenizer": {"name": "unicode"}}
+		}
+	}`)
+
+	dir := t.TempDir()
+	if _, err := indexService.CreateIndex(ctx, &proto.CreateIndexRequest{
+		IndexName:          "example",
+		IndexUri:           fmt.Sprintf("file://%s", filepath.Join(dir, "example")),
+		IndexMapping:       indexMapping,
+		NumShards:          2,
+		DefaultSearchField: "title",
+		DefaultAnalyzer:    []byte(`{"tokenizer": {"name": "unicode"}}`),
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	waitForWriters(t, indexService, "example", 2)
+
+	addRequest := &proto.AddDocumentsRequest{
+		IndexName: "example",
+		Documents: make([]*proto.Document, 0),
+	}
+	for i := 0; i < 8; i++ {
+		addRequest.Documents = append(addRequest.Documents, &proto.Document{
+			Id:     fmt.Sprintf("%d", i+1),
+			Fields: []byte(`{"title": "red shirt"}`),
+		})
+	}
+	if _, err := indexService.AddDocuments(ctx, addRequest); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	searchRequest := &proto.SearchRequest{
+		IndexName: "example",
+		Query: &proto.Query{
+			Type:    "match",
+			Options: []byte(`{"match": "red", "field": "title", "boost": 1.0}`),
+		},
+		Rescore: &proto.Rescore{
+			Query: &proto.Query{
+				Type:    "match",
+				Options: []byte(`{"match": "shirt", "field": "title", "boost": 1.0}`),
+			},
+			Options: []byte(`{"window_size": 1}`),
+		},
+		Num: 10,
+	}
+	var resp *proto.SearchResponse
+	var err error
+	for i := 0; i < 100; i++ {
+		resp, err = indexService.Search(ctx, searchRequest)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+		if resp.Hits == 8 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if len(resp.Documents) != 8 {
+		t.Fatalf("unexpected documents: %v\n", resp.Documents)
+	}
+
+	// The top document of each shard is re-scored, and they are ranked ahead of the others.
+	for i, doc := range resp.Documents {
+		if doc.Rescored != (i < 2) {
+			t.Fatalf("unexpected rescored of %s at %d: %v\n", doc.Id, i, doc.Rescored)
+		}
+	}
+}
+
+func TestNewIndexServiceWithInvalidResourceReloadInterval(t *testing.T) {
+	if _, err := NewIndexService(nil, nil, 0, "", "", zap.NewNop()); err == nil {
+		t.Fatalf("expected error with zero resource reload interval\n")
+	}
+}
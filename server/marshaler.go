@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/mosuka/phalanx/mapping"
@@ -125,34 +126,72 @@ func (m *Marshaler) Marshal(v interface{}) ([]byte, error) {
 		resp["searcher_assignment"] = searcherAssignment
 
 		return json.Marshal(resp)
-	case *proto.SearchResponse:
+	case *proto.AddDocumentsResponse:
 		resp := make(map[string]interface{})
 
-		resp["index_name"] = value.IndexName
-
-		resp["hits"] = value.Hits
-
-		docs := make([]map[string]interface{}, 0)
-		for _, doc := range value.Documents {
-			var fields map[string]interface{}
-			if err := json.Unmarshal(doc.Fields, &fields); err != nil {
+		errors := make([]map[string]interface{}, 0)
+		for _, docErr := range value.Errors {
+			var receivedValue interface{}
+			if err := json.Unmarshal(docErr.ReceivedValue, &receivedValue); err != nil {
 				return nil, err
 			}
 
-			var highlights map[string][]string
-			if err := json.Unmarshal(doc.Highlights, &highlights); err != nil {
-				return nil, err
-			}
+			errors = append(errors, map[string]interface{}{
+				"id":             docErr.Id,
+				"field":          docErr.Field,
+				"expected_type":  docErr.ExpectedType,
+				"received_value": receivedValue,
+				"reason":         docErr.Reason,
+			})
+		}
+		resp["errors"] = errors
 
-			docMap := map[string]interface{}{
-				"id":         doc.Id,
-				"score":      doc.Score,
-				"timestamp":  doc.Timestamp,
-				"fields":     fields,
-				"highlights": highlights,
+		return json.Marshal(resp)
+	case *proto.AnalyzeResponse:
+		resp := make(map[string]interface{})
+
+		resp["tokens"] = makeAnalyzedTokenMaps(value.Tokens)
+
+		if len(value.Stages) > 0 {
+			stages := make([]map[string]interface{}, 0)
+			for _, stage := range value.Stages {
+				stageMap := map[string]interface{}{
+					"name": stage.Name,
+				}
+				// Char filters output text, and the others output tokens.
+				if strings.HasPrefix(stage.Name, "char_filter") {
+					stageMap["text"] = stage.Text
+				} else {
+					stageMap["tokens"] = makeAnalyzedTokenMaps(stage.Tokens)
+				}
+				stages = append(stages, stageMap)
 			}
+			resp["stages"] = stages
+		}
 
-			docs = append(docs, docMap)
+		return json.Marshal(resp)
+	case *proto.GetPipelineResponse:
+		resp := make(map[string]interface{})
+
+		resp["pipeline_name"] = value.PipelineName
+
+		var pipeline map[string]interface{}
+		if err := json.Unmarshal(value.Pipeline, &pipeline); err != nil {
+			return nil, err
+		}
+		resp["pipeline"] = pipeline
+
+		return json.Marshal(resp)
+	case *proto.SearchResponse:
+		resp := make(map[string]interface{})
+
+		resp["index_name"] = value.IndexName
+
+		resp["hits"] = value.Hits
+
+		docs, err := makeDocumentMaps(value.Documents)
+		if err != nil {
+			return nil, err
 		}
 		resp["documents"] = docs
 
@@ -165,6 +204,74 @@ func (m *Marshaler) Marshal(v interface{}) ([]byte, error) {
 			resp["aggregations"].(map[string]interface{})[aggName] = values
 		}
 
+		return json.Marshal(resp)
+	case *proto.ExplainResponse:
+		resp := make(map[string]interface{})
+
+		resp["index_name"] = value.IndexName
+		resp["id"] = value.Id
+		resp["matched"] = value.Matched
+
+		var explanation map[string]interface{}
+		if err := json.Unmarshal(value.Explanation, &explanation); err != nil {
+			return nil, err
+		}
+		resp["explanation"] = explanation
+
+		return json.Marshal(resp)
+	case *proto.ValidateQueryResponse:
+		resp := make(map[string]interface{})
+
+		resp["valid"] = value.Valid
+
+		queryErrors := make([]map[string]interface{}, 0)
+		for _, queryError := range value.Errors {
+			queryErrorMap := map[string]interface{}{
+				"path":   queryError.Path,
+				"type":   queryError.Type,
+				"reason": queryError.Reason,
+			}
+			if queryError.Field != "" {
+				queryErrorMap["field"] = queryError.Field
+			}
+			queryErrors = append(queryErrors, queryErrorMap)
+		}
+		resp["errors"] = queryErrors
+
+		if value.RewrittenQuery != "" {
+			resp["rewritten_query"] = value.RewrittenQuery
+		}
+
+		return json.Marshal(resp)
+	case *proto.SuggestResponse:
+		resp := make(map[string]interface{})
+
+		resp["index_name"] = value.IndexName
+
+		suggestions := make(map[string]interface{})
+		for name, suggestion := range value.Suggestions {
+			entries := make([]map[string]interface{}, 0)
+			for _, entry := range suggestion.Entries {
+				options := make([]map[string]interface{}, 0)
+				for _, option := range entry.Options {
+					options = append(options, map[string]interface{}{
+						"text":  option.Text,
+						"score": option.Score,
+						"freq":  option.Freq,
+					})
+				}
+				entries = append(entries, map[string]interface{}{
+					"text":    entry.Text,
+					"offset":  entry.Offset,
+					"length":  entry.Length,
+					"freq":    entry.Freq,
+					"options": options,
+				})
+			}
+			suggestions[name] = entries
+		}
+		resp["suggestions"] = suggestions
+
 		return json.Marshal(resp)
 	default:
 		return json.Marshal(value)
@@ -210,7 +317,8 @@ func (m *Marshaler) Unmarshal(data []byte, v interface{}) error {
 			value.DefaultSearchField = defaultSearchField
 		}
 
-		if defaultAnalyzer, ok := m["default_analyzer"].(map[string]interface{}); ok {
+		switch defaultAnalyzer := m["default_analyzer"].(type) {
+		case map[string]interface{}, string:
 			defaultAnalyuzerBytes, err := json.Marshal(defaultAnalyzer)
 			if err != nil {
 				return err
@@ -218,6 +326,86 @@ func (m *Marshaler) Unmarshal(data []byte, v interface{}) error {
 			value.DefaultAnalyzer = defaultAnalyuzerBytes
 		}
 
+		if analysis, ok := m["analysis"].(map[string]interface{}); ok {
+			analysisBytes, err := json.Marshal(analysis)
+			if err != nil {
+				return err
+			}
+			value.Analysis = analysisBytes
+		}
+
+		if defaultPipeline, ok := m["default_pipeline"].(string); ok {
+			value.DefaultPipeline = defaultPipeline
+		}
+
+		switch dynamic := m["dynamic"].(type) {
+		case string:
+			value.Dynamic = dynamic
+		case bool:
+			if dynamic {
+				value.Dynamic = string(mapping.DynamicTrue)
+			} else {
+				value.Dynamic = string(mapping.DynamicIgnore)
+			}
+		case nil:
+		default:
+			return fmt.Errorf("dynamic is unexpected: %v", m["dynamic"])
+		}
+
+		return nil
+	case *proto.AnalyzeRequest:
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+
+		if indexName, ok := m["index_name"].(string); ok {
+			value.IndexName = indexName
+		}
+
+		if field, ok := m["field"].(string); ok {
+			value.Field = field
+		}
+
+		switch analyzer := m["analyzer"].(type) {
+		case map[string]interface{}, string:
+			analyzerBytes, err := json.Marshal(analyzer)
+			if err != nil {
+				return err
+			}
+			value.Analyzer = analyzerBytes
+		case nil:
+		default:
+			return fmt.Errorf("analyzer is unexpected: %v", m["analyzer"])
+		}
+
+		text, ok := m["text"].(string)
+		if !ok {
+			return fmt.Errorf("text is unexpected: %v", m["text"])
+		}
+		value.Text = text
+
+		if explain, ok := m["explain"].(bool); ok {
+			value.Explain = explain
+		}
+
+		return nil
+	case *proto.PutPipelineRequest:
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+
+		if pipelineName, ok := m["pipeline_name"].(string); ok {
+			value.PipelineName = pipelineName
+		}
+
+		pipelineBytes, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		value.Pipeline = pipelineBytes
+
 		return nil
 	case *proto.SearchRequest:
 		var m map[string]interface{}
@@ -295,6 +483,114 @@ func (m *Marshaler) Unmarshal(data []byte, v interface{}) error {
 			}
 		}
 
+		if knn, ok := m["knn"].(map[string]interface{}); ok {
+			knnType, ok := knn["type"].(string)
+			if !ok {
+				return fmt.Errorf("knn type is not a string: %v", knn["type"])
+			}
+			knnOpts, ok := knn["options"].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("knn options is not a map: %v", knn["options"])
+			}
+			knnOptsBytes, err := json.Marshal(knnOpts)
+			if err != nil {
+				return err
+			}
+			value.Knn = &proto.Query{
+				Type:    knnType,
+				Options: knnOptsBytes,
+			}
+		}
+
+		if fusion, ok := m["fusion"].(map[string]interface{}); ok {
+			fusionType, ok := fusion["type"].(string)
+			if !ok {
+				return fmt.Errorf("fusion type is not a string: %v", fusion["type"])
+			}
+			fusionOptsBytes := []byte("{}")
+			if fusionOpts, ok := fusion["options"].(map[string]interface{}); ok {
+				bytes, err := json.Marshal(fusionOpts)
+				if err != nil {
+					return err
+				}
+				fusionOptsBytes = bytes
+			}
+			value.Fusion = &proto.Fusion{
+				Type:    fusionType,
+				Options: fusionOptsBytes,
+			}
+		}
+
+		if postFilter, ok := m["post_filter"].(map[string]interface{}); ok {
+			postFilterType, ok := postFilter["type"].(string)
+			if !ok {
+				return fmt.Errorf("post_filter type is not a string: %v", postFilter["type"])
+			}
+			postFilterOpts, ok := postFilter["options"].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("post_filter options is not a map: %v", postFilter["options"])
+			}
+			postFilterOptsBytes, err := json.Marshal(postFilterOpts)
+			if err != nil {
+				return err
+			}
+			value.PostFilter = &proto.Query{
+				Type:    postFilterType,
+				Options: postFilterOptsBytes,
+			}
+		}
+
+		if rescore, ok := m["rescore"].(map[string]interface{}); ok {
+			rescoreQuery, ok := rescore["query"].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("rescore query is not a map: %v", rescore["query"])
+			}
+			rescoreQueryType, ok := rescoreQuery["type"].(string)
+			if !ok {
+				return fmt.Errorf("rescore query type is not a string: %v", rescoreQuery["type"])
+			}
+			rescoreQueryOpts, ok := rescoreQuery["options"].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("rescore query options is not a map: %v", rescoreQuery["options"])
+			}
+			rescoreQueryOptsBytes, err := json.Marshal(rescoreQueryOpts)
+			if err != nil {
+				return err
+			}
+			rescoreOptsBytes := []byte("{}")
+			if rescoreOpts, ok := rescore["options"].(map[string]interface{}); ok {
+				bytes, err := json.Marshal(rescoreOpts)
+				if err != nil {
+					return err
+				}
+				rescoreOptsBytes = bytes
+			}
+			value.Rescore = &proto.Rescore{
+				Query: &proto.Query{
+					Type:    rescoreQueryType,
+					Options: rescoreQueryOptsBytes,
+				},
+				Options: rescoreOptsBytes,
+			}
+		}
+
+		if collapse, ok := m["collapse"].(map[string]interface{}); ok {
+			field, ok := collapse["field"].(string)
+			if !ok {
+				return fmt.Errorf("collapse field is not a string: %v", collapse["field"])
+			}
+			value.Collapse = &proto.Collapse{
+				Field: field,
+			}
+			if innerHits, ok := collapse["inner_hits"].(float64); ok {
+				value.Collapse.InnerHits = int32(innerHits)
+			}
+		}
+
+		if explain, ok := m["explain"].(bool); ok {
+			value.Explain = explain
+		}
+
 		if highlights, ok := m["highlights"].(map[string]interface{}); ok {
 			value.Highlights = make(map[string]*proto.HighlightRequest)
 			for fieldName, highlightReq := range highlights {
@@ -330,12 +626,172 @@ func (m *Marshaler) Unmarshal(data []byte, v interface{}) error {
 			}
 		}
 
+		return nil
+	case *proto.ExplainRequest:
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+
+		if indexName, ok := m["index_name"].(string); ok {
+			value.IndexName = indexName
+		}
+
+		if id, ok := m["id"].(string); ok {
+			value.Id = id
+		}
+
+		query, ok := m["query"].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("query is unexpected: %v", m["query"])
+		}
+		queryType, ok := query["type"].(string)
+		if !ok {
+			return fmt.Errorf("query type is not a string: %v", query["type"])
+		}
+		queryOpts, ok := query["options"].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("query options is not a map: %v", query["options"])
+		}
+		queryOptsBytes, err := json.Marshal(queryOpts)
+		if err != nil {
+			return err
+		}
+		value.Query = &proto.Query{
+			Type:    queryType,
+			Options: queryOptsBytes,
+		}
+
+		return nil
+	case *proto.ValidateQueryRequest:
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+
+		if indexName, ok := m["index_name"].(string); ok {
+			value.IndexName = indexName
+		}
+
+		// Malformed queries are passed through, so that they are reported as validation errors.
+		value.Query = &proto.Query{}
+		if query, ok := m["query"].(map[string]interface{}); ok {
+			if queryType, ok := query["type"].(string); ok {
+				value.Query.Type = queryType
+			}
+			if queryOpts, ok := query["options"]; ok {
+				queryOptsBytes, err := json.Marshal(queryOpts)
+				if err != nil {
+					return err
+				}
+				value.Query.Options = queryOptsBytes
+			}
+		}
+
+		return nil
+	case *proto.SuggestRequest:
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+
+		if indexName, ok := m["index_name"].(string); ok {
+			value.IndexName = indexName
+		}
+
+		if suggesters, ok := m["suggesters"].(map[string]interface{}); ok {
+			value.Suggesters = make(map[string]*proto.Suggester)
+			for name, suggester := range suggesters {
+				if sug, ok := suggester.(map[string]interface{}); ok {
+					sugType, ok := sug["type"].(string)
+					if !ok {
+						return fmt.Errorf("suggester type is not a string: %v", sug["type"])
+					}
+					sugOpts, ok := sug["options"].(map[string]interface{})
+					if !ok {
+						return fmt.Errorf("suggester options is not a map: %v", sug["options"])
+					}
+					sugOptsBytes, err := json.Marshal(sugOpts)
+					if err != nil {
+						return err
+					}
+					value.Suggesters[name] = &proto.Suggester{
+						Type:    sugType,
+						Options: sugOptsBytes,
+					}
+				}
+			}
+		}
+
 		return nil
 	default:
 		return json.Unmarshal(data, value)
 	}
 }
 
+func makeDocumentMaps(docs []*proto.Document) ([]map[string]interface{}, error) {
+	docMaps := make([]map[string]interface{}, 0)
+	for _, doc := range docs {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(doc.Fields, &fields); err != nil {
+			return nil, err
+		}
+
+		var highlights map[string][]string
+		if err := json.Unmarshal(doc.Highlights, &highlights); err != nil {
+			return nil, err
+		}
+
+		docMap := map[string]interface{}{
+			"id":         doc.Id,
+			"score":      doc.Score,
+			"timestamp":  doc.Timestamp,
+			"fields":     fields,
+			"highlights": highlights,
+		}
+
+		if len(doc.Explanation) > 0 {
+			var explanation map[string]interface{}
+			if err := json.Unmarshal(doc.Explanation, &explanation); err != nil {
+				return nil, err
+			}
+			docMap["explanation"] = explanation
+		}
+
+		if doc.CollapseKey != "" {
+			docMap["collapse_key"] = doc.CollapseKey
+		}
+
+		if len(doc.InnerHits) > 0 {
+			innerHits, err := makeDocumentMaps(doc.InnerHits)
+			if err != nil {
+				return nil, err
+			}
+			docMap["inner_hits"] = innerHits
+		}
+
+		docMaps = append(docMaps, docMap)
+	}
+
+	return docMaps, nil
+}
+
+func makeAnalyzedTokenMaps(tokens []*proto.AnalyzedToken) []map[string]interface{} {
+	tokenMaps := make([]map[string]interface{}, 0)
+	for _, token := range tokens {
+		tokenMaps = append(tokenMaps, map[string]interface{}{
+			"term":     token.Term,
+			"position": token.Position,
+			"start":    token.Start,
+			"end":      token.End,
+			"type":     token.Type,
+			"keyword":  token.Keyword,
+		})
+	}
+
+	return tokenMaps
+}
+
 func (m *Marshaler) NewDecoder(r io.Reader) runtime.Decoder {
 	return runtime.DecoderFunc(
 		func(v interface{}) error {